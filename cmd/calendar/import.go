@@ -11,6 +11,7 @@ import (
 	"taskflow/internal/gcal"
 	"taskflow/internal/ics"
 	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
 
 	"github.com/spf13/cobra"
 )
@@ -74,7 +75,7 @@ var IcsCmd = &cobra.Command{
 		}
 
 		storagePath := config.GetStoragePath()
-		s, err := storage.NewStorage(storagePath)
+		s, err := taskstore.Open(storagePath)
 		if err != nil {
 			fmt.Printf("Error creating storage: %v\n", err)
 			return
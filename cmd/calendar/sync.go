@@ -5,6 +5,8 @@ import (
 	"taskflow/internal/config"
 	"taskflow/internal/models"
 	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -28,7 +30,7 @@ var SyncCmd = &cobra.Command{
 		}
 
 		taskStoragePath := config.GetStoragePath()
-		taskStorage, err := storage.NewStorage(taskStoragePath)
+		taskStorage, err := taskstore.Open(taskStoragePath)
 		if err != nil {
 			fmt.Printf("Error creating task storage: %v\n", err)
 			return
@@ -43,9 +45,10 @@ var SyncCmd = &cobra.Command{
 		var newTasks []models.Task
 		for _, event := range events {
 			task := models.Task{
-				ID:      uuid.New().String(),
-				Title:   event.Title,
-				DueDate: event.StartTime,
+				ID:        uuid.New().String(),
+				Title:     event.Title,
+				DueDate:   event.StartTime,
+				CreatedAt: time.Now().UTC().Format(time.RFC3339),
 			}
 			newTasks = append(newTasks, task)
 		}
@@ -63,4 +66,4 @@ var SyncCmd = &cobra.Command{
 
 func init() {
 	CalendarCmd.AddCommand(SyncCmd)
-}
\ No newline at end of file
+}
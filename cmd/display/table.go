@@ -5,11 +5,13 @@ import (
 	"taskflow/internal/config"
 	"taskflow/internal/storage"
 	"taskflow/internal/table"
+	taskspkg "taskflow/internal/tasks"
 
 	"github.com/spf13/cobra"
 )
 
 var compact bool
+var tableSortBy string
 
 var TableCmd = &cobra.Command{
 	Use:   "table",
@@ -28,11 +30,19 @@ var TableCmd = &cobra.Command{
 			return
 		}
 
+		keys, err := taskspkg.ParseSort(tableSortBy)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		taskspkg.Sort(tasks, keys)
+
 		table.RenderTasks(tasks, compact)
 	},
 }
 
 func init() {
 	TableCmd.Flags().BoolVar(&compact, "compact", false, "Show compact table (status, title)")
+	TableCmd.Flags().StringVar(&tableSortBy, "sort-by", "", "Comma-separated sort keys, '-' for descending (e.g. due,-priority,title)")
 	DisplayCmd.AddCommand(TableCmd)
 }
\ No newline at end of file
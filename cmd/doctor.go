@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/schema"
+	"taskflow/internal/storage"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the tasks and archive files for problems and optionally repair them",
+	Long: `Validate the tasks and archive files: YAML syntax, field types, unknown
+statuses and priorities, duplicate or missing IDs, unreadable dates, estimates
+and timestamps, and dependencies on tasks that do not exist. Problems are
+reported as file:line:column.
+
+With --fix the repairable problems are fixed (a .bak copy is kept, so
+"task undo" restores the tasks file) and older files are migrated to the
+current schema version.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		files := []string{config.GetStoragePath()}
+		if arch := config.GetArchiveFilePath(); arch != "" {
+			if _, err := os.Stat(arch); err == nil {
+				files = append(files, arch)
+			}
+		}
+		ids := make([]map[string]bool, len(files))
+		for i, path := range files {
+			ids[i] = fileIDs(path)
+		}
+
+		total, fixable := 0, 0
+		for i, path := range files {
+			data, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				fmt.Printf("Error reading %s: %v\n", path, err)
+				return
+			}
+			known := otherIDs(ids, i)
+			problems := schema.Validate(data, schema.Options{KnownIDs: known})
+			for _, p := range problems {
+				fmt.Printf("%s:%s\n", path, p)
+				total++
+				if p.Fixable {
+					fixable++
+				}
+			}
+			if doctorFix && len(problems) > 0 {
+				if err := repairFile(path, data, known); err != nil {
+					fmt.Printf("Error repairing %s: %v\n", path, err)
+					return
+				}
+			}
+		}
+
+		switch {
+		case total == 0:
+			fmt.Println("No problems found.")
+		case doctorFix:
+			fmt.Printf("%d problem(s) found, %d repaired; %d need manual edits.\n", total, fixable, total-fixable)
+		default:
+			fmt.Printf("%d problem(s) found, %d can be repaired with --fix.\n", total, fixable)
+		}
+	},
+}
+
+// repairFile migrates and repairs one file, printing each change.
+func repairFile(path string, data []byte, known map[string]bool) error {
+	var list models.TaskList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("cannot be parsed, fix it by hand first: %w", err)
+	}
+	migrated, err := schema.Migrate(&list)
+	if err != nil {
+		return err
+	}
+	fixes := schema.Repair(&list, known)
+	if !migrated && len(fixes) == 0 {
+		return nil
+	}
+	st, err := storage.NewStorage(path)
+	if err != nil {
+		return err
+	}
+	if err := st.Backup(); err != nil {
+		return err
+	}
+	if err := st.Rewrite(list.Tasks); err != nil {
+		return err
+	}
+	if migrated {
+		fmt.Printf("%s: migrated to schema version %d\n", path, schema.Version)
+	}
+	for _, f := range fixes {
+		fmt.Printf("%s: %s\n", path, f)
+	}
+	return nil
+}
+
+// fileIDs returns the task IDs in a tasks file, or none if it cannot be read.
+func fileIDs(path string) map[string]bool {
+	ids := map[string]bool{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ids
+	}
+	var list models.TaskList
+	_ = yaml.Unmarshal(data, &list)
+	for _, t := range list.Tasks {
+		ids[t.ID] = true
+	}
+	return ids
+}
+
+func otherIDs(ids []map[string]bool, skip int) map[string]bool {
+	out := map[string]bool{}
+	for i, m := range ids {
+		if i == skip {
+			continue
+		}
+		for id := range m {
+			out[id] = true
+		}
+	}
+	return out
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair what can be repaired (keeps a .bak copy)")
+}
@@ -0,0 +1,89 @@
+package cmd_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"taskflow/cmd"
+	"taskflow/internal/config"
+	"taskflow/internal/storage"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func runDoctor(t *testing.T, args ...string) string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	root := cmd.NewRootCmd()
+	root.SetArgs(append([]string{"doctor"}, args...))
+	_ = root.Execute()
+	w.Close()
+	os.Stdout = old
+	out, _ := io.ReadAll(r)
+	if c, _, err := root.Find([]string{"doctor"}); err == nil {
+		_ = c.Flags().Set("fix", "false")
+	}
+	return string(out)
+}
+
+func TestDoctorReportsAndRepairs(t *testing.T) {
+	home := t.TempDir()
+	os.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", config.AppName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+	path := config.GetStoragePath()
+	content := `tasks:
+  - id: a
+    title: First
+    due: 2025/10/05
+    depends_on: [ghost]
+  - id: a
+    title: Second
+    status: bogus
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := runDoctor(t)
+	for _, want := range []string{
+		path + `:4:10: task a: due: invalid date "2025/10/05"`,
+		path + `:5:18: task a: depends_on: unknown task "ghost"`,
+		path + ":6:9: task a: id: duplicate id",
+		path + `:8:13: task a: status: unknown status "bogus"`,
+		"4 problem(s) found, 3 can be repaired with --fix.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output:\n%s", want, out)
+		}
+	}
+
+	out = runDoctor(t, "--fix")
+	if !strings.Contains(out, "migrated to schema version") || !strings.Contains(out, "3 repaired; 1 need manual edits") {
+		t.Fatalf("unexpected fix output:\n%s", out)
+	}
+	st, _ := storage.NewStorage(path)
+	ts, err := st.ReadTasks()
+	if err != nil || len(ts) != 2 {
+		t.Fatalf("read repaired file: %v %v", ts, err)
+	}
+	if ts[0].DueDate != "2025-10-05" || len(ts[0].DependsOn) != 0 || ts[1].ID == "a" {
+		t.Fatalf("not repaired: %+v", ts)
+	}
+	if _, err := os.Stat(path + ".bak"); err != nil {
+		t.Fatalf("expected backup: %v", err)
+	}
+	if out := runDoctor(t); !strings.Contains(out, "1 problem(s) found, 0 can be repaired") {
+		t.Fatalf("expected only the manual problem left:\n%s", out)
+	}
+}
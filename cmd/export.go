@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/exporter"
+	"taskflow/internal/storage"
+	"taskflow/internal/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tasks as todo.txt, Markdown, Taskwarrior JSON, CSV or HTML",
+	Long: `Export tasks as todo.txt, Markdown, Taskwarrior JSON, CSV or HTML.
+
+The filters are those of 'task list'. Archived tasks are included with
+--include-archive. The todo.txt and Taskwarrior output can be read back with
+'taskflow import', and by Taskwarrior's 'task import'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		write, ok := exporter.Formats[format]
+		if !ok {
+			fmt.Printf("Unknown format %q: use %s\n", format, exportFormats())
+			return
+		}
+
+		s, err := storage.NewStorage(config.GetStoragePath())
+		if err != nil {
+			fmt.Printf("Error creating storage: %v\n", err)
+			return
+		}
+		all, err := s.ReadTasks()
+		if err != nil {
+			fmt.Printf("Error reading tasks: %v\n", err)
+			return
+		}
+		if archive, _ := cmd.Flags().GetBool("include-archive"); archive {
+			if archivePath := config.GetArchiveFilePath(); archivePath != "" {
+				a, _ := storage.NewStorage(archivePath)
+				archived, err := a.ReadTasks()
+				if err != nil {
+					fmt.Printf("Error reading archive: %v\n", err)
+					return
+				}
+				all = append(all, archived...)
+			}
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		priority, _ := cmd.Flags().GetString("priority")
+		tagsFlag, _ := cmd.Flags().GetString("tags")
+		contains, _ := cmd.Flags().GetString("contains")
+		containsFields, _ := cmd.Flags().GetString("contains-fields")
+		opts := tasks.FilterOptions{
+			Status:         status,
+			Priority:       priority,
+			Tags:           splitList(tagsFlag),
+			ContainsWords:  strings.Fields(strings.ToLower(contains)),
+			ContainsFields: map[string]bool{},
+		}
+		for _, f := range splitList(strings.ToLower(containsFields)) {
+			opts.ContainsFields[f] = true
+		}
+		filtered := tasks.ApplyFilters(all, opts)
+
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		keys, err := tasks.ParseSort(sortBy)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		tasks.Sort(filtered, keys)
+
+		var out bytes.Buffer
+		if err := write(&out, filtered); err != nil {
+			fmt.Printf("Error exporting tasks: %v\n", err)
+			return
+		}
+		path, _ := cmd.Flags().GetString("output")
+		if path == "" || path == "-" {
+			os.Stdout.Write(out.Bytes())
+			return
+		}
+		if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", path, err)
+			return
+		}
+		fmt.Printf("Exported %d task(s) to %s\n", len(filtered), path)
+	},
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func exportFormats() string {
+	names := make([]string, 0, len(exporter.Formats))
+	for name := range exporter.Formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	exportCmd.Flags().StringP("format", "f", "markdown", "Output format: csv, html, markdown, taskwarrior or todotxt")
+	exportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	exportCmd.Flags().Bool("include-archive", false, "Also export archived tasks")
+	exportCmd.Flags().String("status", "", "Filter by status")
+	exportCmd.Flags().String("priority", "", "Filter by priority")
+	exportCmd.Flags().String("tags", "", "Filter by tags (comma-separated)")
+	exportCmd.Flags().String("contains", "", "Filter by words contained in fields (space-separated)")
+	exportCmd.Flags().String("contains-fields", "title", "Comma-separated list of fields to search: title,description,notes,link,tags")
+	exportCmd.Flags().String("sort-by", "", "Comma-separated sort keys, '-' for descending (see 'task list --help')")
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"taskflow/internal/config"
+	"taskflow/internal/importer"
+	"taskflow/internal/taskstore"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importDryRun bool
+	importUpdate bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tasks from Taskwarrior, todo.txt, Todoist or Trello",
+	Long: `Import tasks from Taskwarrior, todo.txt, Todoist or Trello.
+
+Each imported task remembers where it came from, so importing the same
+export again only adds what is new. Use --update to also refresh the tasks
+imported before with the export's titles, dates, statuses and tags.`,
+}
+
+// newImportCmd returns the subcommand importing one format.
+func newImportCmd(format, what string) *cobra.Command {
+	return &cobra.Command{
+		Use:   format + " [file]",
+		Short: "Import " + what + " (from stdin without a file or with -)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var in io.Reader = os.Stdin
+			if len(args) == 1 && args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					fmt.Printf("Error opening file: %v\n", err)
+					return
+				}
+				defer f.Close()
+				in = f
+			}
+			runImport(format, in)
+		},
+	}
+}
+
+func runImport(format string, in io.Reader) {
+	res, err := importer.Formats[format](in, time.Now())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	s, err := taskstore.Open(config.GetStoragePath())
+	if err != nil {
+		fmt.Printf("Error creating storage: %v\n", err)
+		return
+	}
+	all, err := s.ReadTasks()
+	if err != nil {
+		fmt.Printf("Error reading tasks: %v\n", err)
+		return
+	}
+	merged, st := importer.Merge(all, res.Tasks, importUpdate)
+
+	if importDryRun {
+		fmt.Print("Dry run: ")
+	}
+	fmt.Printf("%d new task(s)", st.Added)
+	if importUpdate {
+		fmt.Printf(", %d updated", st.Updated)
+	}
+	fmt.Printf(", %d already imported", st.Unchanged)
+	if res.Skipped > 0 {
+		fmt.Printf(", %d skipped (deleted or archived)", res.Skipped)
+	}
+	fmt.Println(".")
+	if len(res.Unmapped) > 0 {
+		fmt.Println("Unmapped fields (left out, or kept in the title for todo.txt):")
+		for _, name := range res.UnmappedFields() {
+			fmt.Printf("  %-20s %d task(s)\n", name, res.Unmapped[name])
+		}
+	}
+	if importDryRun || st.Added+st.Updated == 0 {
+		return
+	}
+
+	// Back up first so 'task undo' reverts the whole import.
+	if err := s.Backup(); err != nil {
+		fmt.Printf("Warning: failed to create backup: %v\n", err)
+	}
+	if err := s.WriteTasks(merged); err != nil {
+		fmt.Printf("Error writing tasks: %v\n", err)
+	}
+}
+
+func init() {
+	importCmd.PersistentFlags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without writing")
+	importCmd.PersistentFlags().BoolVar(&importUpdate, "update", false, "Refresh tasks imported before from the export")
+	importCmd.AddCommand(newImportCmd("taskwarrior", "the JSON printed by 'task export'"))
+	importCmd.AddCommand(newImportCmd("todotxt", "a todo.txt file"))
+	importCmd.AddCommand(newImportCmd("todoist", "Todoist tasks as JSON (Sync or REST API)"))
+	importCmd.AddCommand(newImportCmd("trello", "a Trello board exported as JSON"))
+}
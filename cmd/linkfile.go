@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"taskflow/internal/config"
+	"taskflow/internal/linkfile"
+	"taskflow/internal/statefile"
+	"taskflow/internal/taskstore"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	linkFileUnlink bool
+	linkFileList   bool
+	linkFileDryRun bool
+)
+
+var linkFileCmd = &cobra.Command{
+	Use:   "link-file [path...]",
+	Short: "Sync Markdown checklists with tasks",
+	Long: `Sync Markdown checklists with tasks.
+
+Each "- [ ]" or "- [x]" item of a linked file is a task, tagged with the
+file's name. Inline #tags, !priority and due:YYYY-MM-DD set those fields.
+Checking an item off completes its task; completing or reopening the task
+in taskflow flips the checkbox on the item's line. Edits made on both sides
+since the last sync are merged; conflicting ones are reported and left
+for you to resolve.
+
+The first run on a file links it. Without paths, every linked file is
+synced, e.g. from 'taskflow schedule'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		state, err := linkfile.LoadState(config.GetLinkStatePath())
+		if err != nil {
+			fmt.Printf("Error reading linked files: %v\n", err)
+			return
+		}
+		if linkFileList {
+			for _, p := range state.Linked() {
+				fmt.Printf("%s (%d items)\n", p, len(state.Files[p]))
+			}
+			return
+		}
+
+		paths := state.Linked()
+		if len(args) > 0 {
+			paths = nil
+			for _, a := range args {
+				p, err := filepath.Abs(a)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					return
+				}
+				paths = append(paths, p)
+			}
+		}
+		if len(paths) == 0 {
+			fmt.Println("No linked files; link one with 'taskflow link-file <path>'.")
+			return
+		}
+
+		if linkFileUnlink {
+			for _, p := range paths {
+				delete(state.Files, p)
+				fmt.Printf("Unlinked %s; its tasks are kept.\n", p)
+			}
+			if err := state.Save(); err != nil {
+				fmt.Printf("Error saving linked files: %v\n", err)
+			}
+			return
+		}
+
+		for _, p := range paths {
+			if err := syncLinkedFile(state, p); err != nil {
+				fmt.Printf("Error syncing %s: %v\n", p, err)
+			}
+		}
+	},
+}
+
+// syncLinkedFile syncs one file and records its new state.
+func syncLinkedFile(state *linkfile.State, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s, err := taskstore.Open(config.GetStoragePath())
+	if err != nil {
+		return err
+	}
+	all, err := s.ReadTasks()
+	if err != nil {
+		return err
+	}
+	snaps, linked := state.Files[path]
+	content, merged, next, rep := linkfile.Sync(path, string(data), snaps, all, time.Now())
+
+	verb := "Synced"
+	if !linked {
+		verb = "Linked"
+	}
+	if linkFileDryRun {
+		verb = "Dry run: " + verb
+	}
+	fmt.Printf("%s %s: %d new, %d updated from the file, %d written to it\n", verb, path, len(rep.Added), len(rep.Updated), len(rep.Written))
+	for _, l := range rep.Updated {
+		fmt.Printf("  updated task  %s\n", l)
+	}
+	for _, l := range rep.Written {
+		fmt.Printf("  updated file  %s\n", l)
+	}
+	for _, l := range rep.Unlinked {
+		fmt.Printf("  unlinked      %s\n", l)
+	}
+	for _, l := range rep.Conflicts {
+		fmt.Printf("  conflict      %s\n", l)
+	}
+	if linkFileDryRun {
+		return nil
+	}
+
+	// The file goes first: if writing the tasks fails, the next sync sees
+	// both sides agree on the flipped checkboxes and redoes the rest.
+	if content != string(data) {
+		if err := writeLinkedFile(path, data, content); err != nil {
+			return err
+		}
+	}
+	if len(rep.Added)+len(rep.Updated) > 0 {
+		if err := s.WriteTasks(merged); err != nil {
+			return err
+		}
+	}
+	state.Files[path] = next
+	return state.Save()
+}
+
+// writeLinkedFile replaces the file's content, unless it changed since it
+// was read as orig.
+func writeLinkedFile(path string, orig []byte, content string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if now, err := os.ReadFile(path); err != nil || string(now) != string(orig) {
+		return fmt.Errorf("the file changed while syncing; run link-file again")
+	}
+	return statefile.WriteFile(path, []byte(content), info.Mode().Perm())
+}
+
+func init() {
+	linkFileCmd.Flags().BoolVar(&linkFileUnlink, "unlink", false, "Stop syncing the files (their tasks are kept)")
+	linkFileCmd.Flags().BoolVar(&linkFileList, "list", false, "List the linked files")
+	linkFileCmd.Flags().BoolVar(&linkFileDryRun, "dry-run", false, "Show what would change without writing")
+}
@@ -1,18 +1,44 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
 	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/reminders"
 	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"taskflow/internal/workflow"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	notifyDaemon   bool
+	notifyOnce     bool
+	notifyInterval time.Duration
+)
+
 var notifyCmd = &cobra.Command{
 	Use:   "notify",
 	Short: "Display notifications for upcoming tasks and calendar events",
+	Long: `Display notifications for overdue and upcoming tasks and calendar events.
+
+With --daemon, taskflow keeps running and sends each reminder once through the
+configured notifiers (notify.notifiers: stdout, desktop, email, webhook), as
+tasks come within their lead time (notify.lead_times) or become overdue.
+--once does a single such check and exits, for timers and cron.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if notifyDaemon || notifyOnce {
+			runNotifyDaemon(notifyOnce)
+			return
+		}
+
 		// Check for upcoming tasks
 		taskStoragePath := config.GetStoragePath()
 		taskStorage, err := storage.NewStorage(taskStoragePath)
@@ -30,13 +56,28 @@ var notifyCmd = &cobra.Command{
 		now := time.Now()
 		in24Hours := now.Add(24 * time.Hour)
 
-		fmt.Println("--- Upcoming Tasks ---")
+		fmt.Println("--- Overdue Tasks ---")
+		foundOverdueTask := false
+		for _, task := range tasks {
+			if !workflow.Current().IsTerminal(task.Status) {
+				dueDate, ok := taskspkg.ParseDue(task.DueDate, now.Location())
+				if ok && dueDate.Before(now) {
+					fmt.Printf("Task: %s (Due: %s)\n", task.Title, dueDate.Local().Format("2006-01-02 15:04"))
+					foundOverdueTask = true
+				}
+			}
+		}
+		if !foundOverdueTask {
+			fmt.Println("No overdue tasks.")
+		}
+
+		fmt.Println("\n--- Upcoming Tasks ---")
 		foundUpcomingTask := false
 		for _, task := range tasks {
-			if task.Status != "done" && task.DueDate != "" {
-				dueDate, err := time.Parse(time.RFC3339, task.DueDate)
-				if err == nil && dueDate.After(now) && dueDate.Before(in24Hours) {
-					fmt.Printf("Task: %s (Due: %s)\n", task.Title, dueDate.Format("2006-01-02 15:04"))
+			if !workflow.Current().IsTerminal(task.Status) {
+				dueDate, ok := taskspkg.ParseDue(task.DueDate, now.Location())
+				if ok && !dueDate.Before(now) && dueDate.Before(in24Hours) {
+					fmt.Printf("Task: %s (Due: %s)\n", task.Title, dueDate.Local().Format("2006-01-02 15:04"))
 					foundUpcomingTask = true
 				}
 			}
@@ -74,6 +115,212 @@ var notifyCmd = &cobra.Command{
 	},
 }
 
+// newNotifiers builds the notifiers listed in notify.notifiers.
+func newNotifiers(out io.Writer) ([]reminders.Notifier, error) {
+	var notifiers []reminders.Notifier
+	for _, name := range config.GetNotifyNotifiers() {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "stdout":
+			notifiers = append(notifiers, reminders.Stdout{W: out})
+		case "desktop":
+			notifiers = append(notifiers, reminders.Desktop{Command: config.GetNotifyDesktopCommand()})
+		case "email":
+			notifiers = append(notifiers, reminders.Email{
+				Addr: config.GetNotifyEmailSMTP(),
+				From: config.GetNotifyEmailFrom(),
+				To:   config.GetNotifyEmailTo(),
+			})
+		case "webhook":
+			url := config.GetNotifyWebhookURL()
+			if url == "" {
+				return nil, fmt.Errorf("the webhook notifier needs notify.webhook.url")
+			}
+			notifiers = append(notifiers, reminders.Webhook{URL: url, Secret: config.GetNotifyWebhookSecret()})
+		default:
+			return nil, fmt.Errorf("unknown notifier %q (want stdout, desktop, email or webhook)", name)
+		}
+	}
+	if len(notifiers) == 0 {
+		return nil, fmt.Errorf("no notifiers configured (notify.notifiers)")
+	}
+	return notifiers, nil
+}
+
+func runNotifyDaemon(once bool) {
+	notifiers, err := newNotifiers(os.Stdout)
+	if err != nil {
+		fmt.Printf("Error setting up notifiers: %v\n", err)
+		return
+	}
+	leads, err := config.GetNotifyLeadTimes()
+	if err != nil {
+		fmt.Printf("Error reading lead times: %v\n", err)
+		return
+	}
+	interval := notifyInterval
+	if interval <= 0 {
+		interval = config.GetNotifyInterval()
+	}
+	if once {
+		if err := sendReminders(notifiers, leads, time.Now()); err != nil {
+			fmt.Printf("Error checking reminders: %v\n", err)
+		}
+		return
+	}
+	var names []string
+	for _, n := range notifiers {
+		names = append(names, n.Name())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Printf("Checking for reminders every %s via %s (Ctrl+C to stop)\n", interval, strings.Join(names, ", "))
+	for {
+		if err := sendReminders(notifiers, leads, time.Now()); err != nil {
+			fmt.Printf("Error checking reminders: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// reminderSource reads the tasks and calendar events reminders are built
+// from.
+type reminderSource struct{}
+
+func (reminderSource) ReadTasks() ([]models.Task, error) {
+	s, err := storage.NewStorage(config.GetStoragePath())
+	if err != nil {
+		return nil, err
+	}
+	return s.ReadTasks()
+}
+
+func (reminderSource) ReadCalendarEvents() ([]models.CalendarEvent, error) {
+	s, err := storage.NewStorage(config.GetCalendarStoragePath())
+	if err != nil {
+		return nil, err
+	}
+	return s.ReadCalendarEvents()
+}
+
+// sendReminders delivers the reminders due at now that have not fired yet.
+func sendReminders(notifiers []reminders.Notifier, leads reminders.LeadTimes, now time.Time) error {
+	tasks, err := reminderSource{}.ReadTasks()
+	if err != nil {
+		return err
+	}
+	events, err := reminderSource{}.ReadCalendarEvents()
+	if err != nil {
+		return err
+	}
+	state, err := reminders.LoadState(config.GetNotifyStatePath())
+	if err != nil {
+		return err
+	}
+	rems := reminders.Collect(tasks, events, leads, now)
+	state.Prune(rems, now)
+	_, errs := reminders.Notify(state, notifiers, rems, now)
+	for _, err := range errs {
+		fmt.Printf("Error sending reminder: %v\n", err)
+	}
+	return state.Save()
+}
+
+// findReminderItem resolves a task (by ID or unique prefix) or calendar
+// event ID to its reference, title and due or start time.
+func findReminderItem(id string) (string, string, time.Time, error) {
+	tasks, err := reminderSource{}.ReadTasks()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	i, taskErr := taskspkg.FindByID(tasks, id)
+	if taskErr == nil {
+		due, _ := taskspkg.ParseDue(tasks[i].DueDate, time.Local)
+		return reminders.Ref(reminders.KindTask, tasks[i].ID), tasks[i].Title, due, nil
+	}
+	events, err := reminderSource{}.ReadCalendarEvents()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	for _, e := range events {
+		if e.ID == id {
+			start, _ := time.Parse(time.RFC3339, e.StartTime)
+			return reminders.Ref(reminders.KindEvent, e.ID), e.Title, start, nil
+		}
+	}
+	return "", "", time.Time{}, taskErr
+}
+
+var notifySnoozeCmd = &cobra.Command{
+	Use:   "snooze [task or event id] [duration]",
+	Short: "Silence reminders for a task or event for a while (default 1h)",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		d := time.Hour
+		if len(args) == 2 {
+			var err error
+			if d, err = time.ParseDuration(args[1]); err != nil || d <= 0 {
+				fmt.Printf("Error: invalid duration %q\n", args[1])
+				return
+			}
+		}
+		ref, title, _, err := findReminderItem(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		state, err := reminders.LoadState(config.GetNotifyStatePath())
+		if err != nil {
+			fmt.Printf("Error reading reminder state: %v\n", err)
+			return
+		}
+		until := time.Now().Add(d)
+		state.Snooze(ref, until)
+		if err := state.Save(); err != nil {
+			fmt.Printf("Error saving reminder state: %v\n", err)
+			return
+		}
+		fmt.Printf("Snoozed reminders for %s until %s\n", title, until.Format("2006-01-02 15:04"))
+	},
+}
+
+var notifyAckCmd = &cobra.Command{
+	Use:   "ack [task or event id]",
+	Short: "Stop reminders for a task or event until it is rescheduled",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ref, title, when, err := findReminderItem(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if when.IsZero() {
+			fmt.Printf("Error: %s has no due date or start time\n", title)
+			return
+		}
+		state, err := reminders.LoadState(config.GetNotifyStatePath())
+		if err != nil {
+			fmt.Printf("Error reading reminder state: %v\n", err)
+			return
+		}
+		state.Ack(ref, when)
+		if err := state.Save(); err != nil {
+			fmt.Printf("Error saving reminder state: %v\n", err)
+			return
+		}
+		fmt.Printf("Acknowledged reminders for %s\n", title)
+	},
+}
+
 func init() {
+	notifyCmd.Flags().BoolVar(&notifyDaemon, "daemon", false, "Keep running and send reminders through the configured notifiers")
+	notifyCmd.Flags().BoolVar(&notifyOnce, "once", false, "Send the due reminders once and exit")
+	notifyCmd.Flags().DurationVar(&notifyInterval, "interval", 0, "How often the daemon checks (default notify.interval)")
+	notifyCmd.AddCommand(notifySnoozeCmd)
+	notifyCmd.AddCommand(notifyAckCmd)
 	RootCmd.AddCommand(notifyCmd)
 }
\ No newline at end of file
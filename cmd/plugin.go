@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/plugin"
+	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
+
+	"github.com/spf13/cobra"
+)
+
+// storageHost serves external plugin requests from the configured files.
+type storageHost struct{}
+
+func (storageHost) tasks() (*storage.Storage, error) {
+	return taskstore.Open(config.GetStoragePath())
+}
+
+func (storageHost) events() (*storage.Storage, error) {
+	return storage.NewStorage(config.GetCalendarStoragePath())
+}
+
+func (h storageHost) ReadTasks() ([]models.Task, error) {
+	s, err := h.tasks()
+	if err != nil {
+		return nil, err
+	}
+	return s.ReadTasks()
+}
+
+func (h storageHost) WriteTasks(all []models.Task) error {
+	s, err := h.tasks()
+	if err != nil {
+		return err
+	}
+	if err := s.Backup(); err != nil {
+		return err
+	}
+	return s.WriteTasks(all)
+}
+
+func (h storageHost) ReadCalendarEvents() ([]models.CalendarEvent, error) {
+	s, err := h.events()
+	if err != nil {
+		return nil, err
+	}
+	return s.ReadCalendarEvents()
+}
+
+func (h storageHost) WriteCalendarEvents(events []models.CalendarEvent) error {
+	s, err := h.events()
+	if err != nil {
+		return err
+	}
+	return s.WriteCalendarEvents(events)
+}
+
+// RegisterExternalPlugins registers the taskflow-<name> executables found in
+// the plugin directory and on PATH. Built-in plugins win on name clashes.
+func RegisterExternalPlugins() {
+	for _, path := range plugin.Discover(plugin.SearchDirs(config.GetPluginDir())) {
+		_ = plugin.RegisterPlugin(plugin.NewExternal(path))
+	}
+}
+
+// InitPlugins initialises the registered plugins with their config sections.
+// Plugins that fail are reported on stderr and left out.
+func InitPlugins() {
+	errs := plugin.Default().InitAll(plugin.Env{
+		Root:    RootCmd,
+		Storage: storageHost{},
+		Config:  config.GetPluginConfig,
+		Enabled: config.PluginEnabled,
+		Log:     os.Stderr,
+	})
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+func shutdownPlugins() {
+	for _, err := range plugin.Default().ShutdownAll() {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Inspect built-in and external plugins",
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List plugins",
+	Run: func(cmd *cobra.Command, args []string) {
+		all := plugin.GetAllPlugins()
+		if len(all) == 0 {
+			fmt.Println("No plugins found.")
+			return
+		}
+		for _, p := range all {
+			kind := "built-in"
+			if _, ok := p.(*plugin.External); ok {
+				kind = "external"
+			}
+			state, _ := plugin.Default().State(p.Name())
+			fmt.Printf("%-16s %-9s %-8s %s\n", p.Name(), kind, state, p.Description())
+		}
+	},
+}
+
+var pluginInfoCmd = &cobra.Command{
+	Use:   "info [name]",
+	Short: "Show details about a plugin",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		p, ok := plugin.GetPlugin(args[0])
+		if !ok {
+			fmt.Printf("Plugin %s not found.\n", args[0])
+			return
+		}
+		fmt.Printf("Name:        %s\n", p.Name())
+		state, err := plugin.Default().State(p.Name())
+		fmt.Printf("State:       %s\n", state)
+		if err != nil {
+			fmt.Printf("Error:       %v\n", err)
+		}
+		if req, ok := p.(plugin.Requirer); ok && len(req.Requires()) > 0 {
+			fmt.Printf("Requires:    %s\n", strings.Join(req.Requires(), ", "))
+		}
+		ext, isExternal := p.(*plugin.External)
+		if !isExternal {
+			fmt.Printf("Type:        built-in\n")
+			fmt.Printf("Description: %s\n", p.Description())
+			return
+		}
+		info, err := ext.Info()
+		fmt.Printf("Type:        external\n")
+		fmt.Printf("Path:        %s\n", ext.Path)
+		if err != nil {
+			fmt.Printf("Error:       %v\n", err)
+			return
+		}
+		if info.Version != "" {
+			fmt.Printf("Version:     %s\n", info.Version)
+		}
+		fmt.Printf("Description: %s\n", p.Description())
+		if info.Usage != "" {
+			fmt.Printf("Usage:       %s\n", info.Usage)
+		}
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInfoCmd)
+}
@@ -18,7 +18,9 @@ var RootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	err := RootCmd.Execute()
+	shutdownPlugins()
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -45,6 +47,11 @@ func addSubcommands(root *cobra.Command) {
 	taskCmd.AddCommand(task.DoneCmd)
 	taskCmd.AddCommand(task.EditCmd)
 	taskCmd.AddCommand(task.ListCmd)
+	taskCmd.AddCommand(task.ShowCmd)
+	taskCmd.AddCommand(task.StartCmd)
+	taskCmd.AddCommand(task.StopCmd)
+	taskCmd.AddCommand(task.LogCmd)
+	taskCmd.AddCommand(task.ReportCmd)
 	taskCmd.AddCommand(task.SearchCmd)
 	taskCmd.AddCommand(task.StatsCmd)
 	taskCmd.AddCommand(task.UndoCmd)
@@ -52,11 +59,20 @@ func addSubcommands(root *cobra.Command) {
 	taskCmd.AddCommand(task.CompletionCmd)
 	taskCmd.AddCommand(task.PrioritizeCmd)
 	taskCmd.AddCommand(task.ScheduleCmd)
+	taskCmd.AddCommand(task.PlanCmd)
 	taskCmd.AddCommand(task.ArchiveCmd)
 	root.AddCommand(taskCmd)
 	root.AddCommand(calendar.CalendarCmd)
 	root.AddCommand(display.DisplayCmd)
 	root.AddCommand(remote.RemoteCmd)
+	root.AddCommand(doctorCmd)
+	root.AddCommand(pluginCmd)
+	root.AddCommand(webhooksCmd)
+	root.AddCommand(scheduleCmd)
+	root.AddCommand(importCmd)
+	root.AddCommand(exportCmd)
+	root.AddCommand(linkFileCmd)
+	root.AddCommand(scanCmd)
 }
 
 func init() {
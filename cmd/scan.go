@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"taskflow/internal/codescan"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/taskstore"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanDryRun  bool
+	scanNoBlame bool
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan [dir]",
+	Short: "Track TODO, FIXME and HACK comments as tasks",
+	Long: `Track TODO, FIXME and HACK comments in a source tree as tasks.
+
+Files ignored by .gitignore are skipped. Each comment becomes a task with
+source "code", tagged "code" and "todo", "fixme" or "hack", linking to the
+comment as path:line. In a git repository the comment's author comes from
+git blame, unless the comment names one: TODO(ana): ...
+
+Scanning again adds tasks for new comments, follows comments that moved
+and completes the tasks of comments that are gone; those tasks are reopened
+if the comment comes back, e.g. after switching branches. When nothing
+changed nothing is written. Tasks you edited keep their title and tags.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		comments, err := codescan.Scan(dir, !scanNoBlame)
+		if err != nil {
+			fmt.Printf("Error scanning %s: %v\n", dir, err)
+			return
+		}
+
+		s, err := taskstore.Open(config.GetStoragePath())
+		if err != nil {
+			fmt.Printf("Error creating storage: %v\n", err)
+			return
+		}
+		all, err := s.ReadTasks()
+		if err != nil {
+			fmt.Printf("Error reading tasks: %v\n", err)
+			return
+		}
+		state, err := codescan.LoadState(config.GetScanStatePath())
+		if err != nil {
+			fmt.Printf("Error reading scan state: %v\n", err)
+			return
+		}
+		now := time.Now()
+		merged, res := codescan.Apply(all, dir, comments, state.ClosedIDs(), now)
+
+		if scanDryRun {
+			fmt.Print("Dry run: ")
+		}
+		fmt.Printf("%d comment(s) in %s: %d new, %d moved, %d gone, %d back.\n", len(comments), dir, len(res.Added), len(res.Moved), len(res.Closed), len(res.Reopened))
+		printScanned("new", dir, res.Added)
+		printScanned("closed", dir, res.Closed)
+		printScanned("reopened", dir, res.Reopened)
+		if scanDryRun {
+			return
+		}
+
+		if res.Changed() {
+			// Back up first so 'task undo' reverts the whole scan.
+			if err := s.Backup(); err != nil {
+				fmt.Printf("Warning: failed to create backup: %v\n", err)
+			}
+			if err := s.WriteTasks(merged); err != nil {
+				fmt.Printf("Error writing tasks: %v\n", err)
+				return
+			}
+		}
+		if state.Record(merged, res, now) {
+			if err := state.Save(); err != nil {
+				fmt.Printf("Error saving scan state: %v\n", err)
+			}
+		}
+	},
+}
+
+// printScanned lists tasks with their location relative to dir.
+func printScanned(label, dir string, list []models.Task) {
+	for _, t := range list {
+		loc := t.Link
+		if rel, err := filepath.Rel(dir, loc); err == nil {
+			loc = rel
+		}
+		fmt.Printf("  %-8s %s  %s\n", label, loc, t.Title)
+	}
+}
+
+func init() {
+	scanCmd.Flags().BoolVar(&scanDryRun, "dry-run", false, "Show what would change without writing")
+	scanCmd.Flags().BoolVar(&scanNoBlame, "no-blame", false, "Don't look up authors with git blame")
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/schedule"
+	"taskflow/internal/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleBackend    string
+	scheduleDir        string
+	scheduleNoActivate bool
+)
+
+// newScheduleBackend returns the backend chosen with --backend or
+// schedule.backend; auto picks systemd when systemctl is available.
+func newScheduleBackend() (schedule.Backend, error) {
+	name := scheduleBackend
+	if name == "" {
+		name = config.GetScheduleBackend()
+	}
+	dir := scheduleDir
+	if dir == "" {
+		dir = config.GetScheduleDir()
+	}
+	_, lookErr := exec.LookPath("systemctl")
+	if name == "" || name == "auto" {
+		name = "cron"
+		if lookErr == nil && runtime.GOOS == "linux" {
+			name = "systemd"
+		}
+	}
+	switch name {
+	case "systemd":
+		b := schedule.Systemd{Dir: dir}
+		if !scheduleNoActivate && lookErr == nil {
+			b.Systemctl = func(args ...string) (string, error) {
+				out, err := exec.Command("systemctl", append([]string{"--user"}, args...)...).CombinedOutput()
+				return string(out), err
+			}
+		}
+		return b, nil
+	case "cron":
+		return schedule.Cron{Read: readCrontab, Write: writeCrontab}, nil
+	}
+	return nil, fmt.Errorf("unknown schedule backend %q (want auto, systemd or cron)", name)
+}
+
+func readCrontab() (string, error) {
+	out, err := exec.Command("crontab", "-l").CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(out), "no crontab") {
+			return "", nil
+		}
+		return "", fmt.Errorf("crontab -l: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+func writeCrontab(content string) error {
+	c := exec.Command("crontab", "-")
+	c.Stdin = strings.NewReader(content)
+	if out, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("crontab: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func describeJob(j schedule.Job) string {
+	if j.Command != "" {
+		return j.Command
+	}
+	return "taskflow " + strings.Join(j.Args, " ")
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run background jobs periodically with systemd timers or cron",
+	Long: `Run background jobs periodically with systemd timers or cron.
+
+The jobs come from schedule.jobs in the config file (by default, reminders
+every 5 minutes). systemd user timers are used where systemctl is available,
+crontab entries otherwise; choose with --backend or schedule.backend.`,
+}
+
+var scheduleInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the configured jobs, replacing those installed before",
+	Run: func(cmd *cobra.Command, args []string) {
+		jobs, err := config.GetScheduleJobs()
+		if err != nil {
+			fmt.Printf("Error reading jobs: %v\n", err)
+			return
+		}
+		backend, err := newScheduleBackend()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			fmt.Printf("Error finding the taskflow executable: %v\n", err)
+			return
+		}
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		if err := backend.Install(exe, jobs); err != nil {
+			fmt.Printf("Error installing jobs: %v\n", err)
+			return
+		}
+		fmt.Printf("Installed %d job(s) with %s:\n", len(jobs), backend.Name())
+		for _, j := range jobs {
+			fmt.Printf("  %-14s every %-6s %s\n", j.Name, tasks.FormatDuration(j.Every), describeJob(j))
+		}
+		if b, ok := backend.(schedule.Systemd); ok && b.Systemctl == nil {
+			fmt.Printf("Unit files written to %s; activate them with: systemctl --user daemon-reload && systemctl --user enable --now %s*.timer\n", b.Dir, schedule.Prefix)
+		}
+	},
+}
+
+var scheduleUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove all installed jobs",
+	Run: func(cmd *cobra.Command, args []string) {
+		backend, err := newScheduleBackend()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := backend.Uninstall(); err != nil {
+			fmt.Printf("Error removing jobs: %v\n", err)
+			return
+		}
+		fmt.Printf("Removed taskflow jobs from %s.\n", backend.Name())
+	},
+}
+
+var scheduleStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show configured and installed jobs",
+	Run: func(cmd *cobra.Command, args []string) {
+		jobs, err := config.GetScheduleJobs()
+		if err != nil {
+			fmt.Printf("Error reading jobs: %v\n", err)
+			return
+		}
+		backend, err := newScheduleBackend()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		installed, err := backend.Status()
+		if err != nil {
+			fmt.Printf("Error reading installed jobs: %v\n", err)
+			return
+		}
+		byName := map[string]schedule.Status{}
+		for _, st := range installed {
+			byName[st.Name] = st
+		}
+		fmt.Printf("Backend: %s\n", backend.Name())
+		for _, j := range jobs {
+			st, ok := byName[j.Name]
+			delete(byName, j.Name)
+			every := tasks.FormatDuration(j.Every)
+			switch {
+			case !ok:
+				fmt.Printf("  %-14s every %-6s not installed\n", j.Name, every)
+			case st.Every != every:
+				fmt.Printf("  %-14s every %-6s %s, installed every %s (run schedule install)\n", j.Name, every, st.Detail, st.Every)
+			default:
+				fmt.Printf("  %-14s every %-6s %s\n", j.Name, every, st.Detail)
+			}
+		}
+		for _, st := range installed {
+			if _, stale := byName[st.Name]; stale {
+				fmt.Printf("  %-14s every %-6s %s, not configured (run schedule install)\n", st.Name, st.Every, st.Detail)
+			}
+		}
+	},
+}
+
+func init() {
+	scheduleCmd.PersistentFlags().StringVar(&scheduleBackend, "backend", "", "auto, systemd or cron (default schedule.backend)")
+	scheduleCmd.PersistentFlags().StringVar(&scheduleDir, "dir", "", "Directory for systemd units (default schedule.dir or ~/.config/systemd/user)")
+	scheduleCmd.PersistentFlags().BoolVar(&scheduleNoActivate, "no-activate", false, "Only write systemd unit files, don't call systemctl")
+	scheduleCmd.AddCommand(scheduleInstallCmd)
+	scheduleCmd.AddCommand(scheduleUninstallCmd)
+	scheduleCmd.AddCommand(scheduleStatusCmd)
+}
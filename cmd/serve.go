@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"net/http"
+	"os"
 	"taskflow/internal/config"
 	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
 
 	"github.com/spf13/cobra"
 )
@@ -28,11 +31,18 @@ var serveCmd = &cobra.Command{
 				return
 			}
 
+			keys, err := taskspkg.ParseSort(r.URL.Query().Get("sort"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			taskspkg.Sort(tasks, keys)
+
 			tmpl, err := template.New("tasks").Parse(`
 				<h1>Task List</h1>
 				<ul>
 					{{range .}}
-						<li>{{.Title}} - {{if eq .Status "done"}}Done{{else}}Pending{{end}}</li>
+						<li>{{.Title}} - {{if .Completed}}Done{{else}}Pending{{end}}</li>
 					{{else}}
 						<li>No tasks found.</li>
 					{{end}}
@@ -46,6 +56,13 @@ var serveCmd = &cobra.Command{
 			tmpl.Execute(w, tasks)
 		})
 
+		// Deliver webhooks in the background while serving.
+		if w, err := newWebhookWorker(os.Stdout); err != nil {
+			fmt.Printf("Error loading webhooks: %v\n", err)
+		} else if w != nil {
+			go w.Run(context.Background(), config.GetWebhookInterval())
+		}
+
 		port := ":8081"
 		fmt.Printf("Starting web server on http://localhost%s/tasks\n", port)
 		http.ListenAndServe(port, nil)
@@ -5,7 +5,8 @@ import (
 	"strings"
 	"taskflow/internal/config"
 	"taskflow/internal/models"
-	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
+	"taskflow/internal/workflow"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,8 @@ import (
 )
 
 var dueDate string
+var estimate string
+var dependsOn []string
 
 var AddCmd = &cobra.Command{
 	Use:     "add [title]",
@@ -21,7 +24,7 @@ var AddCmd = &cobra.Command{
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		storagePath := config.GetStoragePath()
-		s, err := storage.NewStorage(storagePath)
+		s, err := taskstore.Open(storagePath)
 		if err != nil {
 			fmt.Printf("Error creating storage: %v\n", err)
 			return
@@ -33,13 +36,23 @@ var AddCmd = &cobra.Command{
 			return
 		}
 
+		if estimate != "" {
+			if _, err := time.ParseDuration(estimate); err != nil {
+				fmt.Printf("Invalid estimate %q: use a duration such as 45m or 1h30m\n", estimate)
+				return
+			}
+		}
+
 		task := models.Task{
 			ID:        uuid.New().String(),
 			Title:     strings.Join(args, " "),
 			DueDate:   dueDate,
-			Status:    "to-do",
-			Priority:  "medium",
+			Estimate:  estimate,
+			DependsOn: dependsOn,
+			Status:    workflow.Current().Initial(),
+			Priority:  workflow.Current().DefaultPriority(),
 			UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
 		}
 
 		tasks = append(tasks, task)
@@ -55,4 +68,6 @@ var AddCmd = &cobra.Command{
 
 func init() {
 	AddCmd.Flags().StringVar(&dueDate, "due-date", "", "Due date of the task (RFC3339 format)")
+	AddCmd.Flags().StringVar(&estimate, "estimate", "", "Estimated effort (duration, e.g. 1h30m)")
+	AddCmd.Flags().StringSliceVar(&dependsOn, "depends-on", nil, "IDs of tasks that must be done first (comma-separated)")
 }
@@ -2,13 +2,12 @@ package task
 
 import (
 	"fmt"
-	"os"
 	"taskflow/internal/config"
 	"taskflow/internal/models"
-	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
+	"taskflow/internal/workflow"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 func init() {
@@ -22,7 +21,7 @@ var ArchiveCmd = &cobra.Command{
 	Short: "Archive completed (done) tasks",
 	Run: func(cmd *cobra.Command, args []string) {
 		storagePath := config.GetStoragePath()
-		s, err := storage.NewStorage(storagePath)
+		s, err := taskstore.Open(storagePath)
 		if err != nil {
 			fmt.Printf("Error creating storage: %v\n", err)
 			return
@@ -37,7 +36,7 @@ var ArchiveCmd = &cobra.Command{
 		var active []models.Task
 		var completed []models.Task
 		for _, t := range all {
-			if t.Status == "done" {
+			if workflow.Current().IsTerminal(t.Status) {
 				completed = append(completed, t)
 			} else {
 				active = append(active, t)
@@ -62,7 +61,12 @@ var ArchiveCmd = &cobra.Command{
 			fmt.Printf("Warning: failed to create backup: %v\n", err)
 		}
 
-		if err := appendToArchive(archivePath, completed); err != nil {
+		archive, err := taskstore.Open(archivePath)
+		if err != nil {
+			fmt.Printf("Error creating archive storage: %v\n", err)
+			return
+		}
+		if err := archive.Archive(completed); err != nil {
 			fmt.Printf("Error writing archive: %v\n", err)
 			return
 		}
@@ -75,19 +79,3 @@ var ArchiveCmd = &cobra.Command{
 		fmt.Printf("Archived %d tasks → %s (remaining active: %d)\n", len(completed), archivePath, len(active))
 	},
 }
-
-// appendToArchive reads existing archive (if any), appends new tasks, and writes back.
-func appendToArchive(path string, tasks []models.Task) error {
-	var existing models.TaskList
-	if data, err := os.ReadFile(path); err == nil {
-		if len(data) > 0 {
-			_ = yaml.Unmarshal(data, &existing) // best-effort
-		}
-	}
-	existing.Tasks = append(existing.Tasks, tasks...)
-	out, err := yaml.Marshal(existing)
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(path, out, 0644)
-}
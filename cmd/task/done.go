@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"taskflow/internal/config"
 	"taskflow/internal/models"
-	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"taskflow/internal/taskstore"
+	"taskflow/internal/workflow"
 	"time"
 
 	"github.com/manifoldco/promptui"
@@ -17,7 +19,7 @@ var DoneCmd = &cobra.Command{
 	Aliases: []string{"complete", "finish"},
 	Run: func(cmd *cobra.Command, args []string) {
 		storagePath := config.GetStoragePath()
-		s, err := storage.NewStorage(storagePath)
+		s, err := taskstore.Open(storagePath)
 		if err != nil {
 			fmt.Printf("Error creating storage: %v\n", err)
 			return
@@ -31,7 +33,7 @@ var DoneCmd = &cobra.Command{
 
 		var activeTasks []models.Task
 		for _, task := range tasks {
-			if task.Status != "done" {
+			if !workflow.Current().IsTerminal(task.Status) {
 				activeTasks = append(activeTasks, task)
 			}
 		}
@@ -64,8 +66,7 @@ var DoneCmd = &cobra.Command{
 
 		for i, task := range tasks {
 			if task.ID == doneTask.ID {
-				tasks[i].Status = "done"
-				tasks[i].UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+				taskspkg.SetStatus(&tasks[i], workflow.Current().Done(), time.Now())
 				break
 			}
 		}
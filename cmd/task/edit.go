@@ -3,20 +3,30 @@ package task
 import (
 	"fmt"
 	"taskflow/internal/config"
+	"taskflow/internal/editor"
+	"taskflow/internal/models"
 	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"taskflow/internal/taskstore"
 	"time"
 
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	EditCmd.Flags().Bool("notes-editor", false, "Edit the task's notes in $EDITOR")
+	EditCmd.Flags().Bool("description-editor", false, "Edit the task's description in $EDITOR")
+}
+
 var EditCmd = &cobra.Command{
-	Use:     "edit",
+	Use:     "edit [id]",
 	Short:   "Edit task properties",
 	Aliases: []string{"modify", "update"},
+	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		storagePath := config.GetStoragePath()
-		s, err := storage.NewStorage(storagePath)
+		s, err := taskstore.Open(storagePath)
 		if err != nil {
 			fmt.Printf("Error creating storage: %v\n", err)
 			return
@@ -33,6 +43,27 @@ var EditCmd = &cobra.Command{
 			return
 		}
 
+		notesEditor, _ := cmd.Flags().GetBool("notes-editor")
+		descEditor, _ := cmd.Flags().GetBool("description-editor")
+		if (notesEditor || descEditor) && len(args) == 0 {
+			fmt.Println("Error: a task id is required with --notes-editor/--description-editor")
+			return
+		}
+
+		if len(args) == 1 {
+			i, err := taskspkg.FindByID(tasks, args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if notesEditor || descEditor {
+				editInEditor(s, tasks, i, notesEditor, descEditor)
+				return
+			}
+			editTitle(s, tasks, tasks[i])
+			return
+		}
+
 		templates := &promptui.SelectTemplates{
 			Label:    "{{ . }}",
 			Active:   "-> {{ .Title | cyan }}",
@@ -52,32 +83,75 @@ var EditCmd = &cobra.Command{
 			return
 		}
 
-		editTask := tasks[i]
+		editTitle(s, tasks, tasks[i])
+	},
+}
+
+// editTitle prompts for a new title for editTask and saves it.
+func editTitle(s *storage.Storage, tasks []models.Task, editTask models.Task) {
+	prompt2 := promptui.Prompt{
+		Label:   "New title",
+		Default: editTask.Title,
+	}
+
+	newTitle, err := prompt2.Run()
+	if err != nil {
+		fmt.Printf("Prompt failed %v\n", err)
+		return
+	}
 
-		prompt2 := promptui.Prompt{
-			Label:   "New title",
-			Default: editTask.Title,
+	for i, task := range tasks {
+		if task.ID == editTask.ID {
+			tasks[i].Title = newTitle
+			tasks[i].UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+			break
 		}
+	}
 
-		newTitle, err := prompt2.Run()
+	if err := s.WriteTasks(tasks); err != nil {
+		fmt.Printf("Error writing tasks: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Edited task: %s\n", newTitle)
+}
+
+// editInEditor opens the notes and/or description of tasks[i] in $EDITOR
+// and saves the result.
+func editInEditor(s *storage.Storage, tasks []models.Task, i int, notes, description bool) {
+	t := &tasks[i]
+	if description {
+		// ReadTasks shows the link as an empty description; edit what is stored.
+		stored, err := s.ReadStored()
 		if err != nil {
-			fmt.Printf("Prompt failed %v\n", err)
+			fmt.Printf("Error reading tasks: %v\n", err)
 			return
 		}
-
-		for i, task := range tasks {
-			if task.ID == editTask.ID {
-				tasks[i].Title = newTitle
-				tasks[i].UpdatedAt = time.Now().UTC().Format(time.RFC3339)
-				break
+		current := ""
+		for _, st := range stored {
+			if st.ID == t.ID {
+				current = st.Description
 			}
 		}
-
-		if err := s.WriteTasks(tasks); err != nil {
-			fmt.Printf("Error writing tasks: %v\n", err)
+		text, err := editor.Edit("description", current)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
-
-		fmt.Printf("Edited task: %s\n", newTitle)
-	},
+		t.Description = text
+	}
+	if notes {
+		text, err := editor.Edit("notes", t.Notes)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		t.Notes = text
+	}
+	t.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := s.WriteTasks(tasks); err != nil {
+		fmt.Printf("Error writing tasks: %v\n", err)
+		return
+	}
+	fmt.Printf("Edited task: %s\n", t.Title)
 }
@@ -0,0 +1,59 @@
+package task_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+)
+
+func TestEditNotesInEditor(t *testing.T) {
+	path := seedTasks(t, []models.Task{{ID: "abc1", Title: "Write docs", Status: "to-do", Notes: "old"}})
+
+	// A fake editor that replaces the file with Markdown notes.
+	script := filepath.Join(t.TempDir(), "fake-editor")
+	body := "#!/bin/sh\nprintf '# Plan\\n\\n- draft\\n- review\\n' > \"$1\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	out := execRootCapture(t, "task", "edit", "abc1", "--notes-editor")
+	if !strings.Contains(out, "Edited task: Write docs") {
+		t.Fatalf("expected confirmation, got: %s", out)
+	}
+	data := readTasksFile(t, path)
+	if !strings.Contains(data, "- draft") || strings.Contains(data, "old") {
+		t.Fatalf("expected notes replaced, got: %s", data)
+	}
+}
+
+func TestEditDescriptionKeepsLinkOut(t *testing.T) {
+	path := seedTasks(t, []models.Task{{ID: "abc1", Title: "Write docs", Link: "https://example.com/doc"}})
+
+	// An editor that saves the file unchanged.
+	script := filepath.Join(t.TempDir(), "fake-editor")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ntrue\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	out := execRootCapture(t, "task", "edit", "abc1", "--description-editor")
+	if !strings.Contains(out, "Edited task: Write docs") {
+		t.Fatalf("expected confirmation, got: %s", out)
+	}
+	if data := readTasksFile(t, path); strings.Contains(data, "description:") {
+		t.Fatalf("link saved as description: %s", data)
+	}
+}
+
+func TestEditEditorFlagNeedsID(t *testing.T) {
+	seedTasks(t, []models.Task{{ID: "abc1", Title: "Write docs"}})
+	out := execRootCapture(t, "task", "edit", "--description-editor")
+	if !strings.Contains(out, "task id is required") {
+		t.Fatalf("expected id error, got: %s", out)
+	}
+}
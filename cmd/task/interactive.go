@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"os"
 	"taskflow/internal/config"
-	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
 	"taskflow/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,7 +22,7 @@ var InteractiveCmd = &cobra.Command{
 	Short:   "Start interactive task management mode",
 	Run: func(cmd *cobra.Command, args []string) {
 		storagePath := config.GetStoragePath()
-		s, err := storage.NewStorage(storagePath)
+		s, err := taskstore.Open(storagePath)
 		if err != nil {
 			fmt.Printf("Error creating storage: %v\n", err)
 			return
@@ -2,12 +2,12 @@ package task
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 	"taskflow/internal/config"
 
 	"taskflow/internal/storage"
 	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
 
 	"github.com/spf13/cobra"
 )
@@ -18,14 +18,14 @@ func init() {
 	ListCmd.Flags().String("tags", "", "Filter by tags (comma-separated)")
 	ListCmd.Flags().String("contains", "", "Filter by words contained in fields (space-separated)")
 	ListCmd.Flags().String("contains-fields", "title", "Comma-separated list of fields to search: title,description,notes,link,tags (tags matched by tag value)")
-	ListCmd.Flags().String("sort-by", "", "Sort by priority or status")
+	ListCmd.Flags().String("sort-by", "", "Comma-separated sort keys, '-' for descending: priority,status,due,created,updated,title,id (e.g. due,-priority,title)")
 	TaskCmd.AddCommand(ListCmd)
 }
 
 var ListCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List tasks",
-	Aliases: []string{"ls", "show"},
+	Aliases: []string{"ls"},
 	Run: func(cmd *cobra.Command, args []string) {
 		storagePath := config.GetStoragePath()
 		s, err := storage.NewStorage(storagePath)
@@ -86,20 +86,12 @@ var ListCmd = &cobra.Command{
 
 		// Sorting
 		sortBy, _ := cmd.Flags().GetString("sort-by")
-		if sortBy != "" {
-			switch sortBy {
-			case "priority":
-				prioRank := map[string]int{"highest": 4, "high": 3, "medium": 2, "low": 1}
-				sort.SliceStable(filtered, func(i, j int) bool {
-					return prioRank[filtered[i].Priority] > prioRank[filtered[j].Priority]
-				})
-			case "status":
-				statusRank := map[string]int{"todo": 1, "in-progress": 2, "done": 3}
-				sort.SliceStable(filtered, func(i, j int) bool {
-					return statusRank[filtered[i].Status] < statusRank[filtered[j].Status]
-				})
-			}
+		keys, err := tasks.ParseSort(sortBy)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
 		}
+		tasks.Sort(filtered, keys)
 
 		if len(filtered) == 0 {
 			fmt.Println("No tasks found.")
@@ -108,7 +100,7 @@ var ListCmd = &cobra.Command{
 
 		for _, task := range filtered {
 			status := " "
-			if task.Status == "done" {
+			if workflow.Current().IsTerminal(task.Status) {
 				status = "x"
 			}
 			fmt.Printf("[%s] (%s) %s\n", status, task.Priority, task.Title)
@@ -69,17 +69,36 @@ func TestListSortingByPriorityAndStatus(t *testing.T) {
 		{ID: "1", Title: "Low", Priority: "low", Status: "todo"},
 		{ID: "2", Title: "High", Priority: "high", Status: "todo"},
 		{ID: "3", Title: "Medium", Priority: "medium", Status: "done"},
-		{ID: "4", Title: "Highest", Priority: "highest", Status: "in-progress"},
+		{ID: "4", Title: "Urgent", Priority: "high", Status: "in-progress"},
 	}
 	out := seedAndExec(t, tasks, "task", "list", "--sort-by", "priority")
 	order := extractOrder(t, out)
-	if len(order) != 4 || order[0] != "Highest" || order[1] != "High" || order[2] != "Medium" || order[3] != "Low" {
+	if len(order) != 4 || order[0] != "High" || order[1] != "Urgent" || order[2] != "Medium" || order[3] != "Low" {
 		t.Fatalf("priority sort wrong order: %v output=%s", order, out)
 	}
 	out = seedAndExec(t, tasks, "task", "list", "--sort-by", "status")
 	order = extractOrder(t, out)
-	// done should appear after todo because we sort ascending on status string; 'done' > 'todo'
-	if len(order) != 4 || order[0] != "Low" || order[1] != "High" || order[2] != "Highest" || order[3] != "Medium" {
+	// Statuses sort in workflow order (to-do, in-progress, done); ties keep the file order.
+	if len(order) != 4 || order[0] != "Low" || order[1] != "High" || order[2] != "Urgent" || order[3] != "Medium" {
 		t.Fatalf("status sort wrong order: %v output=%s", order, out)
 	}
 }
+
+func TestListMultiKeySort(t *testing.T) {
+	tasks := []models.Task{
+		{ID: "1", Title: "B", Priority: "low", Status: "to-do", DueDate: "2025-10-02"},
+		{ID: "2", Title: "A", Priority: "high", Status: "to-do", DueDate: "2025-10-02"},
+		{ID: "3", Title: "C", Priority: "medium", Status: "to-do"},
+		{ID: "4", Title: "D", Priority: "low", Status: "to-do", DueDate: "2025-10-01"},
+	}
+	out := seedAndExec(t, tasks, "task", "list", "--sort-by", "due,-priority,title")
+	order := extractOrder(t, out)
+	if len(order) != 4 || order[0] != "D" || order[1] != "B" || order[2] != "A" || order[3] != "C" {
+		t.Fatalf("multi-key sort wrong order: %v output=%s", order, out)
+	}
+	out = seedAndExec(t, tasks, "task", "list", "--sort-by", "colour")
+	if !regexp.MustCompile(`unknown sort field "colour"`).MatchString(out) {
+		t.Fatalf("expected unknown field error, got %s", out)
+	}
+	seedAndExec(t, tasks, "task", "list", "--sort-by", "")
+}
@@ -0,0 +1,161 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/planner"
+	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	PlanCmd.Flags().Int("days", 0, "Number of days to plan (default from planner.days)")
+	PlanCmd.Flags().Bool("write", false, "Write the proposed time blocks to the calendar")
+}
+
+// PlanCmd proposes time blocks for open tasks in the free time between calendar events.
+var PlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan open tasks into free calendar time",
+	Long: `Reads calendar events to find free time within the configured working hours
+(planner.work_start, planner.work_end, planner.work_days) and proposes time blocks
+for open tasks ordered by due date and priority. Each task needs its estimate
+(or planner.default_estimate) of free time; tasks that do not fit are listed as
+unscheduled instead of overbooking the calendar.
+
+With --write the blocks are stored as calendar events. Blocks written by an
+earlier run are replaced.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		opts, err := plannerOptions(time.Now())
+		if err != nil {
+			fmt.Printf("Error reading planner config: %v\n", err)
+			return
+		}
+		if days, _ := cmd.Flags().GetInt("days"); days > 0 {
+			opts.Days = days
+		}
+
+		taskStorage, err := storage.NewStorage(config.GetStoragePath())
+		if err != nil {
+			fmt.Printf("Error creating task storage: %v\n", err)
+			return
+		}
+		tasks, err := taskStorage.ReadTasks()
+		if err != nil {
+			fmt.Printf("Error reading tasks: %v\n", err)
+			return
+		}
+
+		calendarStorage, err := storage.NewStorage(config.GetCalendarStoragePath())
+		if err != nil {
+			fmt.Printf("Error creating calendar storage: %v\n", err)
+			return
+		}
+		events, err := calendarStorage.ReadCalendarEvents()
+		if err != nil {
+			fmt.Printf("Error reading calendar events: %v\n", err)
+			return
+		}
+
+		plan := planner.Build(tasks, events, opts)
+		printPlan(plan)
+
+		if write, _ := cmd.Flags().GetBool("write"); write {
+			windowStart := plan.Days[0].Date
+			var kept []models.CalendarEvent
+			for _, e := range events {
+				if planner.IsPlanEvent(e) {
+					if start, err := time.Parse(time.RFC3339, e.StartTime); err == nil && !start.Before(windowStart) {
+						continue // replaced by this run
+					}
+				}
+				kept = append(kept, e)
+			}
+			blocks := plan.Events()
+			if err := calendarStorage.WriteCalendarEvents(append(kept, blocks...)); err != nil {
+				fmt.Printf("Error writing calendar events: %v\n", err)
+				return
+			}
+			fmt.Printf("\nWrote %d time blocks to the calendar.\n", len(blocks))
+		}
+	},
+}
+
+// plannerOptions builds planner options from config for the given clock.
+func plannerOptions(now time.Time) (planner.Options, error) {
+	start, err := planner.ParseClock(config.GetPlannerWorkStart())
+	if err != nil {
+		return planner.Options{}, err
+	}
+	end, err := planner.ParseClock(config.GetPlannerWorkEnd())
+	if err != nil {
+		return planner.Options{}, err
+	}
+	if end <= start {
+		return planner.Options{}, fmt.Errorf("planner.work_end must be after planner.work_start")
+	}
+	days, err := planner.ParseWeekdays(config.GetPlannerWorkDays())
+	if err != nil {
+		return planner.Options{}, err
+	}
+	estimate, err := time.ParseDuration(config.GetPlannerDefaultEstimate())
+	if err != nil {
+		return planner.Options{}, fmt.Errorf("invalid planner.default_estimate: %w", err)
+	}
+	minBlock, err := time.ParseDuration(config.GetPlannerMinBlock())
+	if err != nil {
+		return planner.Options{}, fmt.Errorf("invalid planner.min_block: %w", err)
+	}
+	return planner.Options{
+		Now:             now,
+		Days:            config.GetPlannerDays(),
+		WorkStart:       start,
+		WorkEnd:         end,
+		WorkDays:        days,
+		DefaultEstimate: estimate,
+		MinBlock:        minBlock,
+	}, nil
+}
+
+func printPlan(plan planner.Plan) {
+	for _, d := range plan.Days {
+		fmt.Printf("%s  (free: %s)\n", d.Date.Format("Mon 2006-01-02"), taskspkg.FormatDuration(d.Free))
+		type entry struct {
+			start, end time.Time
+			label      string
+		}
+		var entries []entry
+		for _, b := range d.Busy {
+			entries = append(entries, entry{b.Start, b.End, "busy  " + b.Title})
+		}
+		for _, b := range d.Blocks {
+			label := "task  " + b.Title
+			if b.Late {
+				label += " (after due date)"
+			}
+			entries = append(entries, entry{b.Start, b.End, label})
+		}
+		if len(entries) == 0 {
+			fmt.Println("  nothing planned")
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].start.Before(entries[j].start) })
+		for _, e := range entries {
+			fmt.Printf("  %s-%s  %s\n", e.start.Format("15:04"), e.end.Format("15:04"), e.label)
+		}
+	}
+	if len(plan.Unscheduled) > 0 {
+		fmt.Println("\nUnscheduled:")
+		for _, u := range plan.Unscheduled {
+			if u.Needed > 0 {
+				fmt.Printf("  - %s (%s): %s\n", u.Task.Title, taskspkg.FormatDuration(u.Needed), u.Reason)
+			} else {
+				fmt.Printf("  - %s: %s\n", u.Task.Title, u.Reason)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package task_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+)
+
+func TestPlanCommandWritesBlocks(t *testing.T) {
+	tasksPath := seedTasks(t, []models.Task{
+		{ID: "1", Title: "Write report", Status: "to-do", Priority: "high", Estimate: "1h"},
+		{ID: "2", Title: "Huge migration", Status: "to-do", Priority: "low", Estimate: "200h"},
+	})
+	out := execRootCapture(t, "task", "plan", "--days", "7", "--write")
+	if !strings.Contains(out, "task  Write report") {
+		t.Fatalf("expected planned block in output: %s", out)
+	}
+	if !strings.Contains(out, "Unscheduled:") || !strings.Contains(out, "Huge migration") {
+		t.Fatalf("expected oversized task to be unscheduled: %s", out)
+	}
+
+	cal, _ := storage.NewStorage(filepath.Join(filepath.Dir(tasksPath), "calendar.yaml"))
+	events, err := cal.ReadCalendarEvents()
+	if err != nil {
+		t.Fatalf("read calendar: %v", err)
+	}
+	if len(events) != 1 || events[0].Title != "Write report" || !strings.HasPrefix(events[0].ID, "plan-1-") {
+		t.Fatalf("expected one plan event, got %+v", events)
+	}
+
+	// Re-running replaces earlier blocks instead of stacking them.
+	_ = execRootCapture(t, "task", "plan", "--days", "7", "--write")
+	events, _ = cal.ReadCalendarEvents()
+	if len(events) != 1 {
+		t.Fatalf("expected plan events to be replaced, got %+v", events)
+	}
+}
@@ -2,25 +2,41 @@ package task
 
 import (
 	"fmt"
+	"sort"
 	"taskflow/internal/config"
+	"taskflow/internal/models"
 	"taskflow/internal/storage"
+	"taskflow/internal/tasks"
+	"taskflow/internal/taskstore"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	PrioritizeCmd.Flags().Bool("explain", false, "Show each task's score breakdown")
+	PrioritizeCmd.Flags().Bool("dry-run", false, "Show priority changes without writing them")
+}
+
 var PrioritizeCmd = &cobra.Command{
 	Use:   "prioritize",
 	Short: "Prioritize tasks based on due date and calendar events",
+	Long: `Scores every open task from due-date proximity, age, tags, dependencies and
+calendar load, then maps the score onto high/medium/low. Priorities can go
+down as well as up. Weights and thresholds come from config:
+
+  prioritize.weights.{due,age,dependencies,calendar}
+  prioritize.tag_weights.<tag>
+  prioritize.thresholds.{high,medium}`,
 	Run: func(cmd *cobra.Command, args []string) {
 		taskStoragePath := config.GetStoragePath()
-		taskStorage, err := storage.NewStorage(taskStoragePath)
+		taskStorage, err := taskstore.Open(taskStoragePath)
 		if err != nil {
 			fmt.Printf("Error creating task storage: %v\n", err)
 			return
 		}
 
-		tasks, err := taskStorage.ReadTasks()
+		all, err := taskStorage.ReadTasks()
 		if err != nil {
 			fmt.Printf("Error reading tasks: %v\n", err)
 			return
@@ -39,40 +55,55 @@ var PrioritizeCmd = &cobra.Command{
 			return
 		}
 
+		explain, _ := cmd.Flags().GetBool("explain")
+		dry, _ := cmd.Flags().GetBool("dry-run")
+
+		weights := tasks.ScoreWeights{
+			Due:          config.GetPrioritizeWeight("due"),
+			Age:          config.GetPrioritizeWeight("age"),
+			Dependencies: config.GetPrioritizeWeight("dependencies"),
+			Calendar:     config.GetPrioritizeWeight("calendar"),
+			Tags:         config.GetPrioritizeTagWeights(),
+		}
+		thresholds := tasks.ScoreThresholds{
+			High:   config.GetPrioritizeThreshold("high"),
+			Medium: config.GetPrioritizeThreshold("medium"),
+		}
+
 		now := time.Now()
-		in24Hours := now.Add(24 * time.Hour)
-
-		for i, task := range tasks {
-			originalPriority := tasks[i].Priority
-			// Prioritize based on due date
-			if task.DueDate != "" {
-				dueDate, err := time.Parse(time.RFC3339, task.DueDate)
-				if err == nil {
-					if dueDate.After(now) && dueDate.Before(in24Hours) {
-						tasks[i].Priority = "highest" // Highest priority
-					}
-				}
-			}
+		scores := tasks.ScoreTasks(all, events, weights, now)
 
-			// Prioritize based on calendar events
-			for _, event := range events {
-				if task.Title == event.Title {
-					eventTime, err := time.Parse(time.RFC3339, event.StartTime)
-					if err == nil {
-						if eventTime.After(now) && eventTime.Before(in24Hours) {
-							tasks[i].Priority = "highest" // Highest priority
-						}
-					}
-				}
-			}
+		if explain {
+			printScores(all, scores, thresholds)
+		}
 
-			if tasks[i].Priority != originalPriority {
-				// record that this task was updated
-				tasks[i].UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		changed := 0
+		for i, t := range all {
+			score, ok := scores[t.ID]
+			if !ok {
+				continue
+			}
+			next := tasks.PriorityForScore(score.Total(), thresholds)
+			if next == t.Priority {
+				continue
 			}
+			changed++
+			fmt.Printf("~ %s: %s → %s (score %.2f)\n", t.Title, displayPriority(t.Priority), next, score.Total())
+			all[i].Priority = next
+			// record that this task was updated
+			all[i].UpdatedAt = now.UTC().Format(time.RFC3339)
 		}
 
-		if err := taskStorage.WriteTasks(tasks); err != nil {
+		if dry {
+			fmt.Printf("Dry run: %d priority changes not written.\n", changed)
+			return
+		}
+		if changed == 0 {
+			fmt.Println("No priority changes.")
+			return
+		}
+
+		if err := taskStorage.WriteTasks(all); err != nil {
 			fmt.Printf("Error writing tasks: %v\n", err)
 			return
 		}
@@ -80,3 +111,30 @@ var PrioritizeCmd = &cobra.Command{
 		fmt.Println("Tasks prioritized successfully.")
 	},
 }
+
+// printScores prints a score table, highest score first.
+func printScores(all []models.Task, scores map[string]tasks.ScoreBreakdown, th tasks.ScoreThresholds) {
+	var scored []models.Task
+	for _, t := range all {
+		if _, ok := scores[t.ID]; ok {
+			scored = append(scored, t)
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scores[scored[i].ID].Total() > scores[scored[j].ID].Total()
+	})
+	fmt.Printf("%6s  %-6s  %6s %6s %6s %6s %6s  %s\n", "SCORE", "PRIO", "DUE", "AGE", "TAGS", "DEPS", "CAL", "TITLE")
+	for _, t := range scored {
+		s := scores[t.ID]
+		fmt.Printf("%6.2f  %-6s  %6.2f %6.2f %6.2f %6.2f %6.2f  %s\n",
+			s.Total(), tasks.PriorityForScore(s.Total(), th), s.Due, s.Age, s.Tags, s.Dependencies, s.Calendar, t.Title)
+	}
+	fmt.Println()
+}
+
+func displayPriority(p string) string {
+	if p == "" {
+		return "(none)"
+	}
+	return p
+}
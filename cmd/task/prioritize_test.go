@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +34,7 @@ func TestPrioritizeCommand(t *testing.T) {
 	_ = stTasks.WriteTasks([]models.Task{
 		{ID: "1", Title: "DueSoon", DueDate: dueSoon, Priority: "low", Status: "todo"},
 		{ID: "2", Title: "EventSoon", Priority: "low", Status: "todo"},
+		{ID: "3", Title: "Someday", Priority: "high", Status: "todo"},
 	})
 	// seed calendar events
 	calPath := filepath.Join(cfgDir, "calendar.yaml")
@@ -55,15 +57,36 @@ func TestPrioritizeCommand(t *testing.T) {
 	updated, _ := stTasks.ReadTasks()
 	foundDue := false
 	foundEvent := false
+	lowered := false
 	for _, tk := range updated {
-		if tk.ID == "1" && tk.Priority == "highest" {
+		if tk.ID == "1" && tk.Priority == "high" {
 			foundDue = true
 		}
-		if tk.ID == "2" && tk.Priority == "highest" {
+		if tk.ID == "2" && tk.Priority == "high" {
 			foundEvent = true
 		}
+		if tk.ID == "3" && tk.Priority == "low" {
+			lowered = true
+		}
 	}
 	if !foundDue || !foundEvent {
 		t.Fatalf("expected both tasks promoted, got %+v", updated)
 	}
+	if !lowered {
+		t.Fatalf("expected task without urgency to be lowered, got %+v", updated)
+	}
+}
+
+func TestPrioritizeDryRunExplain(t *testing.T) {
+	tasksPath := seedTasks(t, []models.Task{
+		{ID: "1", Title: "Overdue", DueDate: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339), Priority: "low", Status: "to-do"},
+	})
+	before := readTasksFile(t, tasksPath)
+	out := execRootCapture(t, "task", "prioritize", "--dry-run", "--explain")
+	if !strings.Contains(out, "SCORE") || !strings.Contains(out, "Overdue: low → high") || !strings.Contains(out, "Dry run") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if after := readTasksFile(t, tasksPath); after != before {
+		t.Fatalf("dry run modified tasks file:\n%s", after)
+	}
 }
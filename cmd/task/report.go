@@ -0,0 +1,121 @@
+package task
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"taskflow/internal/config"
+	"taskflow/internal/stats"
+	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ReportTimeCmd.Flags().String("by", "task", "Group totals by task, tag or day")
+	ReportTimeCmd.Flags().String("since", "", "Only count entries starting on or after this date (YYYY-MM-DD or RFC3339)")
+	ReportTimeCmd.Flags().String("until", "", "Only count entries starting on or before this date (YYYY-MM-DD, inclusive, or RFC3339)")
+	ReportTimeCmd.Flags().Bool("csv", false, "Print the report as CSV")
+	ReportCmd.AddCommand(ReportTimeCmd)
+}
+
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Reports over task data",
+}
+
+var ReportTimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Total logged time by task, tag or day",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, tasks, ok := loadTasks()
+		if !ok {
+			return
+		}
+		// Time logged on archived tasks still counts.
+		if archivePath := config.GetArchiveFilePath(); archivePath != "" {
+			a, _ := storage.NewStorage(archivePath)
+			archived, err := a.ReadTasks()
+			if err != nil {
+				fmt.Printf("Error reading archive: %v\n", err)
+				return
+			}
+			tasks = append(tasks, archived...)
+		}
+
+		var err error
+		opts := stats.TimeOptions{Now: time.Now()}
+		opts.By, _ = cmd.Flags().GetString("by")
+		if v, _ := cmd.Flags().GetString("since"); v != "" {
+			if opts.Since, err = stats.ParseBound(v); err != nil {
+				fmt.Printf("Invalid --since: %v\n", err)
+				return
+			}
+		}
+		if v, _ := cmd.Flags().GetString("until"); v != "" {
+			if opts.Until, err = stats.ParseUntil(v); err != nil {
+				fmt.Printf("Invalid --until: %v\n", err)
+				return
+			}
+		}
+
+		totals, err := stats.TimeReport(tasks, opts)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if asCSV, _ := cmd.Flags().GetBool("csv"); asCSV {
+			if err := writeTimeCSV(totals, opts.By); err != nil {
+				fmt.Printf("Error writing CSV: %v\n", err)
+			}
+			return
+		}
+		printTimeReport(totals, opts.By)
+	},
+}
+
+func printTimeReport(totals []stats.TimeTotal, by string) {
+	if len(totals) == 0 {
+		fmt.Println("No time logged in this period.")
+		return
+	}
+	width := 0
+	for _, t := range totals {
+		if len(t.Label) > width {
+			width = len(t.Label)
+		}
+	}
+	var sum time.Duration
+	for _, t := range totals {
+		fmt.Printf("%-*s %8s  (%d entries)\n", width, t.Label, taskspkg.FormatDuration(t.Duration), t.Entries)
+		sum += t.Duration
+	}
+	// Tag totals overlap when a task has several tags, so a sum would overcount.
+	if by != "tag" {
+		fmt.Printf("%-*s %8s\n", width, "Total", taskspkg.FormatDuration(sum))
+	}
+}
+
+// writeTimeCSV prints totals with hours as a decimal for spreadsheets.
+func writeTimeCSV(totals []stats.TimeTotal, by string) error {
+	w := csv.NewWriter(os.Stdout)
+	header := []string{"key", "label", "hours", "entries"}
+	if by == "task" || by == "" {
+		header[0], header[1] = "id", "title"
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, t := range totals {
+		row := []string{t.Key, t.Label, strconv.FormatFloat(t.Duration.Hours(), 'f', 2, 64), strconv.Itoa(t.Entries)}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
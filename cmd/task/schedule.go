@@ -5,6 +5,7 @@ import (
 	"taskflow/internal/config"
 	"taskflow/internal/models"
 	"taskflow/internal/storage"
+	"taskflow/internal/taskstore"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,7 +30,7 @@ var ScheduleCmd = &cobra.Command{
 		}
 
 		taskStoragePath := config.GetStoragePath()
-		taskStorage, err := storage.NewStorage(taskStoragePath)
+		taskStorage, err := taskstore.Open(taskStoragePath)
 		if err != nil {
 			fmt.Printf("Error creating task storage: %v\n", err)
 			return
@@ -42,6 +43,7 @@ var ScheduleCmd = &cobra.Command{
 				Title:     event.Title,
 				DueDate:   event.StartTime,
 				UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+				CreatedAt: time.Now().UTC().Format(time.RFC3339),
 			}
 			newTasks = append(newTasks, task)
 		}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"taskflow/internal/config"
 	"taskflow/internal/storage"
+	"taskflow/internal/workflow"
 
 	"github.com/spf13/cobra"
 )
@@ -34,7 +35,7 @@ var SearchCmd = &cobra.Command{
 		for _, task := range tasks {
 			if strings.Contains(strings.ToLower(task.Title), query) {
 				status := " "
-				if task.Status == "done" {
+				if workflow.Current().IsTerminal(task.Status) {
 					status = "x"
 				}
 				fmt.Printf("[%s] %s\n", status, task.Title)
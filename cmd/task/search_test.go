@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"taskflow/cmd"
 	"taskflow/internal/config"
@@ -21,6 +23,7 @@ func execRootCapture(t *testing.T, args ...string) string {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 	root := cmd.NewRootCmd()
+	resetFlags(root)
 	root.SetOut(buf)
 	root.SetErr(buf)
 	root.SetArgs(args)
@@ -32,6 +35,29 @@ func execRootCapture(t *testing.T, args ...string) string {
 	return buf.String()
 }
 
+// resetFlags puts every flag of c and its subcommands back to its default;
+// the commands are package globals, so flags set by one test would otherwise
+// leak into the next.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			var def []string
+			if v := strings.Trim(f.DefValue, "[]"); v != "" {
+				def = strings.Split(v, ",")
+			}
+			_ = s.Replace(def)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
 func seedTasks(t *testing.T, tasks []models.Task) string {
 	t.Helper()
 	tempHome := t.TempDir()
@@ -0,0 +1,82 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ShowCmd.Flags().Bool("history", false, "Show the task's change history")
+}
+
+var ShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a task's details and lifecycle timestamps",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		storagePath := config.GetStoragePath()
+		s, err := storage.NewStorage(storagePath)
+		if err != nil {
+			fmt.Printf("Error creating storage: %v\n", err)
+			return
+		}
+
+		tasks, err := s.ReadTasks()
+		if err != nil {
+			fmt.Printf("Error reading tasks: %v\n", err)
+			return
+		}
+
+		i, err := taskspkg.FindByID(tasks, args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		t := tasks[i]
+
+		fields := []struct{ name, val string }{
+			{"ID", t.ID},
+			{"Title", t.Title},
+			{"Status", t.Status},
+			{"Priority", t.Priority},
+			{"Due", t.DueDate},
+			{"Estimate", t.Estimate},
+			{"Tags", strings.Join(t.Tags, ", ")},
+			{"Depends on", strings.Join(t.DependsOn, ", ")},
+			{"Source", t.Source},
+			{"Link", t.Link},
+			{"Notes", t.Notes},
+			{"Created", t.CreatedAt},
+			{"Started", t.StartedAt},
+			{"Completed", t.CompletedAt},
+			{"Updated", t.UpdatedAt},
+			{"Archived", t.ArchivedAt},
+		}
+		for _, f := range fields {
+			if f.val != "" {
+				fmt.Printf("%-11s %s\n", f.name+":", f.val)
+			}
+		}
+
+		if showHistory, _ := cmd.Flags().GetBool("history"); !showHistory {
+			return
+		}
+		fmt.Println("\nHistory:")
+		if len(t.History) == 0 {
+			if !config.HistoryEnabled() {
+				fmt.Println("  (none recorded; set history.enabled: true to start tracking changes)")
+			} else {
+				fmt.Println("  (none recorded)")
+			}
+			return
+		}
+		for _, c := range t.History {
+			fmt.Printf("  %s\n", taskspkg.FormatChange(c))
+		}
+	},
+}
@@ -0,0 +1,36 @@
+package task_test
+
+import (
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+)
+
+func TestShowPrintsLifecycleAndHistory(t *testing.T) {
+	seedTasks(t, []models.Task{{ID: "abc1", Title: "Write docs", Status: "to-do"}})
+	out := execRootCapture(t, "task", "show", "abc")
+	if !strings.Contains(out, "Write docs") || !strings.Contains(out, "Created:") {
+		t.Fatalf("expected details with created time, got: %s", out)
+	}
+
+	out = execRootCapture(t, "task", "show", "abc1", "--history")
+	if !strings.Contains(out, "history.enabled") {
+		t.Fatalf("expected hint about enabling history, got: %s", out)
+	}
+
+	seedTasks(t, []models.Task{{ID: "abc1", Title: "Write docs", Status: "done", History: []models.Change{
+		{At: "2025-10-01T09:00:00Z", Field: "status", From: "to-do", To: "done"},
+	}}})
+	out = execRootCapture(t, "task", "show", "abc1", "--history")
+	if !strings.Contains(out, `status: "to-do" → "done"`) {
+		t.Fatalf("expected history entry, got: %s", out)
+	}
+}
+
+func TestShowUnknownID(t *testing.T) {
+	seedTasks(t, []models.Task{{ID: "1", Title: "A"}})
+	out := execRootCapture(t, "task", "show", "zzz")
+	if !strings.Contains(out, "no task with id") {
+		t.Fatalf("expected not found error, got: %s", out)
+	}
+}
@@ -1,13 +1,25 @@
 package task
 
 import (
+	"encoding/json"
 	"fmt"
 	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/stats"
 	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	StatsCmd.Flags().String("since", "", "Only count work from this date (YYYY-MM-DD or RFC3339)")
+	StatsCmd.Flags().String("until", "", "Only count work up to this date (YYYY-MM-DD, inclusive, or RFC3339)")
+	StatsCmd.Flags().Int("weeks", 8, "Weeks of throughput to show when --since is not set")
+	StatsCmd.Flags().Bool("json", false, "Print the report as JSON")
+}
+
 var StatsCmd = &cobra.Command{
 	Use:     "stats",
 	Short:   "Show task statistics",
@@ -26,16 +38,86 @@ var StatsCmd = &cobra.Command{
 			return
 		}
 
-		totalTasks := len(tasks)
-		var completedTasks int
-		for _, task := range tasks {
-			if task.Status == "done" {
-				completedTasks++
+		var archived []models.Task
+		if archivePath := config.GetArchiveFilePath(); archivePath != "" {
+			a, _ := storage.NewStorage(archivePath)
+			if archived, err = a.ReadTasks(); err != nil {
+				fmt.Printf("Error reading archive: %v\n", err)
+				return
+			}
+		}
+
+		opts := stats.Options{Now: time.Now()}
+		opts.Weeks, _ = cmd.Flags().GetInt("weeks")
+		if v, _ := cmd.Flags().GetString("since"); v != "" {
+			if opts.Since, err = stats.ParseBound(v); err != nil {
+				fmt.Printf("Invalid --since: %v\n", err)
+				return
+			}
+		}
+		if v, _ := cmd.Flags().GetString("until"); v != "" {
+			if opts.Until, err = stats.ParseUntil(v); err != nil {
+				fmt.Printf("Invalid --until: %v\n", err)
+				return
 			}
 		}
 
-		fmt.Printf("Total tasks: %d\n", totalTasks)
-		fmt.Printf("Completed tasks: %d\n", completedTasks)
-		fmt.Printf("Pending tasks: %d\n", totalTasks-completedTasks)
+		report := stats.Compute(tasks, archived, opts)
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			out, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				fmt.Printf("Error encoding report: %v\n", err)
+				return
+			}
+			fmt.Println(string(out))
+			return
+		}
+
+		fmt.Printf("Total tasks: %d\n", report.Total)
+		fmt.Printf("Completed tasks: %d\n", report.Completed)
+		fmt.Printf("Pending tasks: %d\n", report.Pending)
+		fmt.Printf("Overdue tasks: %d\n", report.Overdue)
+		fmt.Printf("Archived tasks: %d\n", report.Archived)
+
+		printBreakdown("By status", report.ByStatus)
+		printBreakdown("By priority", report.ByPriority)
+		printBreakdown("By tag", report.ByTag)
+
+		if len(report.Throughput) > 0 {
+			var counts []int
+			total := 0
+			for _, w := range report.Throughput {
+				counts = append(counts, w.Count)
+				total += w.Count
+			}
+			first := report.Throughput[0].Week
+			last := report.Throughput[len(report.Throughput)-1].Week
+			fmt.Printf("\nCompleted per week (%s .. %s): %s  total %d\n", first, last, stats.Sparkline(counts), total)
+		}
+		if report.LeadTimeSamples > 0 {
+			fmt.Printf("Average lead time: %s (%d tasks)\n", taskspkg.FormatDuration(report.AvgLeadTime), report.LeadTimeSamples)
+		} else {
+			fmt.Println("Average lead time: n/a (no tasks with created and completed times)")
+		}
 	},
-}
\ No newline at end of file
+}
+
+func printBreakdown(title string, counts []stats.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	max, width := 0, 0
+	for _, c := range counts {
+		if c.Count > max {
+			max = c.Count
+		}
+		if len(c.Label) > width {
+			width = len(c.Label)
+		}
+	}
+	fmt.Printf("\n%s:\n", title)
+	for _, c := range counts {
+		fmt.Printf("  %-*s %4d %s\n", width, c.Label, c.Count, stats.Bar(c.Count, max, 30))
+	}
+}
@@ -2,11 +2,13 @@ package task_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"taskflow/cmd"
@@ -34,6 +36,7 @@ func execSimple(t *testing.T, args ...string) string {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 	root := cmd.NewRootCmd()
+	resetFlags(root)
 	root.SetOut(buf)
 	root.SetErr(buf)
 	root.SetArgs(args)
@@ -56,3 +59,53 @@ func TestStatsCommand(t *testing.T) {
 		t.Fatalf("unexpected stats output: %s", out)
 	}
 }
+
+func TestStatsCommandBreakdownsArchiveAndJSON(t *testing.T) {
+	now := time.Now().UTC()
+	created := now.Add(-72 * time.Hour).Format(time.RFC3339)
+	completed := now.Add(-24 * time.Hour).Format(time.RFC3339)
+	tasksPath := seedTasks(t, []models.Task{
+		{ID: "1", Title: "Open", Status: "to-do", Priority: "high", Tags: []string{"ops"}, DueDate: "2000-01-01"},
+		{ID: "2", Title: "Closed", Status: "done", Priority: "low", CreatedAt: created, CompletedAt: completed},
+	})
+	archive, _ := storage.NewStorage(filepath.Join(filepath.Dir(tasksPath), "tasks.archive.yaml"))
+	_ = archive.WriteTasks([]models.Task{{ID: "3", Title: "Old", Status: "done", Priority: "low", Tags: []string{"ops"}}})
+
+	out := execSimple(t, "task", "stats")
+	for _, want := range []string{"Total tasks: 3", "Completed tasks: 2", "Overdue tasks: 1", "Archived tasks: 1", "By tag:", "ops", "Average lead time: 48h (1 tasks)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output: %s", want, out)
+		}
+	}
+
+	out = execSimple(t, "task", "stats", "--json")
+	var report struct {
+		Total      int `json:"total"`
+		Throughput []struct {
+			Count int `json:"count"`
+		} `json:"throughput"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	if report.Total != 3 || len(report.Throughput) == 0 || report.Throughput[len(report.Throughput)-1].Count+report.Throughput[len(report.Throughput)-2].Count != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestStatsUntilIncludesThatDay(t *testing.T) {
+	seedTasks(t, []models.Task{
+		{ID: "1", Title: "Shipped", Status: "done", CreatedAt: "2026-01-10T09:00:00Z", CompletedAt: "2026-01-15T15:00:00Z"},
+	})
+	out := execSimple(t, "task", "stats", "--until", "2026-01-15", "--json")
+	var report struct {
+		Completed       int `json:"completed"`
+		LeadTimeSamples int `json:"lead_time_samples"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out)
+	}
+	if report.Completed != 1 || report.LeadTimeSamples != 1 {
+		t.Fatalf("task completed on the --until date not counted: %s", out)
+	}
+}
@@ -0,0 +1,117 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+	taskspkg "taskflow/internal/tasks"
+	"taskflow/internal/taskstore"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	StopCmd.Flags().String("note", "", "Note to attach to the closed time entry")
+}
+
+var StartCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start a timer on a task",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		s, tasks, ok := loadTasks()
+		if !ok {
+			return
+		}
+		i, err := taskspkg.FindByID(tasks, args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := taskspkg.StartTimer(tasks, i, time.Now()); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		title := tasks[i].Title
+		if err := s.WriteTasks(tasks); err != nil {
+			fmt.Printf("Error writing tasks: %v\n", err)
+			return
+		}
+		fmt.Printf("Started timer on: %s\n", title)
+	},
+}
+
+var StopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, tasks, ok := loadTasks()
+		if !ok {
+			return
+		}
+		note, _ := cmd.Flags().GetString("note")
+		i, d, err := taskspkg.StopTimer(tasks, time.Now(), note)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		title := tasks[i].Title
+		total := taskspkg.TimeSpent(tasks[i], time.Now())
+		if err := s.WriteTasks(tasks); err != nil {
+			fmt.Printf("Error writing tasks: %v\n", err)
+			return
+		}
+		fmt.Printf("Stopped timer on: %s (%s, %s total)\n", title, taskspkg.FormatDuration(d), taskspkg.FormatDuration(total))
+	},
+}
+
+var LogCmd = &cobra.Command{
+	Use:   "log <id> <duration> [note]",
+	Short: "Record time spent on a task, e.g. task log 42 1h30m \"code review\"",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := time.ParseDuration(args[1])
+		if err != nil {
+			fmt.Printf("Invalid duration %q: %v\n", args[1], err)
+			return
+		}
+		s, tasks, ok := loadTasks()
+		if !ok {
+			return
+		}
+		i, err := taskspkg.FindByID(tasks, args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := taskspkg.LogTime(&tasks[i], d, strings.Join(args[2:], " "), time.Now()); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		title := tasks[i].Title
+		if err := s.WriteTasks(tasks); err != nil {
+			fmt.Printf("Error writing tasks: %v\n", err)
+			return
+		}
+		fmt.Printf("Logged %s on: %s\n", taskspkg.FormatDuration(d), title)
+	},
+}
+
+// loadTasks opens the configured tasks file, printing any error.
+func loadTasks() (*storage.Storage, []models.Task, bool) {
+	s, err := taskstore.Open(config.GetStoragePath())
+	if err != nil {
+		fmt.Printf("Error creating storage: %v\n", err)
+		return nil, nil, false
+	}
+	tasks, err := s.ReadTasks()
+	if err != nil {
+		fmt.Printf("Error reading tasks: %v\n", err)
+		return nil, nil, false
+	}
+	return s, tasks, true
+}
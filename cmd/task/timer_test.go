@@ -0,0 +1,70 @@
+package task_test
+
+import (
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+	"testing"
+	"time"
+)
+
+func TestStartStopAndLog(t *testing.T) {
+	path := seedTasks(t, []models.Task{
+		{ID: "1", Title: "Billable work", Status: "to-do", Tags: []string{"acme"}},
+		{ID: "2", Title: "Other", Status: "to-do"},
+	})
+
+	if out := execRootCapture(t, "task", "start", "1"); !strings.Contains(out, "Started timer on: Billable work") {
+		t.Fatalf("start: %s", out)
+	}
+	if out := execRootCapture(t, "task", "start", "2"); !strings.Contains(out, "already running") {
+		t.Fatalf("second start should be refused: %s", out)
+	}
+	if out := execRootCapture(t, "task", "stop"); !strings.Contains(out, "Stopped timer on: Billable work") {
+		t.Fatalf("stop: %s", out)
+	}
+	if out := execRootCapture(t, "task", "log", "1", "1h30m", "code", "review"); !strings.Contains(out, "Logged 1h30m on: Billable work") {
+		t.Fatalf("log: %s", out)
+	}
+
+	st, _ := storage.NewStorage(path)
+	tasks, _ := st.ReadTasks()
+	if len(tasks[0].Worklog) != 2 || tasks[0].Worklog[1].Note != "code review" || tasks[0].Status != "in-progress" {
+		t.Fatalf("unexpected worklog: %+v", tasks[0])
+	}
+}
+
+func TestReportTimeCSV(t *testing.T) {
+	start := time.Now().Add(-3 * time.Hour).UTC()
+	seedTasks(t, []models.Task{{ID: "1", Title: "Billable work", Tags: []string{"acme"}, Worklog: []models.WorkEntry{
+		{Start: start.Format(time.RFC3339), End: start.Add(2 * time.Hour).Format(time.RFC3339)},
+	}}})
+
+	out := execRootCapture(t, "task", "report", "time", "--by", "tag", "--csv")
+	if !strings.Contains(out, "key,label,hours,entries") || !strings.Contains(out, "acme,acme,2.00,1") {
+		t.Fatalf("unexpected CSV: %s", out)
+	}
+	out = execRootCapture(t, "task", "report", "time", "--by", "task", "--csv=false")
+	if !strings.Contains(out, "Billable work") || !strings.Contains(out, "Total") {
+		t.Fatalf("unexpected text report: %s", out)
+	}
+
+	// A date-only --until includes that whole day.
+	out = execRootCapture(t, "task", "report", "time", "--csv", "--until", start.Format("2006-01-02"))
+	if !strings.Contains(out, "2.00,1") {
+		t.Fatalf("expected the entry within --until, got: %s", out)
+	}
+}
+
+func TestReportTimeTotalsOverADay(t *testing.T) {
+	start := time.Now().Add(-72 * time.Hour).UTC()
+	seedTasks(t, []models.Task{{ID: "1", Title: "Migration", Worklog: []models.WorkEntry{
+		{Start: start.Format(time.RFC3339), End: start.Add(20 * time.Hour).Format(time.RFC3339)},
+		{Start: start.Add(24 * time.Hour).Format(time.RFC3339), End: start.Add(29*time.Hour + 30*time.Minute).Format(time.RFC3339)},
+	}}})
+
+	out := execRootCapture(t, "task", "report", "time", "--by", "task")
+	if !strings.Contains(out, "25h30m") || strings.Contains(out, "1d") {
+		t.Fatalf("expected the total in hours and minutes, got: %s", out)
+	}
+}
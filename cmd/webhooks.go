@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"taskflow/internal/config"
+	"taskflow/internal/webhooks"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	webhooksOnce     bool
+	webhooksInterval time.Duration
+)
+
+// newWebhookWorker returns the worker for the configured webhooks, or nil
+// when none are configured.
+func newWebhookWorker(log io.Writer) (*webhooks.Worker, error) {
+	endpoints, err := config.GetWebhooks()
+	if err != nil || len(endpoints) == 0 {
+		return nil, err
+	}
+	return &webhooks.Worker{
+		Queue:       webhooks.NewQueue(config.GetWebhookQueueDir()),
+		Endpoints:   endpoints,
+		ReadTasks:   storageHost{}.ReadTasks,
+		MaxAttempts: config.GetWebhookMaxAttempts(),
+		Log:         log,
+	}, nil
+}
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Deliver task events to webhooks",
+}
+
+var webhooksRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Deliver queued webhooks and queue overdue events",
+	Long: `Deliver queued webhooks and queue overdue events.
+
+Created and completed tasks are queued when they are written; this worker
+(or 'taskflow serve') sends them, retrying failures with backoff. Use --once
+to process the queue a single time, e.g. from cron.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		w, err := newWebhookWorker(cmd.OutOrStdout())
+		if err != nil {
+			fmt.Printf("Error loading webhooks: %v\n", err)
+			return
+		}
+		if w == nil {
+			fmt.Println("No webhooks configured (see webhooks.endpoints).")
+			return
+		}
+		if webhooksOnce {
+			res, err := w.Tick(context.Background(), time.Now())
+			if err != nil {
+				fmt.Printf("Error delivering webhooks: %v\n", err)
+				return
+			}
+			fmt.Printf("Sent %d, retrying %d, failed %d.\n", res.Sent, res.Retrying, res.Failed)
+			return
+		}
+		interval := webhooksInterval
+		if interval <= 0 {
+			interval = config.GetWebhookInterval()
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		fmt.Printf("Delivering webhooks every %s (Ctrl+C to stop)\n", interval)
+		w.Run(ctx, interval)
+	},
+}
+
+var webhooksStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show pending and failed webhook deliveries",
+	Run: func(cmd *cobra.Command, args []string) {
+		q := webhooks.NewQueue(config.GetWebhookQueueDir())
+		pending, err := q.Pending()
+		if err != nil {
+			fmt.Printf("Error reading webhook queue: %v\n", err)
+			return
+		}
+		failed, err := q.Failed()
+		if err != nil {
+			fmt.Printf("Error reading webhook queue: %v\n", err)
+			return
+		}
+		fmt.Printf("Pending: %d\n", len(pending))
+		for _, d := range pending {
+			fmt.Printf("  %-15s %-16s task %s, attempt %d, next %s\n", d.Event, d.Endpoint, d.TaskID, d.Attempts+1, d.NextAttempt.Local().Format("2006-01-02 15:04:05"))
+		}
+		fmt.Printf("Failed: %d\n", len(failed))
+		for _, d := range failed {
+			fmt.Printf("  %-15s %-16s task %s: %s\n", d.Event, d.Endpoint, d.TaskID, d.LastError)
+		}
+	},
+}
+
+func init() {
+	webhooksRunCmd.Flags().BoolVar(&webhooksOnce, "once", false, "Process the queue once and exit")
+	webhooksRunCmd.Flags().DurationVar(&webhooksInterval, "interval", 0, "Polling interval (default webhooks.interval)")
+	webhooksCmd.AddCommand(webhooksRunCmd)
+	webhooksCmd.AddCommand(webhooksStatusCmd)
+}
@@ -0,0 +1,113 @@
+// Package codescan finds TODO, FIXME and HACK comments in a source tree and
+// keeps a task for each of them: new comments become tasks, tasks whose
+// comment went away are completed.
+//
+// A comment's task ID is derived from its file, kind, text and position
+// among identical comments, so scanning again finds the same tasks however
+// far the comment moved within its file.
+package codescan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Comment is a TODO, FIXME or HACK comment.
+type Comment struct {
+	Path   string // absolute
+	Line   int    // 1-based
+	Kind   string // TODO, FIXME or HACK
+	Text   string
+	Author string // from git blame; empty when unknown
+	// N counts the identical comments (same kind and text) above this one
+	// in the file, so each gets its own task.
+	N int
+}
+
+// Key identifies the comment across scans.
+func (c Comment) Key() string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d", c.Path, c.Kind, c.Text, c.N)
+}
+
+// Location is the comment's place as an editor takes it, path:line.
+func (c Comment) Location() string { return fmt.Sprintf("%s:%d", c.Path, c.Line) }
+
+// comment matches a marker after the start of a comment: //, #, /*, *, --,
+// ;, <!-- or %. An author may follow in parentheses: TODO(ana): text.
+var comment = regexp.MustCompile(`(?://+|#+|/\*+|^\s*\*+|--|;+|<!--|%+)\s*(TODO|FIXME|HACK)\b(?:\(([^)]*)\))?:?\s*(.*)$`)
+
+var commentEnd = regexp.MustCompile(`\s*(\*/|-->)\s*$`)
+
+// maxSize is the largest file scanned; bigger files are data, not code.
+const maxSize = 1 << 20
+
+// Extract returns the comments of the file at path; binary and very large
+// files have none.
+func Extract(path string) ([]Comment, error) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxSize {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return nil, nil
+	}
+	var out []Comment
+	seen := map[string]int{}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), maxSize)
+	for n := 1; sc.Scan(); n++ {
+		m := comment.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		c := Comment{Path: path, Line: n, Kind: m[1], Author: strings.TrimSpace(m[2])}
+		c.Text = strings.Join(strings.Fields(commentEnd.ReplaceAllString(m[3], "")), " ")
+		c.N = seen[c.Kind+"\x00"+c.Text]
+		seen[c.Kind+"\x00"+c.Text]++
+		out = append(out, c)
+	}
+	return out, sc.Err()
+}
+
+// Scan returns the comments of every file under dir that git would track:
+// files ignored by .gitignore (and the .git directory) are skipped. With
+// blame, authors not named in the comment come from git blame.
+func Scan(dir string, blame bool) ([]Comment, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	files, repo, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Comment
+	for _, f := range files {
+		cs, err := Extract(f)
+		if err != nil {
+			return nil, err
+		}
+		if len(cs) == 0 {
+			continue
+		}
+		if blame && repo {
+			authors := blameAuthors(f)
+			for i := range cs {
+				if cs[i].Author == "" {
+					cs[i].Author = authors[cs[i].Line]
+				}
+			}
+		}
+		out = append(out, cs...)
+	}
+	return out, nil
+}
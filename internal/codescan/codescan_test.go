@@ -0,0 +1,213 @@
+package codescan
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	write(t, path, `package main
+
+// TODO: handle errors
+func main() {
+	x := 1 // FIXME(ana) off by one
+	/* HACK: works around a driver bug */
+	// TODO: handle errors
+	s := "TODO: not a comment"
+	// TODOS are not markers
+}
+`)
+	got, err := Extract(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Comment{
+		{Path: path, Line: 3, Kind: "TODO", Text: "handle errors"},
+		{Path: path, Line: 5, Kind: "FIXME", Text: "off by one", Author: "ana"},
+		{Path: path, Line: 6, Kind: "HACK", Text: "works around a driver bug"},
+		{Path: path, Line: 7, Kind: "TODO", Text: "handle errors", N: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("comments = %+v", got)
+	}
+
+	for _, tc := range []struct{ line, text string }{
+		{"# TODO: shell", "shell"},
+		{"-- FIXME: sql", "sql"},
+		{"<!-- TODO: html -->", "html"},
+		{" * TODO: doc block", "doc block"},
+		{"; HACK", ""},
+	} {
+		p := filepath.Join(dir, "x.txt")
+		write(t, p, tc.line+"\n")
+		cs, err := Extract(p)
+		if err != nil || len(cs) != 1 || cs[0].Text != tc.text {
+			t.Errorf("%q: %+v, %v", tc.line, cs, err)
+		}
+	}
+
+	bin := filepath.Join(dir, "blob.bin")
+	write(t, bin, "\x00\x01// TODO: binary\n")
+	if cs, err := Extract(bin); err != nil || len(cs) != 0 {
+		t.Fatalf("binary file: %+v, %v", cs, err)
+	}
+}
+
+func TestWalkGitignore(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, ".gitignore"), "build/\n*.log\n!keep.log\n/root.txt\ndocs/**/gen\n")
+	for _, f := range []string{
+		"a.go", "root.txt", "sub/root.txt", "x.log", "keep.log",
+		"build/out.go", "sub/build/out.go", "docs/a/b/gen", "docs/gen.md",
+		"vendor/.gitignore", "vendor/lib.go", "vendor/keep.go",
+		".git/HEAD",
+	} {
+		write(t, filepath.Join(dir, f), "")
+	}
+	write(t, filepath.Join(dir, "vendor/.gitignore"), "*.go\n!keep.go\n")
+
+	files, err := walk(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range files {
+		rel, _ := filepath.Rel(dir, f)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+	want := []string{".gitignore", "a.go", "docs/gen.md", "keep.log", "sub/root.txt", "vendor/.gitignore", "vendor/keep.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("files = %q", got)
+	}
+}
+
+func TestScanGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Ana Lee", "-c", "user.email=ana@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	write(t, filepath.Join(dir, ".gitignore"), "out/\n")
+	write(t, filepath.Join(dir, "a.py"), "x = 1\n# TODO: committed\n")
+	write(t, filepath.Join(dir, "out/gen.py"), "# TODO: generated\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+	write(t, filepath.Join(dir, "b.py"), "# FIXME: untracked\n")
+
+	cs, err := Scan(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, c := range cs {
+		got[c.Text] = c.Author
+	}
+	if !reflect.DeepEqual(got, map[string]string{"committed": "Ana Lee", "untracked": ""}) {
+		t.Fatalf("comments = %+v", cs)
+	}
+}
+
+func TestApply(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "src", "app")
+	path := filepath.Join(root, "main.go")
+	comments := []Comment{
+		{Path: path, Line: 3, Kind: "TODO", Text: "handle errors", Author: "ana"},
+		{Path: path, Line: 9, Kind: "FIXME", Text: ""},
+	}
+	elsewhere := models.Task{ID: "x", Title: "Other repo", Status: "to-do", Source: Source, Link: filepath.Join(string(filepath.Separator), "src", "application", "a.go") + ":1"}
+
+	all, res := Apply([]models.Task{elsewhere}, root, comments, nil, now)
+	if len(res.Added) != 2 || len(all) != 3 {
+		t.Fatalf("first scan: %+v", res)
+	}
+	todo := all[1]
+	if todo.Source != "code" || todo.Link != path+":3" || todo.Status != "to-do" ||
+		!reflect.DeepEqual(todo.Tags, []string{"code", "todo"}) || todo.Description != "TODO at main.go:3 by ana" {
+		t.Fatalf("todo task = %+v", todo)
+	}
+	if fixme := all[2]; fixme.Title != "FIXME in main.go" || fixme.Priority != "high" {
+		t.Fatalf("fixme task = %+v", fixme)
+	}
+
+	// Scanning the same comments again changes nothing.
+	again, res := Apply(all, root, comments, nil, now)
+	if res.Changed() || !reflect.DeepEqual(again, all) {
+		t.Fatalf("second scan: %+v", res)
+	}
+
+	// The TODO moved down; the FIXME is gone.
+	all[1].Title = "Handle errors properly"
+	moved := []Comment{comments[0]}
+	moved[0].Line = 5
+	state, err := LoadState(filepath.Join(t.TempDir(), "scan-state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, res = Apply(all, root, moved, state.ClosedIDs(), now)
+	if !state.Record(all, res, now) {
+		t.Fatal("closed task not recorded")
+	}
+	if len(res.Moved) != 1 || len(res.Closed) != 1 || len(res.Added) != 0 {
+		t.Fatalf("third scan: %+v", res)
+	}
+	if all[1].Link != path+":5" || all[1].Title != "Handle errors properly" || all[2].Status != "done" || all[2].CompletedAt == "" {
+		t.Fatalf("tasks = %+v", all)
+	}
+	if all[0].Status != "to-do" {
+		t.Fatalf("task outside the scanned dir was closed: %+v", all[0])
+	}
+	if _, res := Apply(all, root, moved, state.ClosedIDs(), now); res.Changed() {
+		t.Fatalf("fourth scan: %+v", res)
+	}
+
+	// The FIXME is back, say after switching branches: its task reopens.
+	back := append(moved, comments[1])
+	all, res = Apply(all, root, back, state.ClosedIDs(), now)
+	if len(res.Reopened) != 1 || len(res.Added)+len(res.Moved)+len(res.Closed) != 0 {
+		t.Fatalf("fifth scan: %+v", res)
+	}
+	if all[2].Status != "to-do" || all[2].CompletedAt != "" || len(all) != 3 {
+		t.Fatalf("tasks = %+v", all)
+	}
+	if !state.Record(all, res, now) || len(state.Closed) != 0 {
+		t.Fatalf("state = %+v", state.Closed)
+	}
+	if _, res := Apply(all, root, back, state.ClosedIDs(), now); res.Changed() {
+		t.Fatalf("sixth scan: %+v", res)
+	}
+
+	// A task completed by hand stays completed while its comment is there.
+	all[1].Status = "done"
+	if _, res := Apply(all, root, back, state.ClosedIDs(), now); res.Changed() {
+		t.Fatalf("scan after completing by hand: %+v", res)
+	}
+}
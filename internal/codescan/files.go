@@ -0,0 +1,205 @@
+package codescan
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// listFiles returns the files under dir and whether dir is in a git
+// repository. In a repository git lists them (tracked and untracked, not
+// ignored); elsewhere the tree is walked, applying .gitignore files.
+func listFiles(dir string) ([]string, bool, error) {
+	if out, err := exec.Command("git", "-C", dir, "ls-files", "-z", "--cached", "--others", "--exclude-standard").Output(); err == nil {
+		var files []string
+		for _, rel := range strings.Split(string(out), "\x00") {
+			if rel == "" {
+				continue
+			}
+			p := filepath.Join(dir, filepath.FromSlash(rel))
+			// Deleted but not yet staged files are still listed.
+			if info, err := os.Lstat(p); err == nil && info.Mode().IsRegular() {
+				files = append(files, p)
+			}
+		}
+		return files, true, nil
+	}
+	files, err := walk(dir)
+	return files, false, err
+}
+
+// walk lists the regular files under dir, skipping .git and what
+// .gitignore files exclude.
+func walk(dir string) ([]string, error) {
+	var files []string
+	var rules []ignoreRule
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if p != dir && ignored(rules, p, true) {
+				return filepath.SkipDir
+			}
+			more, err := readIgnore(p)
+			if err != nil {
+				return err
+			}
+			rules = append(rules, more...)
+			return nil
+		}
+		if d.Type().IsRegular() && !ignored(rules, p, false) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// ignoreRule is a .gitignore pattern, relative to the directory of its file.
+type ignoreRule struct {
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignored applies the rules in order; the last matching rule decides.
+func ignored(rules []ignoreRule, p string, isDir bool) bool {
+	result := false
+	for _, r := range rules {
+		rel, err := filepath.Rel(r.base, p)
+		if err != nil || strings.HasPrefix(rel, "..") || (r.dirOnly && !isDir) {
+			continue
+		}
+		if r.re.MatchString(filepath.ToSlash(rel)) {
+			result = !r.negate
+		}
+	}
+	return result
+}
+
+// readIgnore reads dir/.gitignore. It understands the common forms:
+// comments, !negation, a trailing / for directories, a leading or inner /
+// anchoring the pattern, and the *, ?, [...] and ** wildcards.
+func readIgnore(dir string) ([]ignoreRule, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rules []ignoreRule
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		r := ignoreRule{base: dir}
+		if strings.HasPrefix(line, "!") {
+			r.negate, line = true, line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly, line = true, strings.TrimSuffix(line, "/")
+		}
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		expr := globRegexp(line)
+		if !anchored {
+			expr = "(.*/)?" + expr
+		}
+		re, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			continue // a pattern git would not understand either
+		}
+		r.re = re
+		rules = append(rules, r)
+	}
+	return rules, sc.Err()
+}
+
+// globRegexp translates a gitignore glob into a regular expression.
+func globRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			b.WriteString("/.*")
+			i += 2
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			if j := strings.IndexByte(glob[i:], ']'); j > 0 {
+				class := glob[i+1 : i+j]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += j
+				continue
+			}
+			b.WriteString(`\[`)
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// blameAuthors maps line numbers of the file to their authors. Lines not
+// committed yet have none; so does a file git cannot blame.
+func blameAuthors(path string) map[int]string {
+	out, err := exec.Command("git", "-C", filepath.Dir(path), "blame", "--line-porcelain", "--", filepath.Base(path)).Output()
+	if err != nil {
+		return nil
+	}
+	authors := map[int]string{}
+	line := 0
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 64*1024), maxSize)
+	for sc.Scan() {
+		text := sc.Text()
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			// the line's content ends its entry
+		case len(text) >= 40 && isHash(text[:40]):
+			// "<hash> <orig line> <final line> [<lines in group>]"
+			if f := strings.Fields(text); len(f) >= 3 {
+				line, _ = strconv.Atoi(f[2])
+			}
+		case strings.HasPrefix(text, "author "):
+			if a := strings.TrimPrefix(text, "author "); a != "Not Committed Yet" {
+				authors[line] = a
+			}
+		}
+	}
+	return authors
+}
+
+func isHash(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
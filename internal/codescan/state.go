@@ -0,0 +1,65 @@
+package codescan
+
+import (
+	"taskflow/internal/models"
+	"taskflow/internal/statefile"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// State remembers which tasks a scan completed, so they can be reopened when
+// their comment comes back. It is kept as JSON in the storage directory.
+type State struct {
+	Closed map[string]time.Time `json:"closed"` // task ID -> when the scan closed it
+
+	path string
+}
+
+// LoadState reads the state file at path; a missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	s := &State{path: path}
+	if err := statefile.Load(path, s); err != nil {
+		return nil, err
+	}
+	if s.Closed == nil {
+		s.Closed = map[string]time.Time{}
+	}
+	return s, nil
+}
+
+// Save writes the state back to its file.
+func (s *State) Save() error { return statefile.Save(s.path, s) }
+
+// ClosedIDs returns the IDs of the tasks closed by a scan, for Apply.
+func (s *State) ClosedIDs() map[string]bool {
+	ids := make(map[string]bool, len(s.Closed))
+	for id := range s.Closed {
+		ids[id] = true
+	}
+	return ids
+}
+
+// Record notes the tasks Apply closed; all is the task list it returned.
+// Tasks open again (reopened by the scan or by hand) or deleted are
+// forgotten, so a task later completed by hand stays completed. It reports
+// whether the state changed.
+func (s *State) Record(all []models.Task, res Result, now time.Time) bool {
+	changed := false
+	for _, t := range res.Closed {
+		s.Closed[t.ID] = now.UTC()
+		changed = true
+	}
+	done := map[string]bool{}
+	for _, t := range all {
+		if workflow.Current().IsTerminal(t.Status) {
+			done[t.ID] = true
+		}
+	}
+	for id := range s.Closed {
+		if !done[id] {
+			delete(s.Closed, id)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,126 @@
+package codescan
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Source is the Source of the tasks kept for code comments.
+const Source = "code"
+
+// Result says what Apply changed.
+type Result struct {
+	Added    []models.Task
+	Moved    []models.Task // the comment is on another line now
+	Closed   []models.Task // the comment is gone
+	Reopened []models.Task // the comment is back after a scan closed the task
+}
+
+// Changed reports whether Apply changed any task.
+func (r Result) Changed() bool {
+	return len(r.Added)+len(r.Moved)+len(r.Closed)+len(r.Reopened) > 0
+}
+
+// TaskID is the ID of the task kept for c.
+func TaskID(c Comment) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("taskflow-scan:"+c.Key())).String()
+}
+
+// Apply updates all with the comments found under root: a task is added
+// for each new comment, the link of a task whose comment moved follows it,
+// and open tasks for comments under root that are gone are completed.
+// closed holds the IDs of tasks an earlier scan completed; they are reopened
+// when their comment is back (say, on switching branches). Other completed
+// tasks are left alone, as are titles and tags edited in taskflow.
+func Apply(all []models.Task, root string, comments []Comment, closed map[string]bool, now time.Time) ([]models.Task, Result) {
+	var res Result
+	wf := workflow.Current()
+	out := append([]models.Task(nil), all...)
+	byID := map[string]int{}
+	for i, t := range out {
+		byID[t.ID] = i
+	}
+	found := map[string]bool{}
+	for _, c := range comments {
+		id := TaskID(c)
+		found[id] = true
+		if i, ok := byID[id]; ok {
+			t := &out[i]
+			switch {
+			case wf.IsTerminal(t.Status) && closed[id]:
+				tasks.SetStatus(t, wf.Initial(), now)
+				t.Link, t.Description = c.Location(), describe(root, c)
+				res.Reopened = append(res.Reopened, *t)
+			case t.Link != c.Location() && !wf.IsTerminal(t.Status):
+				t.Link, t.Description = c.Location(), describe(root, c)
+				res.Moved = append(res.Moved, *t)
+			}
+			continue
+		}
+		t := newTask(root, c, id)
+		byID[id] = len(out)
+		out = append(out, t)
+		res.Added = append(res.Added, t)
+	}
+	prefix := strings.TrimSuffix(root, string(filepath.Separator)) + string(filepath.Separator)
+	for i := range out {
+		t := &out[i]
+		if t.Source != Source || found[t.ID] || wf.IsTerminal(t.Status) || !strings.HasPrefix(t.Link, prefix) {
+			continue
+		}
+		tasks.SetStatus(t, wf.Done(), now)
+		res.Closed = append(res.Closed, *t)
+	}
+	return out, res
+}
+
+func newTask(root string, c Comment, id string) models.Task {
+	title := c.Text
+	if title == "" {
+		title = fmt.Sprintf("%s in %s", c.Kind, relPath(root, c.Path))
+	}
+	return models.Task{
+		ID:          id,
+		Title:       title,
+		Description: describe(root, c),
+		Status:      workflow.Current().Initial(),
+		Priority:    priority(c.Kind),
+		Source:      Source,
+		Link:        c.Location(),
+		Tags:        []string{"code", strings.ToLower(c.Kind)},
+	}
+}
+
+// describe says where the comment is and who wrote it.
+func describe(root string, c Comment) string {
+	s := fmt.Sprintf("%s at %s:%d", c.Kind, relPath(root, c.Path), c.Line)
+	if c.Author != "" {
+		s += " by " + c.Author
+	}
+	return s
+}
+
+// priority ranks FIXMEs above TODOs and HACKs below, when the workflow has
+// priorities with those names.
+func priority(kind string) string {
+	wf := workflow.Current()
+	name := map[string]string{"FIXME": "high", "HACK": "low"}[kind]
+	if p, ok := wf.Priority(name); ok {
+		return p.Name
+	}
+	return wf.DefaultPriority()
+}
+
+func relPath(root, p string) string {
+	if rel, err := filepath.Rel(root, p); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return p
+}
@@ -1,8 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"taskflow/internal/schedule"
+	"taskflow/internal/webhooks"
+	"taskflow/internal/workflow"
+	"time"
 
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -32,6 +39,32 @@ func Init() error {
 	viper.SetDefault("storage.tasks_file", "tasks.yaml")
 	viper.SetDefault("storage.archive_file", "tasks.archive.yaml")
 	viper.SetDefault("calendar.storage.path", filepath.Join(configDir, "calendar.yaml"))
+	viper.SetDefault("plugin_dir", filepath.Join(configDir, "plugins"))
+	viper.SetDefault("planner.work_start", "09:00")
+	viper.SetDefault("planner.work_end", "17:00")
+	viper.SetDefault("planner.work_days", []string{"mon", "tue", "wed", "thu", "fri"})
+	viper.SetDefault("planner.days", 5)
+	viper.SetDefault("planner.default_estimate", "1h")
+	viper.SetDefault("planner.min_block", "30m")
+	viper.SetDefault("ui.keymap", "default")
+	viper.SetDefault("ui.theme", "dark")
+	viper.SetDefault("history.enabled", false)
+	viper.SetDefault("hooks.timeout", "5s")
+	viper.SetDefault("webhooks.interval", "30s")
+	viper.SetDefault("notify.interval", "1m")
+	viper.SetDefault("schedule.backend", "auto")
+	viper.SetDefault("notify.notifiers", []string{"stdout"})
+	viper.SetDefault("notify.desktop.command", "notify-send")
+	viper.SetDefault("notify.email.smtp", "localhost:25")
+	viper.SetDefault("notify.email.from", "taskflow@localhost")
+	viper.SetDefault("webhooks.max_attempts", webhooks.DefaultMaxAttempts)
+	viper.SetDefault("history.limit", 50)
+	viper.SetDefault("prioritize.weights.due", 5.0)
+	viper.SetDefault("prioritize.weights.age", 1.0)
+	viper.SetDefault("prioritize.weights.dependencies", 2.0)
+	viper.SetDefault("prioritize.weights.calendar", 3.0)
+	viper.SetDefault("prioritize.thresholds.high", 3.0)
+	viper.SetDefault("prioritize.thresholds.medium", 1.5)
 
 	if err := os.MkdirAll(configDir, os.ModePerm); err != nil {
 		return err
@@ -88,6 +121,11 @@ func Init() error {
 		}
 	}
 
+	wf, err := GetWorkflow()
+	if err != nil {
+		return err
+	}
+	workflow.Use(wf)
 	return nil
 }
 
@@ -145,11 +183,322 @@ func GetStoragePath() string { //nolint:revive
 	return GetTasksFilePath()
 }
 
+// stateDir is where taskflow keeps its own state files: the storage
+// directory, or the directory of the tasks file when only storage.path is set.
+func stateDir() string {
+	if dir := GetStorageDir(); dir != "" {
+		return dir
+	}
+	return filepath.Dir(GetStoragePath())
+}
+
 // GetCalendarStoragePath returns the path to the calendar events YAML file.
 func GetCalendarStoragePath() string {
 	return viper.GetString("calendar.storage.path")
 }
 
+// GetPluginDir returns the directory searched first for external plugins.
+// It lives outside plugins.* so that no plugin name collides with it; a
+// string plugins.dir from older configs is still honoured.
+func GetPluginDir() string {
+	if dir, ok := viper.Get("plugins.dir").(string); ok && dir != "" && !viper.InConfig("plugin_dir") {
+		return dir
+	}
+	return viper.GetString("plugin_dir")
+}
+
+// GetPluginConfig returns the plugins.<name> section handed to a plugin.
+func GetPluginConfig(name string) map[string]any {
+	return viper.GetStringMap("plugins." + name)
+}
+
+// PluginEnabled reports whether a plugin is switched on; plugins are enabled
+// unless plugins.<name>.enabled is false.
+func PluginEnabled(name string) bool {
+	key := "plugins." + name + ".enabled"
+	return !viper.IsSet(key) || viper.GetBool(key)
+}
+
+// GetHooks returns the shell commands configured for a hook event such as
+// "on_complete"; the key may hold a single command or a list.
+func GetHooks(event string) []string {
+	switch v := viper.Get("hooks." + event).(type) {
+	case string:
+		if strings.TrimSpace(v) != "" {
+			return []string{v}
+		}
+	case []any:
+		var out []string
+		for _, c := range v {
+			if s, ok := c.(string); ok && strings.TrimSpace(s) != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	case []string:
+		return v
+	}
+	return nil
+}
+
+// GetHookTimeout returns how long a shell hook may run before it is killed.
+func GetHookTimeout() time.Duration {
+	d, err := time.ParseDuration(viper.GetString("hooks.timeout"))
+	if err != nil || d <= 0 {
+		return 5 * time.Second
+	}
+	return d
+}
+
+// GetWebhooks returns the endpoints configured under webhooks.endpoints.
+func GetWebhooks() ([]webhooks.Endpoint, error) {
+	var endpoints []webhooks.Endpoint
+	if err := viper.UnmarshalKey("webhooks.endpoints", &endpoints); err != nil {
+		return nil, fmt.Errorf("invalid webhooks.endpoints: %w", err)
+	}
+	seen := map[string]bool{}
+	for _, e := range endpoints {
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid webhooks.endpoints: %w", err)
+		}
+		if seen[e.Name] {
+			return nil, fmt.Errorf("invalid webhooks.endpoints: duplicate name %s", e.Name)
+		}
+		seen[e.Name] = true
+	}
+	return endpoints, nil
+}
+
+// GetWebhookQueueDir returns the directory of the webhook delivery queue:
+// webhooks.queue_dir, or "webhooks" in the storage directory.
+func GetWebhookQueueDir() string {
+	if d := viper.GetString("webhooks.queue_dir"); d != "" {
+		return d
+	}
+	return filepath.Join(stateDir(), "webhooks")
+}
+
+// GetWebhookInterval returns how often the webhook worker polls the queue.
+func GetWebhookInterval() time.Duration {
+	d, err := time.ParseDuration(viper.GetString("webhooks.interval"))
+	if err != nil || d <= 0 {
+		return 30 * time.Second
+	}
+	return d
+}
+
+// GetWebhookMaxAttempts returns how often a delivery is tried before it is
+// given up.
+func GetWebhookMaxAttempts() int { return viper.GetInt("webhooks.max_attempts") }
+
+// GetNotifyNotifiers returns the notifiers used by `notify --daemon`.
+func GetNotifyNotifiers() []string { return viper.GetStringSlice("notify.notifiers") }
+
+// GetNotifyInterval returns how often `notify --daemon` checks for reminders.
+func GetNotifyInterval() time.Duration {
+	d, err := time.ParseDuration(viper.GetString("notify.interval"))
+	if err != nil || d <= 0 {
+		return time.Minute
+	}
+	return d
+}
+
+// GetNotifyLeadTimes returns the reminder lead times from notify.lead_times,
+// keyed by priority, "default" or "event".
+func GetNotifyLeadTimes() (map[string]time.Duration, error) {
+	out := map[string]time.Duration{}
+	for key := range viper.GetStringMap("notify.lead_times") {
+		raw := viper.GetString("notify.lead_times." + key)
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid notify.lead_times.%s: %q", key, raw)
+		}
+		out[key] = d
+	}
+	return out, nil
+}
+
+// GetNotifyStatePath returns the file remembering fired, snoozed and
+// acknowledged reminders.
+func GetNotifyStatePath() string {
+	return filepath.Join(stateDir(), "notify-state.json")
+}
+
+// GetLinkStatePath returns where `link-file` keeps the last synced state of
+// linked files.
+func GetLinkStatePath() string {
+	return filepath.Join(stateDir(), "linked-files.json")
+}
+
+// GetScanStatePath returns where `scan` remembers the tasks it completed.
+func GetScanStatePath() string {
+	return filepath.Join(stateDir(), "scan-state.json")
+}
+
+// Notifier settings used by `notify --daemon`.
+func GetNotifyDesktopCommand() string { return viper.GetString("notify.desktop.command") }
+func GetNotifyEmailSMTP() string      { return viper.GetString("notify.email.smtp") }
+func GetNotifyEmailFrom() string      { return viper.GetString("notify.email.from") }
+func GetNotifyEmailTo() []string      { return viper.GetStringSlice("notify.email.to") }
+func GetNotifyWebhookURL() string     { return viper.GetString("notify.webhook.url") }
+func GetNotifyWebhookSecret() string  { return viper.GetString("notify.webhook.secret") }
+
+// GetScheduleBackend returns how background jobs are installed: auto,
+// systemd or cron.
+func GetScheduleBackend() string { return viper.GetString("schedule.backend") }
+
+// GetScheduleDir returns the directory for generated systemd user units:
+// schedule.dir, or ~/.config/systemd/user.
+func GetScheduleDir() string {
+	if d := viper.GetString("schedule.dir"); d != "" {
+		return d
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "systemd", "user")
+}
+
+// GetScheduleJobs returns the background jobs under schedule.jobs, sorted by
+// name. A job is either an interval ("notify: 5m") or a section with every,
+// and optionally command and enabled. Without the section, reminders are
+// checked every 5 minutes.
+func GetScheduleJobs() ([]schedule.Job, error) {
+	if !viper.IsSet("schedule.jobs") {
+		return []schedule.Job{{Name: "notify", Every: 5 * time.Minute, Args: schedule.Builtin["notify"]}}, nil
+	}
+	raw := viper.GetStringMap("schedule.jobs")
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var jobs []schedule.Job
+	for _, name := range names {
+		key := "schedule.jobs." + name
+		every, isInterval := raw[name].(string)
+		if !isInterval {
+			if viper.IsSet(key+".enabled") && !viper.GetBool(key+".enabled") {
+				continue
+			}
+			every = viper.GetString(key + ".every")
+		}
+		d, err := time.ParseDuration(every)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: interval %q", key, every)
+		}
+		job := schedule.Job{Name: name, Every: d, Args: schedule.Builtin[name]}
+		if !isInterval {
+			job.Command = viper.GetString(key + ".command")
+		}
+		if err := job.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid schedule.jobs: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// HistoryEnabled reports whether per-task change history is recorded.
+func HistoryEnabled() bool { return viper.GetBool("history.enabled") }
+
+// HistoryLimit returns the maximum number of history entries kept per task.
+func HistoryLimit() int { return viper.GetInt("history.limit") }
+
+// Planner settings used by `task plan`.
+func GetPlannerWorkStart() string       { return viper.GetString("planner.work_start") }
+func GetPlannerWorkEnd() string         { return viper.GetString("planner.work_end") }
+func GetPlannerWorkDays() []string      { return viper.GetStringSlice("planner.work_days") }
+func GetPlannerDays() int               { return viper.GetInt("planner.days") }
+func GetPlannerDefaultEstimate() string { return viper.GetString("planner.default_estimate") }
+func GetPlannerMinBlock() string        { return viper.GetString("planner.min_block") }
+
+// GetPrioritizeWeight returns the scoring weight for a component (due, age, dependencies, calendar).
+func GetPrioritizeWeight(name string) float64 {
+	return viper.GetFloat64("prioritize.weights." + name)
+}
+
+// GetPrioritizeTagWeights returns per-tag score adjustments from prioritize.tag_weights.
+func GetPrioritizeTagWeights() map[string]float64 {
+	out := map[string]float64{}
+	for tag := range viper.GetStringMap("prioritize.tag_weights") {
+		out[tag] = viper.GetFloat64("prioritize.tag_weights." + tag)
+	}
+	return out
+}
+
+// GetPrioritizeThreshold returns the minimum score for a priority level (high, medium).
+func GetPrioritizeThreshold(level string) float64 {
+	return viper.GetFloat64("prioritize.thresholds." + level)
+}
+
+// GetUIKeymap returns the keymap preset for the interactive UI (default, vim, emacs).
+func GetUIKeymap() string { return viper.GetString("ui.keymap") }
+
+// GetUIKeys returns per-action key overrides from ui.keys. Each value may be
+// a list or a comma-separated string, e.g. `toggle_status: "t, x"`.
+func GetUIKeys() map[string][]string {
+	out := map[string][]string{}
+	for action := range viper.GetStringMap("ui.keys") {
+		var keys []string
+		for _, v := range viper.GetStringSlice("ui.keys." + action) {
+			for _, k := range strings.Split(v, ",") {
+				if k = strings.TrimSpace(k); k != "" {
+					keys = append(keys, k)
+				}
+			}
+		}
+		out[action] = keys
+	}
+	return out
+}
+
+// GetUITheme returns the colour theme for the interactive UI (dark, light).
+func GetUITheme() string { return viper.GetString("ui.theme") }
+
+// GetUIColors returns per-colour overrides from ui.colors.
+func GetUIColors() map[string]string {
+	out := map[string]string{}
+	for name := range viper.GetStringMap("ui.colors") {
+		out[name] = viper.GetString("ui.colors." + name)
+	}
+	return out
+}
+
+// GetWorkflow returns the task workflow from the workflow section, or the
+// built-in one when it is absent. Statuses and priorities left out of the
+// section keep their defaults. A configured workflow is enforced on write.
+func GetWorkflow() (*workflow.Workflow, error) {
+	wf := workflow.Default()
+	if !viper.IsSet("workflow") {
+		return wf, nil
+	}
+	wf.Enforce = true
+	if viper.IsSet("workflow.statuses") {
+		wf.Statuses = nil
+		if err := viper.UnmarshalKey("workflow.statuses", &wf.Statuses); err != nil {
+			return nil, fmt.Errorf("invalid workflow.statuses: %w", err)
+		}
+	}
+	if viper.IsSet("workflow.priorities") {
+		wf.Priorities = nil
+		if err := viper.UnmarshalKey("workflow.priorities", &wf.Priorities); err != nil {
+			return nil, fmt.Errorf("invalid workflow.priorities: %w", err)
+		}
+	}
+	if viper.IsSet("workflow.transitions") {
+		wf.Transitions = map[string][]string{}
+		for from := range viper.GetStringMap("workflow.transitions") {
+			wf.Transitions[from] = viper.GetStringSlice("workflow.transitions." + from)
+		}
+	}
+	if err := wf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workflow: %w", err)
+	}
+	return wf, nil
+}
+
 // Remote gist sync metadata helpers
 func GetGistLastVersion() string   { return viper.GetString("remote.gist.last_version") }
 func GetGistLastLocalHash() string { return viper.GetString("remote.gist.last_local_hash") }
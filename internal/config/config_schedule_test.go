@@ -0,0 +1,45 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleJobsFromConfig(t *testing.T) {
+	if err := initWithConfig(t, "storage:\n  dir: /tmp/x\n"); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := GetScheduleJobs()
+	if err != nil || len(jobs) != 1 || jobs[0].Name != "notify" || jobs[0].Every != 5*time.Minute {
+		t.Fatalf("default jobs = %+v, %v", jobs, err)
+	}
+
+	cfg := `schedule:
+  jobs:
+    notify: 10m
+    gist-sync:
+      every: 1h
+      enabled: false
+    calendar:
+      every: 30m
+      command: gcalcli agenda --tsv | taskflow calendar import gcal
+`
+	if err := initWithConfig(t, cfg); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err = GetScheduleJobs()
+	if err != nil || len(jobs) != 2 {
+		t.Fatalf("jobs = %+v, %v", jobs, err)
+	}
+	if jobs[0].Name != "calendar" || !strings.HasPrefix(jobs[0].Command, "gcalcli") || jobs[1].Name != "notify" || jobs[1].Every != 10*time.Minute || jobs[1].Args[0] != "notify" {
+		t.Fatalf("jobs = %+v", jobs)
+	}
+
+	if err := initWithConfig(t, "schedule:\n  jobs:\n    backup: 1h\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetScheduleJobs(); err == nil || !strings.Contains(err.Error(), "no command") {
+		t.Fatalf("expected error for a job without command, got %v", err)
+	}
+}
@@ -37,3 +37,33 @@ func TestInitCreatesDefaults(t *testing.T) {
 		t.Fatalf("expected config dir to exist: %v", err)
 	}
 }
+
+func TestPluginDirOutsidePluginSections(t *testing.T) {
+	viper.Reset()
+	tempHome := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempHome)
+	t.Cleanup(func() { os.Setenv("HOME", oldHome) })
+	configDir := filepath.Join(tempHome, ".config", AppName)
+	_ = os.MkdirAll(configDir, 0755)
+	cfg := "plugins:\n  dir:\n    greeting: hi\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+	if got := GetPluginDir(); got != filepath.Join(configDir, "plugins") {
+		t.Fatalf("plugin dir = %q", got)
+	}
+	if got := GetPluginConfig("dir"); got["greeting"] != "hi" {
+		t.Fatalf("config of plugin \"dir\" = %v", got)
+	}
+
+	// Older configs set the directory as plugins.dir.
+	viper.Set("plugins.dir", "/opt/taskflow-plugins")
+	if got := GetPluginDir(); got != "/opt/taskflow-plugins" {
+		t.Fatalf("legacy plugin dir = %q", got)
+	}
+}
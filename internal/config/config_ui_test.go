@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestUIKeysAndColorsFromConfig(t *testing.T) {
+	viper.Reset()
+	tempHome := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempHome)
+	t.Cleanup(func() { os.Setenv("HOME", oldHome); viper.Reset() })
+
+	dir := filepath.Join(tempHome, ".config", AppName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg := `ui:
+  keymap: vim
+  theme: light
+  keys:
+    toggle_status: "t, x"
+    quit: [Q, ctrl+c]
+  colors:
+    border: "#ff00ff"
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+
+	if GetUIKeymap() != "vim" || GetUITheme() != "light" {
+		t.Fatalf("unexpected keymap/theme: %q %q", GetUIKeymap(), GetUITheme())
+	}
+	keys := GetUIKeys()
+	if got := keys["toggle_status"]; len(got) != 2 || got[0] != "t" || got[1] != "x" {
+		t.Fatalf("comma-separated keys not split: %v", got)
+	}
+	if got := keys["quit"]; len(got) != 2 || got[0] != "Q" {
+		t.Fatalf("list keys not read: %v", got)
+	}
+	if GetUIColors()["border"] != "#ff00ff" {
+		t.Fatalf("colour override not read: %v", GetUIColors())
+	}
+}
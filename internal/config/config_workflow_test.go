@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/workflow"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func initWithConfig(t *testing.T, cfg string) error {
+	t.Helper()
+	viper.Reset()
+	tempHome := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempHome)
+	t.Cleanup(func() { os.Setenv("HOME", oldHome); viper.Reset(); workflow.Use(nil) })
+
+	dir := filepath.Join(tempHome, ".config", AppName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(cfg), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return Init()
+}
+
+func TestWorkflowFromConfig(t *testing.T) {
+	cfg := `workflow:
+  statuses:
+    - name: backlog
+    - name: doing
+      active: true
+      color: "33"
+    - name: Shipped
+      terminal: true
+  transitions:
+    backlog: [doing]
+    shipped: [backlog]
+`
+	if err := initWithConfig(t, cfg); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+	wf := workflow.Current()
+	if !wf.Enforce || strings.Join(wf.StatusNames(), ",") != "backlog,doing,Shipped" {
+		t.Fatalf("statuses not loaded: %v", wf.StatusNames())
+	}
+	if st, _ := wf.Status("doing"); st.Color != "33" || !st.Active {
+		t.Fatalf("status fields not loaded: %+v", st)
+	}
+	// Priorities were left out and keep their defaults.
+	if wf.DefaultPriority() != "medium" {
+		t.Fatalf("default priorities not kept: %v", wf.PriorityNames())
+	}
+	if wf.CanTransition("backlog", "Shipped") || !wf.CanTransition("Shipped", "backlog") {
+		t.Fatal("transitions not loaded")
+	}
+}
+
+func TestWorkflowConfigInvalid(t *testing.T) {
+	err := initWithConfig(t, "workflow:\n  statuses:\n    - name: open\n")
+	if err == nil || !strings.Contains(err.Error(), "terminal") {
+		t.Fatalf("expected invalid workflow error, got %v", err)
+	}
+}
+
+func TestWorkflowDefaultWhenUnset(t *testing.T) {
+	if err := initWithConfig(t, "ui:\n  theme: dark\n"); err != nil {
+		t.Fatal(err)
+	}
+	if workflow.Current().Enforce || workflow.Current().Initial() != "to-do" {
+		t.Fatal("expected the lenient default workflow")
+	}
+}
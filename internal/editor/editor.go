@@ -0,0 +1,68 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Name returns the editor command from $VISUAL or $EDITOR, falling back to vi.
+func Name() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	return "vi"
+}
+
+// Command returns the command that opens path in the user's editor. Editor
+// settings with arguments such as "code --wait" are split on spaces.
+func Command(path string) *exec.Cmd {
+	parts := strings.Fields(Name())
+	args := append(parts[1:], path)
+	return exec.Command(parts[0], args...)
+}
+
+// TempFile writes content to a new temporary Markdown file named after name
+// and returns its path. The caller removes it.
+func TempFile(name, content string) (string, error) {
+	f, err := os.CreateTemp("", "taskflow-"+name+"-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// ReadBack returns the edited file's content without the trailing newline
+// most editors add, and removes the file.
+func ReadBack(path string) (string, error) {
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// Edit opens content in the editor attached to the terminal and returns the
+// edited text.
+func Edit(name, content string) (string, error) {
+	path, err := TempFile(name, content)
+	if err != nil {
+		return "", err
+	}
+	cmd := Command(path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("editor %q failed: %w", Name(), err)
+	}
+	return ReadBack(path)
+}
@@ -0,0 +1,43 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeEditor installs a script as $EDITOR that appends a line to the file.
+func fakeEditor(t *testing.T) {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "ed.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nprintf 'edited\\n' >> \"$1\"\n"), 0755); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+}
+
+func TestEdit_RoundTrip(t *testing.T) {
+	fakeEditor(t)
+	got, err := Edit("notes", "# Title\n")
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if got != "# Title\nedited" {
+		t.Fatalf("unexpected content: %q", got)
+	}
+}
+
+func TestNameAndCommand(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	cmd := Command("/tmp/x.md")
+	if Name() != "code --wait" || cmd.Args[0] != "code" || cmd.Args[1] != "--wait" || cmd.Args[2] != "/tmp/x.md" {
+		t.Fatalf("unexpected command: %v", cmd.Args)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if Name() != "vi" {
+		t.Fatalf("expected vi fallback, got %q", Name())
+	}
+}
@@ -0,0 +1,92 @@
+// Package exporter writes tasks in formats other tools read: todo.txt,
+// Taskwarrior JSON, Markdown checklists, CSV and HTML. The todo.txt and
+// Taskwarrior output can be read back with the importer package.
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// Writer writes tasks in one format.
+type Writer func(w io.Writer, ts []models.Task) error
+
+// Formats are the supported formats by name.
+var Formats = map[string]Writer{
+	"todotxt":     TodoTxt,
+	"markdown":    Markdown,
+	"taskwarrior": Taskwarrior,
+	"csv":         CSV,
+	"html":        HTML,
+}
+
+// csvHeader lists the CSV columns.
+var csvHeader = []string{
+	"id", "title", "status", "priority", "due", "estimate", "tags", "depends_on",
+	"created_at", "updated_at", "completed_at", "archived_at", "source", "link", "description", "notes",
+}
+
+// CSV writes one row per task with a header row. Lists (tags,
+// dependencies) are joined with commas.
+func CSV(w io.Writer, ts []models.Task) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, t := range ts {
+		row := []string{
+			t.ID, t.Title, t.Status, t.Priority, t.DueDate, t.Estimate,
+			strings.Join(t.Tags, ","), strings.Join(t.DependsOn, ","),
+			t.CreatedAt, t.UpdatedAt, t.CompletedAt, t.ArchivedAt, t.Source, t.Link, t.Description, t.Notes,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// done reports whether t is finished.
+func done(t models.Task) bool { return workflow.Current().IsTerminal(t.Status) }
+
+// priorityLevel is the position of t's priority, 0 being the most urgent,
+// and how many priorities there are; -1 for an unknown priority.
+func priorityLevel(t models.Task) (int, int) {
+	names := workflow.Current().PriorityNames()
+	for i, n := range names {
+		if strings.EqualFold(n, t.Priority) {
+			return i, len(names)
+		}
+	}
+	return -1, len(names)
+}
+
+// day returns the date part of a timestamp or due date, in local time.
+func day(s string) string {
+	if at, err := time.Parse(time.RFC3339, s); err == nil {
+		return at.Local().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return s
+	}
+	return ""
+}
+
+// dueLabel is a short form of the due date for people: the date, plus the
+// time when the task is due at a given time.
+func dueLabel(due string) string {
+	at, ok := tasks.ParseDue(due, time.Local)
+	if !ok {
+		return due
+	}
+	if len(due) == len("2006-01-02") {
+		return due
+	}
+	return at.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,104 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"taskflow/internal/importer"
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var sample = []models.Task{
+	{ID: "11111111-1111-4111-8111-111111111111", Title: "Write report", Status: "in-progress", Priority: "high",
+		DueDate: "2025-10-03", Tags: []string{"work", "q4 plan"}, CreatedAt: "2025-09-01T08:00:00Z",
+		UpdatedAt: "2025-09-02T08:00:00Z", StartedAt: "2025-09-02T08:00:00Z", DependsOn: []string{"b"},
+		Description: "For the board", Link: "https://example.com/r"},
+	{ID: "b", Title: "Gather *data*", Status: "done", Priority: "low", CreatedAt: "2025-08-01T08:00:00Z",
+		CompletedAt: "2025-08-15T08:00:00Z", UpdatedAt: "2025-08-15T08:00:00Z"},
+}
+
+func export(t *testing.T, w Writer) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := w(&buf, sample); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	out := export(t, TodoTxt)
+	want := "(A) 2025-09-01 Write report +work +q4-plan due:2025-10-03\n" +
+		"x 2025-08-15 2025-08-01 Gather *data* pri:C\n"
+	if out != want {
+		t.Fatalf("todo.txt:\n%s\nwant:\n%s", out, want)
+	}
+	res, err := importer.ParseTodoTxt(strings.NewReader(out), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := res.Tasks[0], res.Tasks[1]
+	if a.Title != "Write report" || a.Priority != "high" || a.DueDate != "2025-10-03" || len(a.Tags) != 2 ||
+		b.Status != "done" || b.Priority != "low" {
+		t.Fatalf("re-imported %+v", res.Tasks)
+	}
+}
+
+func TestTaskwarriorRoundTrip(t *testing.T) {
+	out := export(t, Taskwarrior)
+	var raw []map[string]any
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		t.Fatalf("%v:\n%s", err, out)
+	}
+	if raw[0]["start"] != "20250902T080000Z" || raw[0]["priority"] != "H" || raw[1]["priority"] != "L" ||
+		raw[1]["status"] != "completed" || raw[1]["end"] != "20250815T080000Z" {
+		t.Fatalf("export:\n%s", out)
+	}
+
+	res, err := importer.ParseTaskwarrior(strings.NewReader(out), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := res.Tasks[0], res.Tasks[1]
+	if a.ID != sample[0].ID || a.Status != "in-progress" || a.DueDate != "2025-10-03T23:59:59Z" ||
+		len(a.DependsOn) != 1 || a.DependsOn[0] != b.ID || !strings.Contains(a.Notes, "For the board") {
+		t.Fatalf("re-imported %+v", a)
+	}
+	// Non-UUID IDs map to the same UUID every time.
+	if b.ID != twUUID("b") || len(res.Unmapped) != 0 {
+		t.Fatalf("re-imported %+v, unmapped %v", b, res.Unmapped)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	want := "- [ ] [Write report](<https://example.com/r>) — due 2025-10-03, high, `work`, `q4 plan`\n" +
+		"- [x] Gather \\*data\\* — low\n"
+	if out := export(t, Markdown); out != want {
+		t.Fatalf("markdown:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestCSVAndHTML(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(export(t, CSV))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][6] != "tags" || rows[1][6] != "work,q4 plan" || rows[2][1] != "Gather *data*" {
+		t.Fatalf("csv rows = %q", rows)
+	}
+
+	page := export(t, HTML)
+	for _, want := range []string{
+		`<a href="https://example.com/r">Write report</a>`,
+		`<tr class="done">`,
+		`<span class="tag">q4 plan</span>`,
+		`<td>2025-10-03</td>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("html lacks %q:\n%s", want, page)
+		}
+	}
+}
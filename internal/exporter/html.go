@@ -0,0 +1,51 @@
+package exporter
+
+import (
+	"html/template"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+)
+
+var htmlPage = template.Must(template.New("tasks").Funcs(template.FuncMap{
+	"done": done,
+	"due":  dueLabel,
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Tasks</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: .3em .8em; border-bottom: 1px solid #ddd; vertical-align: top; }
+tr.done td { color: #888; }
+tr.done .title { text-decoration: line-through; }
+.tag { background: #eee; border-radius: 3px; padding: 0 .3em; margin-right: .2em; }
+</style>
+</head>
+<body>
+<table>
+<thead><tr><th></th><th>Task</th><th>Status</th><th>Priority</th><th>Due</th><th>Tags</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr{{if done .}} class="done"{{end}}>
+<td>{{if done .}}&#9745;{{else}}&#9744;{{end}}</td>
+<td><span class="title">{{if .Link}}<a href="{{.Link}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</span>{{if .Description}}<br><small>{{.Description}}</small>{{end}}</td>
+<td>{{.Status}}</td>
+<td>{{.Priority}}</td>
+<td>{{if .DueDate}}{{due .DueDate}}{{end}}</td>
+<td>{{range .Tags}}<span class="tag">{{.}}</span>{{end}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`))
+
+// HTML writes a standalone page with a table of the tasks.
+func HTML(w io.Writer, ts []models.Task) error {
+	return htmlPage.Execute(w, ts)
+}
@@ -0,0 +1,43 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+)
+
+var mdEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`)
+
+// Markdown writes a GitHub-style checklist, one item per task, with the due
+// date, priority and tags after the title. Titles with a link are linked.
+func Markdown(w io.Writer, ts []models.Task) error {
+	for _, t := range ts {
+		mark := " "
+		if done(t) {
+			mark = "x"
+		}
+		title := mdEscaper.Replace(strings.Join(strings.Fields(t.Title), " "))
+		if t.Link != "" {
+			title = fmt.Sprintf("[%s](<%s>)", title, t.Link)
+		}
+		var meta []string
+		if t.DueDate != "" {
+			meta = append(meta, "due "+dueLabel(t.DueDate))
+		}
+		if t.Priority != "" {
+			meta = append(meta, t.Priority)
+		}
+		for _, tag := range t.Tags {
+			meta = append(meta, "`"+strings.ReplaceAll(tag, "`", "'")+"`")
+		}
+		line := fmt.Sprintf("- [%s] %s", mark, title)
+		if len(meta) > 0 {
+			line += " — " + strings.Join(meta, ", ")
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// twTask is a task as `task import` reads it.
+type twTask struct {
+	UUID        string         `json:"uuid"`
+	Description string         `json:"description"`
+	Status      string         `json:"status"`
+	Entry       string         `json:"entry,omitempty"`
+	Modified    string         `json:"modified,omitempty"`
+	Start       string         `json:"start,omitempty"`
+	End         string         `json:"end,omitempty"`
+	Due         string         `json:"due,omitempty"`
+	Priority    string         `json:"priority,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Depends     []string       `json:"depends,omitempty"`
+	Annotations []twAnnotation `json:"annotations,omitempty"`
+}
+
+type twAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// Taskwarrior writes a JSON array for `task import`. Task IDs that are not
+// UUIDs are turned into stable ones, so exporting again updates the same
+// Taskwarrior tasks. The most urgent third of the priorities becomes H, the
+// next M, the rest L. The description and notes become annotations.
+func Taskwarrior(w io.Writer, ts []models.Task) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i, t := range ts {
+		data, err := json.Marshal(twExport(t))
+		if err != nil {
+			return err
+		}
+		sep := ",\n"
+		if i == 0 {
+			sep = "\n"
+		}
+		if _, err := io.WriteString(w, sep+string(data)); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+func twExport(t models.Task) twTask {
+	out := twTask{
+		UUID:        twUUID(t.ID),
+		Description: t.Title,
+		Status:      "pending",
+		Entry:       twTime(t.CreatedAt),
+		Modified:    twTime(t.UpdatedAt),
+	}
+	if done(t) {
+		out.Status = "completed"
+		out.End = twTime(t.CompletedAt)
+		if out.End == "" {
+			out.End = out.Modified
+		}
+	} else if workflow.Current().IsActive(t.Status) {
+		out.Start = twTime(t.StartedAt)
+	}
+	if due, ok := tasks.ParseDue(t.DueDate, time.Local); ok {
+		out.Due = due.UTC().Format(twLayout)
+	}
+	if level, n := priorityLevel(t); level >= 0 {
+		out.Priority = string("HML"[level*3/n])
+	}
+	for _, tag := range t.Tags {
+		out.Tags = append(out.Tags, strings.Join(strings.Fields(tag), "-"))
+	}
+	for _, d := range t.DependsOn {
+		out.Depends = append(out.Depends, twUUID(d))
+	}
+	// Annotations need a timestamp; the task's last change is the closest.
+	noted := out.Modified
+	if noted == "" {
+		noted = out.Entry
+	}
+	if noted == "" {
+		noted = time.Now().UTC().Format(twLayout)
+	}
+	for _, text := range []string{t.Description, t.Notes} {
+		if strings.TrimSpace(text) != "" {
+			out.Annotations = append(out.Annotations, twAnnotation{Entry: noted, Description: text})
+		}
+	}
+	return out
+}
+
+const twLayout = "20060102T150405Z"
+
+// twUUID returns id when it is a UUID, or a UUID derived from it.
+func twUUID(id string) string {
+	if u, err := uuid.Parse(id); err == nil {
+		return u.String()
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("taskflow:"+id)).String()
+}
+
+func twTime(s string) string {
+	at, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return ""
+	}
+	return at.UTC().Format(twLayout)
+}
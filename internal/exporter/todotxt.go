@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+)
+
+// TodoTxt writes one todo.txt line per task (see todotxt.org). Priorities
+// become (A), (B)… from the most urgent down, kept as pri:X on completed
+// tasks; tags become +projects; the due date becomes due:YYYY-MM-DD.
+// Descriptions and notes have no place in todo.txt and are left out.
+func TodoTxt(w io.Writer, ts []models.Task) error {
+	for _, t := range ts {
+		if _, err := fmt.Fprintln(w, todoLine(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func todoLine(t models.Task) string {
+	var parts []string
+	created, completed := day(t.CreatedAt), day(t.CompletedAt)
+	pri := ""
+	if level, _ := priorityLevel(t); level >= 0 && level < 26 {
+		pri = string(rune('A' + level))
+	}
+	if done(t) {
+		parts = append(parts, "x")
+		if completed != "" {
+			if created == "" {
+				created = completed // a completion date needs a creation date
+			}
+			parts = append(parts, completed)
+		}
+	} else if pri != "" {
+		parts = append(parts, "("+pri+")")
+	}
+	if created != "" {
+		parts = append(parts, created)
+	}
+	parts = append(parts, strings.Join(strings.Fields(t.Title), " "))
+	for _, tag := range t.Tags {
+		parts = append(parts, "+"+strings.Join(strings.Fields(tag), "-"))
+	}
+	if due := day(t.DueDate); due != "" {
+		parts = append(parts, "due:"+due)
+	}
+	if done(t) && pri != "" {
+		parts = append(parts, "pri:"+pri)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,171 @@
+// Package hooks runs lifecycle hooks when tasks are added, modified,
+// completed or archived: in-process hooks of plugins implementing
+// plugin.TaskHooks, then the shell commands configured under hooks.<event>.
+// Hooks run before the change is written and may amend the task or veto the
+// write by failing.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/plugin"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// Event names a task mutation; it is also the config key of its shell hooks.
+type Event string
+
+const (
+	OnAdd      Event = "on_add"
+	OnModify   Event = "on_modify"
+	OnComplete Event = "on_complete"
+	OnArchive  Event = "on_archive"
+)
+
+// Error reports a hook that vetoed a change or failed to run.
+type Error struct {
+	Event  Event
+	Hook   string // plugin name or shell command
+	TaskID string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s hook %q rejected task %s: %v", e.Event, e.Hook, e.TaskID, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Apply runs the hooks for a write of all over the stored tasks (prev, by
+// ID): on_add for new tasks, on_modify for changed ones and additionally
+// on_complete for tasks that reach a terminal status.
+func Apply(prev map[string]*models.Task, all []models.Task) error {
+	wf := workflow.Current()
+	for i := range all {
+		t := &all[i]
+		old := prev[t.ID]
+		if old == nil {
+			if err := Run(OnAdd, nil, t); err != nil {
+				return err
+			}
+			continue
+		}
+		if !tasks.Changed(old, t) {
+			continue
+		}
+		if err := Run(OnModify, old, t); err != nil {
+			return err
+		}
+		if !wf.IsTerminal(old.Status) && wf.IsTerminal(t.Status) {
+			if err := Run(OnComplete, old, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Run runs the hooks for ev on t: those of the active plugins in their
+// initialisation order, then the shell hooks. prev is the stored version for
+// on_modify and on_complete, nil otherwise. The first failing hook stops the
+// run.
+func Run(ev Event, prev, t *models.Task) error {
+	for _, p := range plugin.ActivePlugins() {
+		h, ok := p.(plugin.TaskHooks)
+		if !ok {
+			continue
+		}
+		if err := callPlugin(h, ev, prev, t); err != nil {
+			return &Error{Event: ev, Hook: p.Name(), TaskID: t.ID, Err: err}
+		}
+	}
+	for _, command := range config.GetHooks(string(ev)) {
+		if err := runShell(command, ev, prev, t); err != nil {
+			return &Error{Event: ev, Hook: command, TaskID: t.ID, Err: err}
+		}
+	}
+	return nil
+}
+
+func callPlugin(h plugin.TaskHooks, ev Event, prev, t *models.Task) error {
+	switch ev {
+	case OnAdd:
+		return h.OnAdd(t)
+	case OnModify:
+		return h.OnModify(*prev, t)
+	case OnComplete:
+		return h.OnComplete(t)
+	case OnArchive:
+		return h.OnArchive(t)
+	}
+	return nil
+}
+
+// runShell runs one shell hook. The task is passed as JSON on stdin (for
+// on_modify the previous version comes first, one JSON object per line). A
+// non-zero exit vetoes the change with stderr as the reason; a task printed
+// as JSON on stdout replaces t.
+func runShell(command string, ev Event, prev, t *models.Task) error {
+	var stdin bytes.Buffer
+	enc := json.NewEncoder(&stdin)
+	if ev == OnModify && prev != nil {
+		if err := enc.Encode(prev); err != nil {
+			return err
+		}
+	}
+	if err := enc.Encode(t); err != nil {
+		return err
+	}
+
+	timeout := config.GetHookTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := shellCommand(ctx, command)
+	cmd.Stdin = &stdin
+	cmd.Env = append(os.Environ(), "TASKFLOW_HOOK="+string(ev), "TASKFLOW_TASK_ID="+t.ID)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	cmd.WaitDelay = time.Second // don't wait on children that keep the pipes open
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+
+	out := bytes.TrimSpace(stdout.Bytes())
+	if len(out) == 0 || out[0] != '{' {
+		return nil
+	}
+	var amended models.Task
+	if err := json.Unmarshal(out, &amended); err != nil {
+		return fmt.Errorf("invalid task JSON on stdout: %w", err)
+	}
+	if amended.ID != t.ID {
+		return fmt.Errorf("hook changed the task id")
+	}
+	*t = amended
+	return nil
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
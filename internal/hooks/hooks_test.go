@@ -0,0 +1,111 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/plugin"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// recorder is an in-process hook plugin that logs calls and tags new tasks.
+type recorder struct {
+	plugin.NoHooks
+	calls []string
+	veto  error
+}
+
+func (r *recorder) Name() string               { return "recorder" }
+func (r *recorder) Description() string        { return "test hooks" }
+func (r *recorder) Init(*plugin.Context) error { return nil }
+func (r *recorder) OnAdd(t *models.Task) error {
+	r.calls = append(r.calls, "add "+t.ID)
+	t.Tags = append(t.Tags, "hooked")
+	return nil
+}
+func (r *recorder) OnModify(prev models.Task, t *models.Task) error {
+	r.calls = append(r.calls, fmt.Sprintf("modify %s %s->%s", t.ID, prev.Status, t.Status))
+	return r.veto
+}
+func (r *recorder) OnComplete(t *models.Task) error {
+	r.calls = append(r.calls, "complete "+t.ID)
+	return nil
+}
+
+var rec = &recorder{}
+
+func init() {
+	plugin.MustRegister(rec)
+	plugin.Default().InitAll(plugin.Env{})
+}
+
+func TestApplyInProcess(t *testing.T) {
+	viper.Reset()
+	rec.calls, rec.veto = nil, nil
+	prev := map[string]*models.Task{
+		"1": {ID: "1", Title: "a", Status: "to-do"},
+		"2": {ID: "2", Title: "b", Status: "to-do"},
+	}
+	all := []models.Task{
+		{ID: "1", Title: "a", Status: "done"},
+		{ID: "2", Title: "b", Status: "to-do", UpdatedAt: "2025-10-01T00:00:00Z"}, // not a user-visible change
+		{ID: "3", Title: "c", Status: "to-do"},
+	}
+	if err := Apply(prev, all); err != nil {
+		t.Fatal(err)
+	}
+	want := "modify 1 to-do->done|complete 1|add 3"
+	if got := strings.Join(rec.calls, "|"); got != want {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+	if len(all[2].Tags) != 1 || all[2].Tags[0] != "hooked" {
+		t.Fatalf("add hook did not amend the task: %+v", all[2])
+	}
+
+	rec.veto = errors.New("not today")
+	err := Apply(prev, []models.Task{{ID: "1", Title: "renamed", Status: "to-do"}})
+	var hookErr *Error
+	if !errors.As(err, &hookErr) || hookErr.Hook != "recorder" || hookErr.Event != OnModify || !strings.Contains(err.Error(), "not today") {
+		t.Fatalf("expected veto from recorder, got %v", err)
+	}
+}
+
+func TestShellHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+	viper.Reset()
+	rec.calls, rec.veto = nil, nil
+	task := models.Task{ID: "1", Title: "draft", Status: "done"}
+
+	// Amend: a task printed on stdout replaces the input.
+	viper.Set("hooks.on_complete", `sed 's/"title":"draft"/"title":"final"/'`)
+	if err := Run(OnComplete, nil, &task); err != nil || task.Title != "final" {
+		t.Fatalf("amend: %v %+v", err, task)
+	}
+
+	// on_modify receives the previous and the new task, one per line.
+	viper.Set("hooks.on_modify", []any{`test "$(wc -l)" -eq 2 && test "$TASKFLOW_HOOK" = on_modify`})
+	prev := task
+	if err := Run(OnModify, &prev, &task); err != nil {
+		t.Fatalf("modify stdin: %v", err)
+	}
+
+	// Veto: a non-zero exit rejects the change with stderr as the reason.
+	viper.Set("hooks.on_archive", "echo 'still referenced' >&2; exit 3")
+	err := Run(OnArchive, nil, &task)
+	if err == nil || !strings.Contains(err.Error(), "still referenced") || !strings.Contains(err.Error(), "on_archive") {
+		t.Fatalf("expected veto, got %v", err)
+	}
+
+	// Hooks that run too long are killed.
+	viper.Set("hooks.timeout", "100ms")
+	viper.Set("hooks.on_add", "sleep 5")
+	if err := Run(OnAdd, nil, &task); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+}
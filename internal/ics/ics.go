@@ -34,14 +34,15 @@ func ParseICS(reader io.Reader, daysAhead int) ([]models.Task, error) {
 		}
 		if dtstart.After(now) && dtstart.Before(future) {
 			task := models.Task{
-				ID:       uuid.New().String(),
-				Title:    summary,
-				DueDate:  dtstart.Format(time.RFC3339),
-				Priority: "high", // High
+				ID:        uuid.New().String(),
+				Title:     summary,
+				DueDate:   dtstart.Format(time.RFC3339),
+				Priority:  "high", // High
+				CreatedAt: now.UTC().Format(time.RFC3339),
 			}
 			tasks = append(tasks, task)
 		}
 	}
 
 	return tasks, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,273 @@
+// Package importer reads tasks exported by other tools (Taskwarrior,
+// todo.txt, Todoist, Trello) and merges them into taskflow's task list.
+//
+// Each imported task keeps where it came from in Source, e.g.
+// "taskwarrior:<uuid>", so importing the same export again finds the tasks
+// it created before instead of duplicating them.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// Result is what a parser read from an export.
+type Result struct {
+	Tasks []models.Task
+	// Skipped counts records that are not tasks to import, such as deleted
+	// Taskwarrior tasks or recurrence templates.
+	Skipped int
+	// Unmapped counts, per source field, the records that had a value in a
+	// field taskflow has no place for.
+	Unmapped map[string]int
+}
+
+// UnmappedFields lists the unmapped fields by name.
+func (r *Result) UnmappedFields() []string {
+	names := make([]string, 0, len(r.Unmapped))
+	for name := range r.Unmapped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Result) unmapped(field string) {
+	if r.Unmapped == nil {
+		r.Unmapped = map[string]int{}
+	}
+	r.Unmapped[field]++
+}
+
+// Parser reads one export format. now stamps tasks the export has no dates
+// for and resolves relative dates.
+type Parser func(r io.Reader, now time.Time) (*Result, error)
+
+// Formats are the supported export formats by name.
+var Formats = map[string]Parser{
+	"taskwarrior": ParseTaskwarrior,
+	"todotxt":     ParseTodoTxt,
+	"todoist":     ParseTodoist,
+	"trello":      ParseTrello,
+}
+
+// Stats summarises a Merge.
+type Stats struct {
+	Added     int
+	Updated   int
+	Unchanged int // imported before and skipped (or identical with update)
+}
+
+// Merge adds the imported tasks to all. A task whose Source matches an
+// existing task was imported before: it is left alone, or with update its
+// imported fields replace the existing ones. Local additions (notes, time
+// logged, history) are kept either way. Dependencies are rewritten to the
+// IDs the tasks end up with; those pointing outside the result are dropped.
+func Merge(all, imported []models.Task, update bool) ([]models.Task, Stats) {
+	var st Stats
+	out := append([]models.Task(nil), all...)
+	bySource := map[string]int{}
+	for i, t := range out {
+		if t.Source != "" {
+			bySource[t.Source] = i
+		}
+	}
+	// Resolve the IDs first, so dependencies can point at tasks further on.
+	ids := map[string]string{} // imported ID -> ID in out
+	for _, t := range imported {
+		if i, seen := bySource[t.Source]; seen && t.Source != "" {
+			ids[t.ID] = out[i].ID
+		} else {
+			ids[t.ID] = t.ID
+		}
+	}
+	for _, t := range imported {
+		var deps []string
+		for _, d := range t.DependsOn {
+			if id, ok := ids[d]; ok {
+				deps = append(deps, id)
+			} else if existing(out, d) {
+				deps = append(deps, d)
+			}
+		}
+		t.DependsOn = deps
+
+		i, seen := bySource[t.Source]
+		if !seen || t.Source == "" {
+			out = append(out, t)
+			bySource[t.Source] = len(out) - 1
+			st.Added++
+			continue
+		}
+		if !update {
+			st.Unchanged++
+			continue
+		}
+		next := out[i]
+		next.Title = t.Title
+		next.Description = t.Description
+		next.DueDate = t.DueDate
+		next.Status = t.Status
+		next.Priority = t.Priority
+		next.Tags = t.Tags
+		next.Link = t.Link
+		next.DependsOn = t.DependsOn
+		if t.CompletedAt != "" {
+			next.CompletedAt = t.CompletedAt
+		}
+		if next.Notes == "" {
+			next.Notes = t.Notes
+		}
+		if sameImported(out[i], next) {
+			st.Unchanged++
+			continue
+		}
+		out[i] = next
+		st.Updated++
+	}
+	return out, st
+}
+
+func sameImported(a, b models.Task) bool {
+	return a.Title == b.Title && a.Description == b.Description && a.DueDate == b.DueDate &&
+		a.Status == b.Status && a.Priority == b.Priority && a.Link == b.Link &&
+		strings.Join(a.Tags, "\x00") == strings.Join(b.Tags, "\x00") &&
+		strings.Join(a.DependsOn, "\x00") == strings.Join(b.DependsOn, "\x00")
+}
+
+func existing(all []models.Task, id string) bool {
+	for _, t := range all {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// record is a JSON object whose fields are consumed one by one, so the
+// fields left over can be reported as unmapped.
+type record struct {
+	fields map[string]json.RawMessage
+	used   map[string]bool
+}
+
+func newRecord(raw json.RawMessage) (*record, error) {
+	r := &record{used: map[string]bool{}}
+	if err := json.Unmarshal(raw, &r.fields); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// get decodes field into v and reports whether it had a value.
+func (r *record) get(field string, v any) bool {
+	raw, ok := r.fields[field]
+	r.used[field] = true
+	if !ok || string(raw) == "null" {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+func (r *record) str(field string) string {
+	var s string
+	r.get(field, &s)
+	return s
+}
+
+// ignore marks fields that carry nothing worth importing (positions,
+// computed values, internal IDs).
+func (r *record) ignore(fields ...string) {
+	for _, f := range fields {
+		r.used[f] = true
+	}
+}
+
+// report adds the fields not consumed and not empty to res.
+func (r *record) report(res *Result, prefix string) {
+	for name, raw := range r.fields {
+		if r.used[name] {
+			continue
+		}
+		switch strings.TrimSpace(string(raw)) {
+		case "null", `""`, "[]", "{}", "false", "0":
+			continue
+		}
+		res.unmapped(prefix + name)
+	}
+}
+
+// priorityAt maps a level of another tool, 0 being the most urgent, onto
+// the workflow priorities; levels past the last one get the least urgent.
+func priorityAt(level int) string {
+	names := workflow.Current().PriorityNames()
+	if level >= len(names) {
+		level = len(names) - 1
+	}
+	if level < 0 {
+		level = 0
+	}
+	return names[level]
+}
+
+// priorityNamed returns the workflow priority called name, or the default.
+func priorityNamed(name string) string {
+	if p, ok := workflow.Current().Priority(name); ok {
+		return p.Name
+	}
+	return workflow.Current().DefaultPriority()
+}
+
+// status is the initial status, or the done status for finished tasks.
+func status(done bool) string {
+	if done {
+		return workflow.Current().Done()
+	}
+	return workflow.Current().Initial()
+}
+
+// stamp formats t like the lifecycle timestamps.
+func stamp(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+
+// parseTime reads a timestamp in one of layouts; layouts without a zone
+// are read in local time. Dates alone stay dates, which taskflow reads as
+// the end of that day.
+func parseTime(s string, layouts ...string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", false
+	}
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return s, true
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return stamp(t), true
+		}
+	}
+	return "", false
+}
+
+func decodeError(format string, err error) error {
+	return fmt.Errorf("reading %s export: %w", format, err)
+}
+
+// addTag appends tag unless it is empty or already present.
+func addTag(tags []string, tag string) []string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return tags
+	}
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
@@ -0,0 +1,186 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func parse(t *testing.T, p Parser, input string) *Result {
+	t.Helper()
+	res, err := p(strings.NewReader(input), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestTaskwarrior(t *testing.T) {
+	res := parse(t, ParseTaskwarrior, `[
+{"id":1,"uuid":"11111111-1111-4111-8111-111111111111","description":"Write report","status":"pending",
+ "entry":"20250901T080000Z","modified":"20250902T080000Z","start":"20250902T090000Z","due":"20251003T170000Z",
+ "project":"work.q4","tags":["writing","work.q4"],"priority":"H","urgency":12.3,
+ "depends":"22222222-2222-4222-8222-222222222222,99999999-9999-4999-8999-999999999999",
+ "annotations":[{"entry":"20250901T090000Z","description":"outline done"}],"wait":"20250905T000000Z"},
+{"id":0,"uuid":"22222222-2222-4222-8222-222222222222","description":"Gather data","status":"completed",
+ "entry":"20250801T080000Z","end":"20250815T080000Z","priority":"L","depends":[]},
+{"id":0,"uuid":"33333333-3333-4333-8333-333333333333","description":"Old","status":"deleted"}
+]`)
+	if len(res.Tasks) != 2 || res.Skipped != 1 {
+		t.Fatalf("tasks %d, skipped %d", len(res.Tasks), res.Skipped)
+	}
+	w := res.Tasks[0]
+	if w.ID != "11111111-1111-4111-8111-111111111111" || w.Source != "taskwarrior:"+w.ID || w.Status != "in-progress" ||
+		w.Priority != "high" || w.DueDate != "2025-10-03T17:00:00Z" || w.CreatedAt != "2025-09-01T08:00:00Z" ||
+		w.StartedAt != "2025-09-02T09:00:00Z" || w.Notes != "- 2025-09-01: outline done" {
+		t.Fatalf("task = %+v", w)
+	}
+	if !reflect.DeepEqual(w.Tags, []string{"work.q4", "writing"}) || len(w.DependsOn) != 2 {
+		t.Fatalf("tags %v, depends %v", w.Tags, w.DependsOn)
+	}
+	g := res.Tasks[1]
+	if g.Status != "done" || g.Priority != "low" || g.CompletedAt != "2025-08-15T08:00:00Z" {
+		t.Fatalf("task = %+v", g)
+	}
+	if !reflect.DeepEqual(res.UnmappedFields(), []string{"wait"}) {
+		t.Fatalf("unmapped = %v", res.Unmapped)
+	}
+}
+
+func TestTodoTxt(t *testing.T) {
+	res := parse(t, ParseTodoTxt, `(A) 2025-09-20 Call Mom +Family @phone due:2025-10-02
+x 2025-09-30 2025-09-01 Pay rent +Home pri:B
+Read https://example.com/post rec:1w
+
+(D) Water plants
+`)
+	if len(res.Tasks) != 4 {
+		t.Fatalf("tasks = %+v", res.Tasks)
+	}
+	call, rent, read, water := res.Tasks[0], res.Tasks[1], res.Tasks[2], res.Tasks[3]
+	if call.Title != "Call Mom" || call.Priority != "high" || call.DueDate != "2025-10-02" ||
+		!reflect.DeepEqual(call.Tags, []string{"Family", "phone"}) || call.Status != "to-do" {
+		t.Fatalf("call = %+v", call)
+	}
+	if rent.Status != "done" || rent.Priority != "medium" || rent.Title != "Pay rent" || rent.CompletedAt == "" || rent.CreatedAt == "" {
+		t.Fatalf("rent = %+v", rent)
+	}
+	if read.Title != "Read https://example.com/post rec:1w" || read.Priority != "medium" {
+		t.Fatalf("read = %+v", read)
+	}
+	if water.Priority != "low" {
+		t.Fatalf("water = %+v", water)
+	}
+	if !reflect.DeepEqual(res.UnmappedFields(), []string{"rec:"}) {
+		t.Fatalf("unmapped = %v", res.Unmapped)
+	}
+
+	// Completing a line keeps its identity.
+	again := parse(t, ParseTodoTxt, "x 2025-10-01 2025-09-20 Call Mom +Family @phone due:2025-10-02 pri:A\n")
+	if again.Tasks[0].Source != call.Source {
+		t.Fatalf("source changed: %s vs %s", again.Tasks[0].Source, call.Source)
+	}
+}
+
+func TestTodoist(t *testing.T) {
+	sync := `{"items":[
+{"id":"101","content":"Ship release","description":"v2","priority":4,"project_id":"p1","section_id":"s1",
+ "labels":["release"],"due":{"date":"2025-10-05","is_recurring":false},"checked":false,"added_at":"2025-09-01T10:00:00.000000Z",
+ "duration":{"amount":90,"unit":"minute"},"child_order":3,"responsible_uid":"42"},
+{"id":"102","content":"Standup","priority":1,"project_id":"p1","due":{"date":"2025-10-02T09:30:00","is_recurring":true},
+ "checked":true,"completed_at":"2025-09-30T09:45:00Z"},
+{"id":"103","content":"Gone","is_deleted":true}
+],"projects":[{"id":"p1","name":"Work"}],"sections":[{"id":"s1","name":"Next"}]}`
+	res := parse(t, ParseTodoist, sync)
+	if len(res.Tasks) != 2 || res.Skipped != 1 {
+		t.Fatalf("tasks %+v, skipped %d", res.Tasks, res.Skipped)
+	}
+	ship, standup := res.Tasks[0], res.Tasks[1]
+	if ship.Title != "Ship release" || ship.Description != "v2" || ship.Priority != "high" || ship.DueDate != "2025-10-05" ||
+		ship.Estimate != "1h30m" || ship.Source != "todoist:101" || ship.Link != "https://app.todoist.com/app/task/101" ||
+		ship.CreatedAt != "2025-09-01T10:00:00Z" || !reflect.DeepEqual(ship.Tags, []string{"Work", "Next", "release"}) {
+		t.Fatalf("ship = %+v", ship)
+	}
+	if standup.Status != "done" || standup.Priority != "low" || standup.CompletedAt != "2025-09-30T09:45:00Z" ||
+		!strings.HasPrefix(standup.DueDate, "2025-10-02T") {
+		t.Fatalf("standup = %+v", standup)
+	}
+	if !reflect.DeepEqual(res.UnmappedFields(), []string{"due.is_recurring", "responsible_uid"}) {
+		t.Fatalf("unmapped = %v", res.Unmapped)
+	}
+
+	rest := parse(t, ParseTodoist, `[{"id":"7","content":"Review","priority":3,"project_id":"x","is_completed":false,"url":"https://todoist.com/showTask?id=7"}]`)
+	if r := rest.Tasks[0]; r.Priority != "medium" || r.Link != "https://todoist.com/showTask?id=7" || len(r.Tags) != 0 {
+		t.Fatalf("rest task = %+v", r)
+	}
+	if !reflect.DeepEqual(rest.UnmappedFields(), []string{"project_id"}) {
+		t.Fatalf("unmapped = %v", rest.Unmapped)
+	}
+}
+
+func TestTrello(t *testing.T) {
+	res := parse(t, ParseTrello, `{"name":"Team","lists":[
+  {"id":"l1","name":"Backlog"},{"id":"l2","name":"In Progress"},{"id":"l3","name":"Old","closed":true}],
+"cards":[
+  {"id":"650000000000000000000001","name":"Design","desc":"mockups","idList":"l2","due":"2025-10-04T12:00:00.000Z",
+   "labels":[{"name":"ui","color":"green"},{"name":"","color":"red"}],"shortUrl":"https://trello.com/c/abc","pos":1,"idMembers":["m1"]},
+  {"id":"650000000000000000000002","name":"Launch","idList":"l1","dueComplete":true},
+  {"id":"650000000000000000000003","name":"Archived","idList":"l1","closed":true},
+  {"id":"650000000000000000000004","name":"On old list","idList":"l3"}],
+"checklists":[{"idCard":"650000000000000000000001","name":"Steps","checkItems":[
+  {"name":"Sketch","state":"complete"},{"name":"Review","state":"incomplete"}]}]}`)
+	if len(res.Tasks) != 2 || res.Skipped != 2 {
+		t.Fatalf("tasks %+v, skipped %d", res.Tasks, res.Skipped)
+	}
+	design, launch := res.Tasks[0], res.Tasks[1]
+	if design.Status != "in-progress" || design.Description != "mockups" || design.DueDate != "2025-10-04T12:00:00Z" ||
+		design.Link != "https://trello.com/c/abc" || design.Source != "trello:650000000000000000000001" ||
+		design.CreatedAt != "2023-09-12T06:06:56Z" || !reflect.DeepEqual(design.Tags, []string{"ui", "red"}) ||
+		design.Notes != "### Steps\n- [x] Sketch\n- [ ] Review" {
+		t.Fatalf("design = %+v", design)
+	}
+	if launch.Status != "done" || !reflect.DeepEqual(launch.Tags, []string{"Backlog"}) {
+		t.Fatalf("launch = %+v", launch)
+	}
+	if !reflect.DeepEqual(res.UnmappedFields(), []string{"idMembers"}) {
+		t.Fatalf("unmapped = %v", res.Unmapped)
+	}
+}
+
+func TestMergeDeduplicates(t *testing.T) {
+	existing := []models.Task{
+		{ID: "local", Title: "Mine", Status: "to-do"},
+		{ID: "a", Title: "Old title", Status: "to-do", Source: "todoist:1", Notes: "my notes"},
+	}
+	imported := []models.Task{
+		{ID: "new-a", Title: "New title", Status: "done", Source: "todoist:1", Notes: "remote"},
+		{ID: "new-b", Title: "Next", Status: "to-do", Source: "todoist:2", DependsOn: []string{"new-a", "gone"}},
+	}
+
+	out, st := Merge(existing, imported, false)
+	if st != (Stats{Added: 1, Unchanged: 1}) || len(out) != 3 || out[1].Title != "Old title" {
+		t.Fatalf("stats %+v, tasks %+v", st, out)
+	}
+	if !reflect.DeepEqual(out[2].DependsOn, []string{"a"}) {
+		t.Fatalf("dependencies = %v", out[2].DependsOn)
+	}
+
+	// Importing the result again adds nothing.
+	again, st := Merge(out, imported, false)
+	if st != (Stats{Unchanged: 2}) || len(again) != 3 {
+		t.Fatalf("stats %+v", st)
+	}
+
+	out, st = Merge(existing, imported, true)
+	if st != (Stats{Added: 1, Updated: 1}) || out[1].Title != "New title" || out[1].Status != "done" ||
+		out[1].ID != "a" || out[1].Notes != "my notes" {
+		t.Fatalf("stats %+v, tasks %+v", st, out)
+	}
+	if _, st = Merge(out, imported, true); st != (Stats{Unchanged: 2}) {
+		t.Fatalf("stats %+v", st)
+	}
+}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// twTime is Taskwarrior's timestamp format, always UTC.
+const twTime = "20060102T150405Z"
+
+// ParseTaskwarrior reads the JSON array printed by `task export`. The task
+// UUIDs become the task IDs, so dependencies carry over. Projects become
+// tags next to the task's own tags; annotations go to the notes. Deleted
+// tasks and recurrence templates are skipped.
+func ParseTaskwarrior(r io.Reader, now time.Time) (*Result, error) {
+	var raws []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raws); err != nil {
+		return nil, decodeError("Taskwarrior", err)
+	}
+	res := &Result{}
+	for i, raw := range raws {
+		rec, err := newRecord(raw)
+		if err != nil {
+			return nil, decodeError("Taskwarrior", fmt.Errorf("task %d: %w", i+1, err))
+		}
+		st := rec.str("status")
+		if st == "deleted" || st == "recurring" {
+			res.Skipped++
+			continue
+		}
+		done := st == "completed"
+		id := rec.str("uuid")
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, decodeError("Taskwarrior", fmt.Errorf("task %d: missing uuid", i+1))
+		}
+		t := models.Task{
+			ID:        id,
+			Title:     rec.str("description"),
+			Status:    status(done),
+			Priority:  priorityNamed(map[string]string{"H": "high", "M": "medium", "L": "low"}[rec.str("priority")]),
+			Source:    "taskwarrior:" + id,
+			CreatedAt: twStamp(rec.str("entry")),
+			UpdatedAt: twStamp(rec.str("modified")),
+		}
+		if done {
+			t.CompletedAt = twStamp(rec.str("end"))
+			rec.ignore("start")
+		} else {
+			rec.ignore("end")
+			if start := twStamp(rec.str("start")); start != "" {
+				t.Status = workflow.Current().Started()
+				t.StartedAt = start
+			}
+		}
+		if due := twStamp(rec.str("due")); due != "" {
+			t.DueDate = due
+		}
+		if p := rec.str("project"); p != "" {
+			t.Tags = addTag(t.Tags, p)
+		}
+		var tags []string
+		rec.get("tags", &tags)
+		for _, tag := range tags {
+			t.Tags = addTag(t.Tags, tag)
+		}
+		// Taskwarrior 2.x exports depends as a comma-separated string, 3.x
+		// as an array.
+		var deps []string
+		if !rec.get("depends", &deps) {
+			for _, d := range strings.Split(rec.str("depends"), ",") {
+				if d = strings.TrimSpace(d); d != "" {
+					deps = append(deps, d)
+				}
+			}
+		}
+		t.DependsOn = deps
+		var annotations []struct {
+			Entry       string `json:"entry"`
+			Description string `json:"description"`
+		}
+		rec.get("annotations", &annotations)
+		var notes []string
+		for _, a := range annotations {
+			line := "- " + a.Description
+			if at, err := time.Parse(twTime, a.Entry); err == nil {
+				line = "- " + at.Format("2006-01-02") + ": " + a.Description
+			}
+			notes = append(notes, line)
+		}
+		t.Notes = strings.Join(notes, "\n")
+		rec.ignore("id", "urgency", "mask", "imask", "parent")
+		rec.report(res, "")
+		res.Tasks = append(res.Tasks, t)
+	}
+	return res, nil
+}
+
+func twStamp(s string) string {
+	t, err := time.Parse(twTime, s)
+	if err != nil {
+		return ""
+	}
+	return stamp(t)
+}
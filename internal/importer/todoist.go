@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParseTodoist reads Todoist tasks as JSON: either the object returned by
+// the Sync API (items with their projects, sections and labels) or the
+// array of tasks returned by the REST API. Todoist's p1…p4 map onto the
+// workflow priorities from the most urgent down; labels, the project and
+// the section become tags; a duration becomes the estimate.
+func ParseTodoist(r io.Reader, now time.Time) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, decodeError("Todoist", err)
+	}
+	var export struct {
+		Items    []json.RawMessage `json:"items"`
+		Tasks    []json.RawMessage `json:"tasks"`
+		Projects []named           `json:"projects"`
+		Sections []named           `json:"sections"`
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &export.Items)
+	} else {
+		err = json.Unmarshal(data, &export)
+		export.Items = append(export.Items, export.Tasks...)
+	}
+	if err != nil {
+		return nil, decodeError("Todoist", err)
+	}
+	projects, sections := names(export.Projects), names(export.Sections)
+
+	res := &Result{}
+	for i, raw := range export.Items {
+		rec, err := newRecord(raw)
+		if err != nil {
+			return nil, decodeError("Todoist", fmt.Errorf("task %d: %w", i+1, err))
+		}
+		var deleted, checked, completed bool
+		if rec.get("is_deleted", &deleted) && deleted {
+			res.Skipped++
+			continue
+		}
+		rec.get("checked", &checked)
+		rec.get("is_completed", &completed)
+		id := rawID(rec, "id")
+		if id == "" {
+			return nil, decodeError("Todoist", fmt.Errorf("task %d: missing id", i+1))
+		}
+		t := models.Task{
+			ID:          uuid.New().String(),
+			Title:       rec.str("content"),
+			Description: rec.str("description"),
+			Status:      status(checked || completed),
+			Priority:    priorityNamed(""),
+			Source:      "todoist:" + id,
+			Link:        rec.str("url"),
+		}
+		if t.Link == "" {
+			t.Link = "https://app.todoist.com/app/task/" + id
+		}
+		var p int
+		if rec.get("priority", &p) && p >= 1 && p <= 4 {
+			t.Priority = priorityAt(4 - p)
+		}
+		for _, f := range []string{"added_at", "created_at"} {
+			if at, ok := parseTime(rec.str(f), time.RFC3339Nano); ok {
+				t.CreatedAt = at
+			}
+		}
+		if at, ok := parseTime(rec.str("completed_at"), time.RFC3339Nano); ok && t.Status == status(true) {
+			t.CompletedAt = at
+		}
+		var due struct {
+			Date        string `json:"date"`
+			Datetime    string `json:"datetime"`
+			IsRecurring bool   `json:"is_recurring"`
+		}
+		if rec.get("due", &due) {
+			for _, s := range []string{due.Datetime, due.Date} {
+				if at, ok := parseTime(s, time.RFC3339Nano, "2006-01-02T15:04:05"); ok {
+					t.DueDate = at
+					break
+				}
+			}
+			if due.IsRecurring {
+				res.unmapped("due.is_recurring")
+			}
+		}
+		var duration struct {
+			Amount int    `json:"amount"`
+			Unit   string `json:"unit"`
+		}
+		if rec.get("duration", &duration) && duration.Amount > 0 {
+			d := time.Duration(duration.Amount) * time.Minute
+			if duration.Unit == "day" {
+				d = time.Duration(duration.Amount) * 24 * time.Hour
+			}
+			t.Estimate = tasks.FormatDuration(d)
+		}
+		var labels []string
+		rec.get("labels", &labels)
+		t.Tags = addTag(t.Tags, idName(rec, res, "project_id", projects))
+		t.Tags = addTag(t.Tags, idName(rec, res, "section_id", sections))
+		for _, l := range labels {
+			t.Tags = addTag(t.Tags, l)
+		}
+		rec.ignore("child_order", "order", "day_order", "collapsed", "sync_id", "v2_id", "v2_project_id",
+			"v2_section_id", "v2_parent_id", "user_id", "added_by_uid", "assigned_by_uid", "creator_id",
+			"comment_count", "note_count", "is_archived")
+		rec.report(res, "")
+		res.Tasks = append(res.Tasks, t)
+	}
+	return res, nil
+}
+
+// named is a Todoist project or section.
+type named struct {
+	ID   json.RawMessage `json:"id"`
+	Name string          `json:"name"`
+}
+
+func names(list []named) map[string]string {
+	m := map[string]string{}
+	for _, n := range list {
+		m[strings.Trim(string(n.ID), `"`)] = n.Name
+	}
+	return m
+}
+
+// rawID reads an ID field, a number in old exports and a string in new
+// ones.
+func rawID(rec *record, field string) string {
+	raw, ok := rec.fields[field]
+	rec.used[field] = true
+	if !ok || string(raw) == "null" {
+		return ""
+	}
+	return strings.Trim(string(raw), `"`)
+}
+
+// idName returns the name of the project or section an ID field points to.
+// An ID the export has no name for (REST exports list no projects) is
+// reported as unmapped.
+func idName(rec *record, res *Result, field string, names map[string]string) string {
+	id := rawID(rec, field)
+	if id == "" {
+		return ""
+	}
+	name, ok := names[id]
+	if !ok {
+		res.unmapped(field)
+	}
+	return name
+}
@@ -0,0 +1,117 @@
+package importer
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+	"taskflow/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	todoPriority = regexp.MustCompile(`^\(([A-Z])\) `)
+	todoDate     = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}) `)
+	todoKeyValue = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):([^\s/][^\s]*)$`)
+)
+
+// ParseTodoTxt reads a todo.txt file (see todotxt.org). Priorities A, B, C…
+// map onto the workflow priorities from the most urgent down; +projects and
+// @contexts become tags; due:YYYY-MM-DD sets the due date. Other key:value
+// pairs stay in the title and are reported as unmapped.
+//
+// todo.txt lines have no IDs, so a line is recognised on re-import by its
+// text without the completion mark, priority and dates.
+func ParseTodoTxt(r io.Reader, now time.Time) (*Result, error) {
+	res := &Result{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		res.Tasks = append(res.Tasks, parseTodoLine(line, res))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, decodeError("todo.txt", err)
+	}
+	return res, nil
+}
+
+func parseTodoLine(line string, res *Result) models.Task {
+	t := models.Task{ID: uuid.New().String()}
+	done := strings.HasPrefix(line, "x ")
+	level := -1
+	if done {
+		line = line[2:]
+		if m := todoDate.FindStringSubmatch(line); m != nil {
+			t.CompletedAt = m[1]
+			line = line[len(m[0]):]
+		}
+	} else if m := todoPriority.FindStringSubmatch(line); m != nil {
+		level = int(m[1][0] - 'A')
+		line = line[len(m[0]):]
+	}
+	if m := todoDate.FindStringSubmatch(line); m != nil {
+		t.CreatedAt = m[1]
+		line = line[len(m[0]):]
+	}
+	// Dates alone are not timestamps; stamp them at the start of the day.
+	for _, s := range []*string{&t.CreatedAt, &t.CompletedAt} {
+		if d, err := time.ParseInLocation("2006-01-02", *s, time.Local); err == nil {
+			*s = stamp(d)
+		}
+	}
+
+	key := line
+	var words []string
+	for _, w := range strings.Fields(line) {
+		switch {
+		case len(w) > 1 && (w[0] == '+' || w[0] == '@'):
+			t.Tags = addTag(t.Tags, w[1:])
+			continue
+		case todoKeyValue.MatchString(w):
+			m := todoKeyValue.FindStringSubmatch(w)
+			switch strings.ToLower(m[1]) {
+			case "due":
+				if due, ok := parseTime(m[2]); ok {
+					t.DueDate = due
+					continue
+				}
+			case "pri":
+				// Completed tasks keep their priority as pri:A.
+				if len(m[2]) == 1 && m[2][0] >= 'A' && m[2][0] <= 'Z' {
+					level = int(m[2][0] - 'A')
+					continue
+				}
+			}
+			res.unmapped(strings.ToLower(m[1]) + ":")
+		}
+		words = append(words, w)
+	}
+	t.Title = strings.Join(words, " ")
+	t.Status = status(done)
+	t.Priority = priorityAt(level)
+	if level < 0 {
+		t.Priority = priorityNamed("")
+	}
+	sum := sha1.Sum([]byte(strings.Join(strings.Fields(stripPri(key)), " ")))
+	t.Source = "todotxt:" + hex.EncodeToString(sum[:8])
+	return t
+}
+
+// stripPri drops the pri:X pair, which todo.txt clients add on completion.
+func stripPri(s string) string {
+	var out []string
+	for _, w := range strings.Fields(s) {
+		if !strings.HasPrefix(strings.ToLower(w), "pri:") {
+			out = append(out, w)
+		}
+	}
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,140 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParseTrello reads a Trello board exported as JSON (Menu › Print, export
+// and share › Export as JSON). Each open card becomes a task. A list whose
+// name is a workflow status sets the status; other list names become tags,
+// like the card's labels. Checklists go to the notes. Archived cards and
+// cards on archived lists are skipped.
+func ParseTrello(r io.Reader, now time.Time) (*Result, error) {
+	var board struct {
+		Cards []json.RawMessage `json:"cards"`
+		Lists []struct {
+			ID     string `json:"id"`
+			Name   string `json:"name"`
+			Closed bool   `json:"closed"`
+		} `json:"lists"`
+		Checklists []struct {
+			IDCard     string `json:"idCard"`
+			Name       string `json:"name"`
+			CheckItems []struct {
+				Name  string  `json:"name"`
+				State string  `json:"state"`
+				Pos   float64 `json:"pos"`
+			} `json:"checkItems"`
+		} `json:"checklists"`
+	}
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, decodeError("Trello", err)
+	}
+	type list struct {
+		name   string
+		closed bool
+	}
+	lists := map[string]list{}
+	for _, l := range board.Lists {
+		lists[l.ID] = list{l.Name, l.Closed}
+	}
+	checklists := map[string][]string{}
+	for _, c := range board.Checklists {
+		lines := []string{"### " + c.Name}
+		for _, item := range c.CheckItems {
+			mark := " "
+			if item.State == "complete" {
+				mark = "x"
+			}
+			lines = append(lines, fmt.Sprintf("- [%s] %s", mark, item.Name))
+		}
+		checklists[c.IDCard] = append(checklists[c.IDCard], strings.Join(lines, "\n"))
+	}
+
+	wf := workflow.Current()
+	res := &Result{}
+	for i, raw := range board.Cards {
+		rec, err := newRecord(raw)
+		if err != nil {
+			return nil, decodeError("Trello", fmt.Errorf("card %d: %w", i+1, err))
+		}
+		id := rec.str("id")
+		if id == "" {
+			return nil, decodeError("Trello", fmt.Errorf("card %d: missing id", i+1))
+		}
+		var closed, dueComplete bool
+		rec.get("closed", &closed)
+		l := lists[rec.str("idList")]
+		if closed || l.closed {
+			res.Skipped++
+			continue
+		}
+		rec.get("dueComplete", &dueComplete)
+		t := models.Task{
+			ID:          uuid.New().String(),
+			Title:       rec.str("name"),
+			Description: rec.str("desc"),
+			Status:      status(dueComplete),
+			Priority:    priorityNamed(""),
+			Source:      "trello:" + id,
+			Link:        rec.str("shortUrl"),
+			CreatedAt:   trelloCreated(id),
+		}
+		if url := rec.str("url"); t.Link == "" {
+			t.Link = url
+		}
+		s, ok := wf.Status(l.name)
+		if !ok {
+			s, ok = wf.Status(strings.ReplaceAll(l.name, " ", "-")) // "In Progress"
+		}
+		if ok && !dueComplete {
+			t.Status = s.Name
+		} else {
+			t.Tags = addTag(t.Tags, l.name)
+		}
+		if due, ok := parseTime(rec.str("due"), time.RFC3339Nano); ok {
+			t.DueDate = due
+		}
+		var labels []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		}
+		rec.get("labels", &labels)
+		for _, label := range labels {
+			name := label.Name
+			if name == "" {
+				name = label.Color
+			}
+			t.Tags = addTag(t.Tags, name)
+		}
+		t.Notes = strings.Join(checklists[id], "\n\n")
+		rec.ignore("idBoard", "idLabels", "idChecklists", "idShort", "shortLink", "pos", "badges",
+			"dateLastActivity", "descData", "subscribed", "cover", "manualCoverAttachment",
+			"idAttachmentCover", "checkItemStates", "limits", "cardRole", "isTemplate", "email", "nodeId")
+		rec.report(res, "")
+		res.Tasks = append(res.Tasks, t)
+	}
+	return res, nil
+}
+
+// trelloCreated reads the creation time Trello encodes in the first eight
+// hex digits of an ID.
+func trelloCreated(id string) string {
+	if len(id) < 8 {
+		return ""
+	}
+	secs, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return ""
+	}
+	return stamp(time.Unix(secs, 0))
+}
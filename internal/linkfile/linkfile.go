@@ -0,0 +1,112 @@
+// Package linkfile keeps Markdown checklists in sync with tasks. Every
+// "- [ ]" or "- [x]" item of a linked file is a task; checking an item off
+// completes its task, and completing or reopening the task in taskflow
+// flips the checkbox on the item's line. Nothing else in the file changes.
+//
+// A snapshot of each item as last synced (see State) tells which side
+// changed since then, so edits on both sides are merged and conflicting
+// ones reported instead of overwritten.
+package linkfile
+
+import (
+	"regexp"
+	"strings"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+)
+
+// Item is a checklist item of a file.
+type Item struct {
+	Line    int    // 0-based line number
+	Checked bool   // [x]
+	Text    string // the text after the checkbox, as written
+}
+
+// Fields are the task fields an item's text sets.
+type Fields struct {
+	Title    string
+	Tags     []string
+	Priority string // empty unless the text has !priority
+	Due      string // empty unless the text has due:
+}
+
+// item matches a list item with a checkbox: "- [ ] text", "* [x] text",
+// "1. [ ] text", indented or not.
+var item = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*?)\s*$`)
+
+var fence = regexp.MustCompile("^\\s*(```|~~~)")
+
+// Parse returns the checklist items of a Markdown file. Items in fenced
+// code blocks are not tasks.
+func Parse(content string) []Item {
+	var items []Item
+	inFence := ""
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if m := fence.FindStringSubmatch(line); m != nil {
+			switch inFence {
+			case "":
+				inFence = m[1]
+			case m[1]:
+				inFence = ""
+			}
+			continue
+		}
+		if inFence != "" {
+			continue
+		}
+		if m := item.FindStringSubmatch(line); m != nil && m[4] != "" {
+			items = append(items, Item{Line: i, Checked: m[2] != " ", Text: m[4]})
+		}
+	}
+	return items
+}
+
+// Fields reads the inline syntax of the item text: #tag words become tags
+// (#123 stays, it is usually an issue), !priority words naming a workflow
+// priority set the priority and due:DATE sets the due date. The rest is the
+// title.
+func (it Item) Fields() Fields {
+	var f Fields
+	var words []string
+	for _, w := range strings.Fields(it.Text) {
+		switch {
+		case len(w) > 1 && w[0] == '#' && !numeric(w[1:]):
+			f.Tags = append(f.Tags, w[1:])
+			continue
+		case len(w) > 1 && w[0] == '!':
+			if p, ok := workflow.Current().Priority(w[1:]); ok {
+				f.Priority = p.Name
+				continue
+			}
+		case strings.HasPrefix(w, "due:") && tasks.ValidDue(w[4:]) && len(w) > 4:
+			f.Due = w[4:]
+			continue
+		}
+		words = append(words, w)
+	}
+	f.Title = strings.Join(words, " ")
+	return f
+}
+
+func numeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// setChecked returns the line with its checkbox set to checked.
+func setChecked(line string, checked bool) string {
+	m := item.FindStringSubmatchIndex(line)
+	if m == nil {
+		return line
+	}
+	mark := " "
+	if checked {
+		mark = "x"
+	}
+	return line[:m[4]] + mark + line[m[5]:]
+}
@@ -0,0 +1,160 @@
+package linkfile
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+const readme = `# Project
+
+- [ ] Write docs #docs !high due:2025-10-10
+- [x] Set up CI
+  * [ ] Fix flaky test, see #123
+
+` + "```" + `
+- [ ] not a task
+` + "```" + `
+1. [ ] Release
+`
+
+func TestParse(t *testing.T) {
+	items := Parse(readme)
+	var got []string
+	for _, it := range items {
+		got = append(got, it.Text)
+	}
+	want := []string{"Write docs #docs !high due:2025-10-10", "Set up CI", "Fix flaky test, see #123", "Release"}
+	if !reflect.DeepEqual(got, want) || !items[1].Checked || items[3].Line != 9 {
+		t.Fatalf("items = %+v", items)
+	}
+	f := items[0].Fields()
+	if f.Title != "Write docs" || f.Priority != "high" || f.Due != "2025-10-10" || !reflect.DeepEqual(f.Tags, []string{"docs"}) {
+		t.Fatalf("fields = %+v", f)
+	}
+	if f := items[2].Fields(); f.Title != "Fix flaky test, see #123" || len(f.Tags) != 0 {
+		t.Fatalf("fields = %+v", f)
+	}
+	if f := (Item{Text: "Ping !important"}).Fields(); f.Title != "Ping !important" || f.Priority != "" {
+		t.Fatalf("fields = %+v", f)
+	}
+}
+
+func find(t *testing.T, all []models.Task, title string) *models.Task {
+	t.Helper()
+	for i := range all {
+		if all[i].Title == title {
+			return &all[i]
+		}
+	}
+	t.Fatalf("no task %q in %+v", title, all)
+	return nil
+}
+
+func TestSyncBothWays(t *testing.T) {
+	path := filepath.Join("/notes", "README.md")
+	existing := []models.Task{{ID: "other", Title: "Unrelated", Status: "to-do"}}
+
+	// Linking creates a task per item.
+	content, all, snaps, rep := Sync(path, readme, nil, existing, now)
+	if content != readme || len(rep.Added) != 4 || len(all) != 5 {
+		t.Fatalf("link: %+v", rep)
+	}
+	docs := find(t, all, "Write docs")
+	if docs.Source != "file:/notes/README.md" || !reflect.DeepEqual(docs.Tags, []string{"README.md", "docs"}) ||
+		docs.Priority != "high" || docs.DueDate != "2025-10-10" {
+		t.Fatalf("docs task = %+v", docs)
+	}
+	if ci := find(t, all, "Set up CI"); ci.Status != "done" || ci.CompletedAt == "" {
+		t.Fatalf("ci task = %+v", ci)
+	}
+
+	// Nothing changed: nothing to do.
+	if c, _, _, rep := Sync(path, content, snaps, all, now); c != content || len(rep.Added)+len(rep.Updated)+len(rep.Written) != 0 {
+		t.Fatalf("idle sync: %+v", rep)
+	}
+
+	// Completed in taskflow, CI reopened in the file, a line inserted above.
+	find(t, all, "Write docs").Status = "done"
+	edited := strings.Replace(content, "# Project\n", "# Project\n\nIntro.\n", 1)
+	edited = strings.Replace(edited, "- [x] Set up CI", "- [ ] Set up CI", 1)
+	content, all, snaps, rep = Sync(path, edited, snaps, all, now)
+	if !strings.Contains(content, "\n- [x] Write docs #docs !high due:2025-10-10\n") || !strings.Contains(content, "\n- [ ] Set up CI\n") {
+		t.Fatalf("content:\n%s", content)
+	}
+	if len(rep.Written) != 1 || len(rep.Updated) != 1 || len(rep.Conflicts) != 0 {
+		t.Fatalf("report = %+v", rep)
+	}
+	if ci := find(t, all, "Set up CI"); ci.Status != "to-do" || ci.CompletedAt != "" {
+		t.Fatalf("ci task = %+v", ci)
+	}
+	lines := strings.Split(content, "\n")
+	if lines[snaps[0].Line] != "- [x] Write docs #docs !high due:2025-10-10" {
+		t.Fatalf("snapshot line %d: %q", snaps[0].Line, lines[snaps[0].Line])
+	}
+
+	// Text edits reach the task; a tag dropped in the file is dropped.
+	edited = strings.Replace(content, "Write docs #docs !high", "Write user docs !low", 1)
+	content, all, snaps, rep = Sync(path, edited, snaps, all, now)
+	docs = find(t, all, "Write user docs")
+	if docs.Priority != "low" || !reflect.DeepEqual(docs.Tags, []string{"README.md"}) || len(rep.Updated) != 1 {
+		t.Fatalf("docs task = %+v, report %+v", docs, rep)
+	}
+
+	// Unchecked in the file while completed in taskflow before; another
+	// task completed in taskflow.
+	find(t, all, "Release").Status = "done"
+	unchecked := strings.Replace(content, "- [x] Write user docs", "- [ ] Write user docs", 1)
+	content2, all2, snaps2, rep := Sync(path, unchecked, snaps, all, now)
+	if len(rep.Written) != 1 || len(rep.Updated) != 1 || find(t, all2, "Write user docs").Status != "to-do" ||
+		!strings.Contains(content2, "1. [x] Release") {
+		t.Fatalf("report = %+v, content:\n%s", rep, content2)
+	}
+
+	// A title edited on both sides keeps taskflow's and is reported.
+	find(t, all2, "Fix flaky test, see #123").Title = "Fix the flaky test"
+	renamed := strings.Replace(content2, "Fix flaky test, see #123", "Fix flaky login test", 1)
+	content3, all3, snaps3, rep := Sync(path, renamed, snaps2, all2, now)
+	if len(rep.Conflicts) != 1 || content3 != renamed {
+		t.Fatalf("conflict report = %+v", rep)
+	}
+	find(t, all3, "Fix the flaky test")
+
+	// Removed items and deleted tasks are reported once, then forgotten.
+	removed := strings.Replace(content3, "1. [x] Release\n", "", 1)
+	var kept []models.Task
+	for _, task := range all3 {
+		if task.Title != "Set up CI" {
+			kept = append(kept, task)
+		}
+	}
+	_, _, snaps4, rep := Sync(path, removed, snaps3, kept, now)
+	if len(rep.Unlinked) != 2 || len(rep.Added) != 0 {
+		t.Fatalf("report = %+v", rep)
+	}
+	if _, _, _, rep := Sync(path, removed, snaps4, kept, now); len(rep.Unlinked)+len(rep.Added) != 0 {
+		t.Fatalf("second report = %+v", rep)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "linked-files.json")
+	s, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Files["/b.md"] = []Snapshot{{TaskID: "1", Line: 2, Text: "x", Checked: true}}
+	s.Files["/a.md"] = nil
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	s, err = LoadState(path)
+	if err != nil || !reflect.DeepEqual(s.Linked(), []string{"/a.md", "/b.md"}) || s.Files["/b.md"][0].Line != 2 {
+		t.Fatalf("state = %+v, %v", s, err)
+	}
+}
@@ -0,0 +1,268 @@
+package linkfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/statefile"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Snapshot is a checklist item as it was when last synced.
+type Snapshot struct {
+	// TaskID is empty for an item whose task was deleted; such items are
+	// no longer synced.
+	TaskID  string `json:"task_id"`
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// State holds the snapshots of every linked file by absolute path. It is
+// kept as JSON in the storage directory.
+type State struct {
+	Files map[string][]Snapshot `json:"files"`
+
+	path string
+}
+
+// LoadState reads the state file at path; a missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	s := &State{path: path}
+	if err := statefile.Load(path, s); err != nil {
+		return nil, err
+	}
+	if s.Files == nil {
+		s.Files = map[string][]Snapshot{}
+	}
+	return s, nil
+}
+
+// Save writes the state back to its file.
+func (s *State) Save() error { return statefile.Save(s.path, s) }
+
+// Linked lists the linked files.
+func (s *State) Linked() []string {
+	paths := make([]string, 0, len(s.Files))
+	for p := range s.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Source is the Source of the tasks of the file at path.
+func Source(path string) string { return "file:" + path }
+
+// Report says what a Sync did, item by item.
+type Report struct {
+	Added     []string // new items, now tasks
+	Updated   []string // tasks changed by edits in the file
+	Written   []string // items checked or unchecked from taskflow
+	Unlinked  []string // items removed from the file or whose task was deleted
+	Conflicts []string // items whose title was edited on both sides; taskflow's is kept
+}
+
+// Sync merges the checklist in content (the file at path) with the tasks.
+// snaps are the file's snapshots from the last sync, nil for a file being
+// linked. It returns the new file content, the task list and the new
+// snapshots; all is not modified.
+func Sync(path, content string, snaps []Snapshot, all []models.Task, now time.Time) (string, []models.Task, []Snapshot, Report) {
+	var rep Report
+	out := append([]models.Task(nil), all...)
+	byID := map[string]int{}
+	for i, t := range out {
+		byID[t.ID] = i
+	}
+	lines := strings.Split(content, "\n")
+	items := Parse(content)
+	matched := match(items, snaps)
+	wf := workflow.Current()
+
+	var next []Snapshot
+	for i, it := range items {
+		j := matched[i]
+		if j < 0 {
+			t := newTask(path, it, now)
+			out = append(out, t)
+			rep.Added = append(rep.Added, t.Title)
+			next = append(next, Snapshot{TaskID: t.ID, Line: it.Line, Text: it.Text, Checked: it.Checked})
+			continue
+		}
+		s := snaps[j]
+		idx, ok := byID[s.TaskID]
+		if !ok {
+			if s.TaskID != "" {
+				rep.Unlinked = append(rep.Unlinked, fmt.Sprintf("line %d: %s (task deleted)", it.Line+1, it.Text))
+			}
+			next = append(next, Snapshot{Line: it.Line, Text: it.Text, Checked: it.Checked})
+			continue
+		}
+		t := &out[idx]
+		label := fmt.Sprintf("line %d: %s", it.Line+1, it.Text)
+		checked := it.Checked
+		taskDone := wf.IsTerminal(t.Status)
+		fileChanged, taskChanged := it.Checked != s.Checked, taskDone != s.Checked
+		updated := false
+		// A checkbox has two states: when both sides changed, they agree.
+		switch {
+		case fileChanged && taskChanged:
+		case fileChanged:
+			st := wf.Initial()
+			if it.Checked {
+				st = wf.Done()
+			}
+			tasks.SetStatus(t, st, now)
+			updated = true
+		case taskChanged:
+			lines[it.Line] = setChecked(lines[it.Line], taskDone)
+			rep.Written = append(rep.Written, label)
+			checked = taskDone
+		}
+		if it.Text != s.Text {
+			changed, conflict := applyText(t, Item{Text: s.Text}.Fields(), it.Fields())
+			if conflict {
+				rep.Conflicts = append(rep.Conflicts, label+" (title edited in both places)")
+			}
+			updated = updated || changed
+		}
+		if updated {
+			rep.Updated = append(rep.Updated, label)
+		}
+		next = append(next, Snapshot{TaskID: s.TaskID, Line: it.Line, Text: it.Text, Checked: checked})
+	}
+	for j, s := range snaps {
+		if !contains(matched, j) && s.TaskID != "" {
+			rep.Unlinked = append(rep.Unlinked, fmt.Sprintf("line %d: %s (removed from the file)", s.Line+1, s.Text))
+		}
+	}
+	return strings.Join(lines, "\n"), out, next, rep
+}
+
+// match pairs items with the snapshot they were: the same text on the same
+// line, then the same text on another line (lines moved), then the same
+// line (text edited). It returns the snapshot index per item, -1 for new
+// items.
+func match(items []Item, snaps []Snapshot) []int {
+	matched := make([]int, len(items))
+	for i := range matched {
+		matched[i] = -1
+	}
+	used := make([]bool, len(snaps))
+	pass := func(ok func(it Item, s Snapshot) bool) {
+		for i, it := range items {
+			if matched[i] >= 0 {
+				continue
+			}
+			best := -1
+			for j, s := range snaps {
+				if used[j] || !ok(it, s) {
+					continue
+				}
+				if best < 0 || abs(s.Line-it.Line) < abs(snaps[best].Line-it.Line) {
+					best = j
+				}
+			}
+			if best >= 0 {
+				matched[i] = best
+				used[best] = true
+			}
+		}
+	}
+	pass(func(it Item, s Snapshot) bool { return it.Text == s.Text && it.Line == s.Line })
+	pass(func(it Item, s Snapshot) bool { return it.Text == s.Text })
+	pass(func(it Item, s Snapshot) bool { return it.Line == s.Line })
+	return matched
+}
+
+// applyText applies an edit of the item text, from old to cur, to t. A
+// field taskflow changed too is left alone; for the title that is a
+// conflict.
+func applyText(t *models.Task, old, cur Fields) (changed, conflict bool) {
+	if cur.Title != old.Title && t.Title != cur.Title {
+		if t.Title == old.Title {
+			t.Title = cur.Title
+			changed = true
+		} else {
+			conflict = true
+		}
+	}
+	if cur.Priority != old.Priority && cur.Priority != "" && t.Priority == priorityOr(old.Priority) {
+		t.Priority = cur.Priority
+		changed = true
+	}
+	if cur.Due != old.Due && t.DueDate == old.Due {
+		t.DueDate = cur.Due
+		changed = true
+	}
+	for _, tag := range old.Tags {
+		if !contains(cur.Tags, tag) && contains(t.Tags, tag) {
+			t.Tags = remove(t.Tags, tag)
+			changed = true
+		}
+	}
+	for _, tag := range cur.Tags {
+		if !contains(t.Tags, tag) {
+			t.Tags = append(t.Tags, tag)
+			changed = true
+		}
+	}
+	return changed, conflict
+}
+
+func newTask(path string, it Item, now time.Time) models.Task {
+	f := it.Fields()
+	t := models.Task{
+		ID:       uuid.New().String(),
+		Title:    f.Title,
+		DueDate:  f.Due,
+		Status:   workflow.Current().Initial(),
+		Priority: priorityOr(f.Priority),
+		Source:   Source(path),
+		Tags:     append([]string{filepath.Base(path)}, f.Tags...),
+	}
+	if it.Checked {
+		tasks.SetStatus(&t, workflow.Current().Done(), now)
+	}
+	return t
+}
+
+// priorityOr returns p, or the default priority when p is empty.
+func priorityOr(p string) string {
+	if p == "" {
+		return workflow.Current().DefaultPriority()
+	}
+	return p
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, v string) []string {
+	var out []string
+	for _, x := range list {
+		if x != v {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -3,32 +3,60 @@ package models
 // Shared data models for tasks, calendar events, etc.
 
 type CalendarEvent struct {
-	ID          string
-	Title       string
-	StartTime   string // ISO8601 format
-	EndTime     string // ISO8601 format
-	Location    string
-	Description string
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	StartTime   string `json:"start_time"` // ISO8601 format
+	EndTime     string `json:"end_time"`   // ISO8601 format
+	Location    string `json:"location,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 // TaskList represents the top-level structure of the sample tasks file.
 type TaskList struct {
-	Tasks []Task `yaml:"tasks"`
+	Version int            `yaml:"version,omitempty" json:"version,omitempty"` // schema version, see internal/schema
+	Tasks   []Task         `yaml:"tasks" json:"tasks"`
+	Extra   map[string]any `yaml:",inline" json:"extra,omitempty"` // unknown top-level keys, written back unchanged
 }
 
 // Task represents a task from the sample file.
 type Task struct {
-	ID          string   `yaml:"id"`
-	Title       string   `yaml:"title"`
-	Description string   `yaml:"description,omitempty"`
-	DueDate     string   `yaml:"due,omitempty"`
-	Completed   bool     `yaml:"-"`
-	Status      string   `yaml:"status"`
-	Priority    string   `yaml:"priority"`
-	PriorityInt int      `yaml:"-"`
-	Source      string   `yaml:"source"`
-	Link        string   `yaml:"link"`
-	Tags        []string `yaml:"tags,omitempty"`
-	Notes       string   `yaml:"notes,omitempty"`
-	UpdatedAt   string   `yaml:"updated_at,omitempty"`
+	ID          string      `yaml:"id" json:"id"`
+	Title       string      `yaml:"title" json:"title"`
+	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
+	DueDate     string      `yaml:"due,omitempty" json:"due,omitempty"`
+	Estimate    string      `yaml:"estimate,omitempty" json:"estimate,omitempty"` // Go duration, e.g. "1h30m"
+	Completed   bool        `yaml:"-" json:"-"`
+	Status      string      `yaml:"status" json:"status"`
+	Priority    string      `yaml:"priority" json:"priority"`
+	PriorityInt int         `yaml:"-" json:"-"`
+	Source      string      `yaml:"source" json:"source"`
+	Link        string      `yaml:"link" json:"link"`
+	Tags        []string    `yaml:"tags,omitempty" json:"tags,omitempty"`
+	DependsOn   []string    `yaml:"depends_on,omitempty" json:"depends_on,omitempty"` // IDs of tasks that must be done first
+	Notes       string      `yaml:"notes,omitempty" json:"notes,omitempty"`
+	UpdatedAt   string      `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	CreatedAt   string      `yaml:"created_at,omitempty" json:"created_at,omitempty"`
+	StartedAt   string      `yaml:"started_at,omitempty" json:"started_at,omitempty"`
+	CompletedAt string      `yaml:"completed_at,omitempty" json:"completed_at,omitempty"`
+	ArchivedAt  string      `yaml:"archived_at,omitempty" json:"archived_at,omitempty"`
+	History     []Change    `yaml:"history,omitempty" json:"history,omitempty"`
+	Worklog     []WorkEntry `yaml:"worklog,omitempty" json:"worklog,omitempty"`
+	// Extra holds keys taskflow does not know (custom fields added by hand
+	// or by newer versions); they are written back unchanged.
+	Extra map[string]any `yaml:",inline" json:"extra,omitempty"`
+}
+
+// WorkEntry is a span of time spent on a task. End is empty while the timer runs.
+type WorkEntry struct {
+	Start string `yaml:"start" json:"start"` // RFC3339
+	End   string `yaml:"end,omitempty" json:"end,omitempty"`
+	Note  string `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+// Change records a single field change in a task's history.
+type Change struct {
+	At    string `yaml:"at" json:"at"`
+	Field string `yaml:"field" json:"field"`
+	From  string `yaml:"from,omitempty" json:"from,omitempty"`
+	To    string `yaml:"to,omitempty" json:"to,omitempty"`
 }
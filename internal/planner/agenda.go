@@ -0,0 +1,130 @@
+package planner
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// AgendaItem is a calendar event or a task due on an agenda day.
+type AgendaItem struct {
+	Start   time.Time
+	End     time.Time // zero for tasks
+	AllDay  bool      // task due on a date without a time
+	Title   string
+	EventID string // set for events
+	TaskID  string // the task itself, or the task an event is linked to
+	Task    *models.Task
+}
+
+// IsEvent reports whether the item is a calendar event.
+func (i AgendaItem) IsEvent() bool { return i.EventID != "" }
+
+// AgendaDay holds the items of one day in start order, all-day tasks first.
+type AgendaDay struct {
+	Date  time.Time
+	Items []AgendaItem
+}
+
+// Agenda lays out events and tasks due over days days starting at the
+// midnight of from (in from's location).
+func Agenda(tasks []models.Task, events []models.CalendarEvent, from time.Time, days int) []AgendaDay {
+	loc := from.Location()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	out := make([]AgendaDay, days)
+	for d := range out {
+		out[d].Date = start.AddDate(0, 0, d)
+	}
+	dayOf := func(t time.Time) int {
+		t = t.In(loc)
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		for d := range out {
+			if out[d].Date.Equal(day) {
+				return d
+			}
+		}
+		return -1
+	}
+
+	for _, e := range events {
+		s, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil {
+			continue
+		}
+		d := dayOf(s)
+		if d < 0 {
+			continue
+		}
+		item := AgendaItem{Start: s.In(loc), Title: e.Title, EventID: e.ID}
+		if end, err := time.Parse(time.RFC3339, e.EndTime); err == nil {
+			item.End = end.In(loc)
+		}
+		if i := LinkedTask(e, tasks); i >= 0 {
+			item.TaskID = tasks[i].ID
+			item.Task = &tasks[i]
+		}
+		out[d].Items = append(out[d].Items, item)
+	}
+
+	for i := range tasks {
+		t := &tasks[i]
+		item := AgendaItem{Title: t.Title, TaskID: t.ID, Task: t}
+		if due, err := time.Parse(time.RFC3339, t.DueDate); err == nil {
+			item.Start = due.In(loc)
+		} else if due, err := time.ParseInLocation("2006-01-02", t.DueDate, loc); err == nil {
+			item.Start, item.AllDay = due, true
+		} else {
+			continue
+		}
+		if d := dayOf(item.Start); d >= 0 {
+			out[d].Items = append(out[d].Items, item)
+		}
+	}
+
+	for d := range out {
+		items := out[d].Items
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].AllDay != items[j].AllDay {
+				return items[i].AllDay
+			}
+			return items[i].Start.Before(items[j].Start)
+		})
+	}
+	return out
+}
+
+var planEventSuffix = regexp.MustCompile(`-\d{12}$`)
+
+// LinkedTask returns the index of the task an event belongs to, or -1.
+// Planner blocks carry the task ID; other events match a task by title,
+// preferring open tasks.
+func LinkedTask(e models.CalendarEvent, tasks []models.Task) int {
+	if IsPlanEvent(e) {
+		id := planEventSuffix.ReplaceAllString(strings.TrimPrefix(e.ID, PlanEventPrefix), "")
+		for i, t := range tasks {
+			if t.ID == id {
+				return i
+			}
+		}
+	}
+	title := strings.ToLower(strings.TrimSpace(e.Title))
+	if title == "" {
+		return -1
+	}
+	match := -1
+	for i, t := range tasks {
+		if strings.ToLower(strings.TrimSpace(t.Title)) != title {
+			continue
+		}
+		if !workflow.Current().IsTerminal(t.Status) {
+			return i
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	return match
+}
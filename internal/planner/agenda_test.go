@@ -0,0 +1,48 @@
+package planner
+
+import (
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+func TestAgenda_InterleavesEventsAndTasks(t *testing.T) {
+	from := time.Date(2025, 10, 13, 8, 0, 0, 0, time.UTC) // Monday
+	tasks := []models.Task{
+		{ID: "t1", Title: "Write report", DueDate: "2025-10-13T15:00:00Z"},
+		{ID: "t2", Title: "Pay invoice", DueDate: "2025-10-14"},
+		{ID: "t3", Title: "Standup", Status: "done"},
+		{ID: "t4", Title: "Later", DueDate: "2025-11-01"},
+	}
+	events := []models.CalendarEvent{
+		{ID: "e1", Title: "Standup", StartTime: "2025-10-13T09:00:00Z", EndTime: "2025-10-13T09:15:00Z"},
+		{ID: "plan-t1-202510131000", Title: "Write report", StartTime: "2025-10-13T10:00:00Z", EndTime: "2025-10-13T11:00:00Z"},
+		{ID: "e2", Title: "Retro", StartTime: "2025-10-14T16:00:00Z"},
+	}
+
+	days := Agenda(tasks, events, from, 2)
+	if len(days) != 2 || !days[0].Date.Equal(time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+	mon := days[0].Items
+	if len(mon) != 3 || mon[0].EventID != "e1" || mon[1].EventID == "" || mon[2].TaskID != "t1" || mon[2].IsEvent() {
+		t.Fatalf("monday not in time order: %+v", mon)
+	}
+	if mon[0].TaskID != "t3" || mon[1].TaskID != "t1" {
+		t.Fatalf("events not linked to tasks: %+v", mon)
+	}
+	tue := days[1].Items
+	if len(tue) != 2 || !tue[0].AllDay || tue[0].TaskID != "t2" || tue[1].EventID != "e2" || tue[1].TaskID != "" {
+		t.Fatalf("all-day tasks should lead the day: %+v", tue)
+	}
+}
+
+func TestLinkedTask_PrefersOpenTask(t *testing.T) {
+	tasks := []models.Task{{ID: "a", Title: "Sync", Status: "done"}, {ID: "b", Title: "sync"}}
+	if i := LinkedTask(models.CalendarEvent{Title: "Sync "}, tasks); i != 1 {
+		t.Fatalf("expected open task, got %d", i)
+	}
+	if i := LinkedTask(models.CalendarEvent{Title: "Other"}, tasks); i != -1 {
+		t.Fatalf("expected no link, got %d", i)
+	}
+}
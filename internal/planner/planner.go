@@ -0,0 +1,340 @@
+package planner
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"taskflow/internal/models"
+	taskspkg "taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// PlanEventPrefix marks calendar events written by the planner so a later run
+// can replace them instead of treating them as busy time.
+const PlanEventPrefix = "plan-"
+
+// defaultEventLength is used for calendar events that have no end time.
+const defaultEventLength = 30 * time.Minute
+
+// Options controls how open tasks are laid out into free time.
+type Options struct {
+	Now             time.Time             // fixed clock; planning never starts before this instant
+	Days            int                   // number of calendar days to plan, starting with Now's day
+	WorkStart       time.Duration         // offset from midnight when the working day starts
+	WorkEnd         time.Duration         // offset from midnight when the working day ends
+	WorkDays        map[time.Weekday]bool // days with working hours; empty means every day
+	DefaultEstimate time.Duration         // used for tasks without an estimate
+	MinBlock        time.Duration         // smallest chunk a task may be split into
+}
+
+// Interval is a half-open time range [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+	Title string
+}
+
+// Block is a proposed time block for a task.
+type Block struct {
+	TaskID string
+	Title  string
+	Start  time.Time
+	End    time.Time
+	Late   bool // block ends after the task's due date
+}
+
+// Day is the plan for a single calendar day.
+type Day struct {
+	Date   time.Time
+	Busy   []Interval
+	Blocks []Block
+	Free   time.Duration // working time left unallocated after planning
+}
+
+// Unscheduled records a task the planner could not fit.
+type Unscheduled struct {
+	Task   models.Task
+	Needed time.Duration
+	Reason string
+}
+
+// Plan is the result of Build.
+type Plan struct {
+	Days        []Day
+	Unscheduled []Unscheduled
+}
+
+// Build proposes time blocks for open tasks in the free time left by events.
+// The result only depends on its inputs, so it is deterministic for a fixed
+// opts.Now. Tasks are taken in order of due date, then priority, then ID, and
+// are placed into the earliest free slots, split into chunks no shorter than
+// MinBlock. A task that does not fit entirely is left unscheduled rather than
+// overbooking the calendar.
+func Build(tasks []models.Task, events []models.CalendarEvent, opts Options) Plan {
+	if opts.Days <= 0 {
+		opts.Days = 1
+	}
+	if opts.MinBlock <= 0 {
+		opts.MinBlock = 15 * time.Minute
+	}
+	loc := opts.Now.Location()
+	busy := busyIntervals(events, loc)
+
+	var plan Plan
+	var free [][]Interval // free slots per day, consumed as tasks are placed
+	first := time.Date(opts.Now.Year(), opts.Now.Month(), opts.Now.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < opts.Days; i++ {
+		date := first.AddDate(0, 0, i)
+		day := Day{Date: date}
+		var slots []Interval
+		if len(opts.WorkDays) == 0 || opts.WorkDays[date.Weekday()] {
+			start := date.Add(opts.WorkStart)
+			end := date.Add(opts.WorkEnd)
+			if start.Before(opts.Now) {
+				start = opts.Now
+			}
+			for _, b := range busy {
+				if b.End.After(date.Add(opts.WorkStart)) && b.Start.Before(end) {
+					day.Busy = append(day.Busy, b)
+				}
+			}
+			if start.Before(end) {
+				slots = subtract(Interval{Start: start, End: end}, day.Busy)
+			}
+		}
+		plan.Days = append(plan.Days, day)
+		free = append(free, slots)
+	}
+
+	for _, t := range orderTasks(tasks, loc) {
+		need := opts.DefaultEstimate
+		if t.Estimate != "" {
+			d, err := time.ParseDuration(t.Estimate)
+			if err != nil || d <= 0 {
+				plan.Unscheduled = append(plan.Unscheduled, Unscheduled{Task: t, Reason: fmt.Sprintf("invalid estimate %q", t.Estimate)})
+				continue
+			}
+			need = d
+		}
+		if need <= 0 {
+			plan.Unscheduled = append(plan.Unscheduled, Unscheduled{Task: t, Reason: "no estimate"})
+			continue
+		}
+		chunks, ok := allocate(free, need, opts.MinBlock)
+		if !ok {
+			plan.Unscheduled = append(plan.Unscheduled, Unscheduled{Task: t, Needed: need, Reason: "not enough free time in planning window"})
+			continue
+		}
+		due, hasDue := taskspkg.ParseDue(t.DueDate, loc)
+		for _, c := range chunks {
+			free[c.day] = take(free[c.day], c.iv)
+			plan.Days[c.day].Blocks = append(plan.Days[c.day].Blocks, Block{
+				TaskID: t.ID,
+				Title:  t.Title,
+				Start:  c.iv.Start,
+				End:    c.iv.End,
+				Late:   hasDue && c.iv.End.After(due),
+			})
+		}
+	}
+
+	for i := range plan.Days {
+		sort.SliceStable(plan.Days[i].Blocks, func(a, b int) bool {
+			return plan.Days[i].Blocks[a].Start.Before(plan.Days[i].Blocks[b].Start)
+		})
+		for _, s := range free[i] {
+			plan.Days[i].Free += s.End.Sub(s.Start)
+		}
+	}
+	return plan
+}
+
+// Events converts the plan's blocks into calendar events that can be written
+// to calendar storage.
+func (p Plan) Events() []models.CalendarEvent {
+	var out []models.CalendarEvent
+	for _, d := range p.Days {
+		for _, b := range d.Blocks {
+			out = append(out, models.CalendarEvent{
+				ID:          PlanEventPrefix + b.TaskID + "-" + b.Start.UTC().Format("200601021504"),
+				Title:       b.Title,
+				StartTime:   b.Start.Format(time.RFC3339),
+				EndTime:     b.End.Format(time.RFC3339),
+				Description: "taskflow plan for task " + b.TaskID,
+			})
+		}
+	}
+	return out
+}
+
+// IsPlanEvent reports whether an event was written by the planner.
+func IsPlanEvent(e models.CalendarEvent) bool {
+	return strings.HasPrefix(e.ID, PlanEventPrefix)
+}
+
+type chunk struct {
+	day int
+	iv  Interval
+}
+
+// allocate finds the earliest chunks covering need without modifying free.
+func allocate(free [][]Interval, need, minBlock time.Duration) ([]chunk, bool) {
+	var out []chunk
+	remaining := need
+	for d, slots := range free {
+		for _, s := range slots {
+			length := s.End.Sub(s.Start)
+			if length < minBlock && length < remaining {
+				continue
+			}
+			use := length
+			if use > remaining {
+				use = remaining
+			}
+			// Avoid leaving a tail shorter than minBlock for the next chunk.
+			if rest := remaining - use; rest > 0 && rest < minBlock {
+				use = remaining - minBlock
+				if use < minBlock {
+					continue
+				}
+			}
+			out = append(out, chunk{day: d, iv: Interval{Start: s.Start, End: s.Start.Add(use)}})
+			remaining -= use
+			if remaining == 0 {
+				return out, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// take removes iv (which starts at the beginning of one of the slots) from slots.
+func take(slots []Interval, iv Interval) []Interval {
+	out := make([]Interval, 0, len(slots))
+	for _, s := range slots {
+		if s.Start.Equal(iv.Start) {
+			if iv.End.Before(s.End) {
+				out = append(out, Interval{Start: iv.End, End: s.End})
+			}
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// subtract returns the parts of window not covered by busy.
+func subtract(window Interval, busy []Interval) []Interval {
+	slots := []Interval{window}
+	for _, b := range busy {
+		var next []Interval
+		for _, s := range slots {
+			if !b.Start.Before(s.End) || !b.End.After(s.Start) {
+				next = append(next, s)
+				continue
+			}
+			if b.Start.After(s.Start) {
+				next = append(next, Interval{Start: s.Start, End: b.Start})
+			}
+			if b.End.Before(s.End) {
+				next = append(next, Interval{Start: b.End, End: s.End})
+			}
+		}
+		slots = next
+	}
+	return slots
+}
+
+func busyIntervals(events []models.CalendarEvent, loc *time.Location) []Interval {
+	var out []Interval
+	for _, e := range events {
+		if IsPlanEvent(e) {
+			continue
+		}
+		start, ok := parseTime(e.StartTime, loc)
+		if !ok {
+			continue
+		}
+		end, ok := parseTime(e.EndTime, loc)
+		if !ok || !end.After(start) {
+			end = start.Add(defaultEventLength)
+		}
+		out = append(out, Interval{Start: start, End: end, Title: e.Title})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out
+}
+
+// orderTasks returns open tasks ordered by due date in loc (undated last),
+// priority (highest first) and ID.
+func orderTasks(all []models.Task, loc *time.Location) []models.Task {
+	var open []models.Task
+	for _, t := range all {
+		if !workflow.Current().IsTerminal(t.Status) {
+			open = append(open, t)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		di, oki := taskspkg.ParseDue(open[i].DueDate, loc)
+		dj, okj := taskspkg.ParseDue(open[j].DueDate, loc)
+		if oki != okj {
+			return oki
+		}
+		if oki && !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		if open[i].PriorityInt != open[j].PriorityInt {
+			return open[i].PriorityInt > open[j].PriorityInt
+		}
+		return open[i].ID < open[j].ID
+	})
+	return open
+}
+
+// parseTime parses an RFC3339 event time into loc.
+func parseTime(s string, loc *time.Location) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.In(loc), true
+}
+
+// ParseClock parses a "HH:MM" time of day into an offset from midnight.
+func ParseClock(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time of day %q (want HH:MM)", s)
+	}
+	h, err1 := strconv.Atoi(parts[0])
+	m, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time of day %q (want HH:MM)", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// ParseWeekdays converts names such as "mon" or "Monday" into a weekday set.
+func ParseWeekdays(names []string) (map[time.Weekday]bool, error) {
+	out := map[time.Weekday]bool{}
+	for _, n := range names {
+		key := strings.ToLower(strings.TrimSpace(n))
+		if len(key) < 3 {
+			return nil, fmt.Errorf("invalid weekday %q", n)
+		}
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.HasPrefix(strings.ToLower(d.String()), key) {
+				out[d] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid weekday %q", n)
+		}
+	}
+	return out, nil
+}
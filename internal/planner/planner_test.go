@@ -0,0 +1,166 @@
+package planner
+
+import (
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+// Monday 2025-10-06 08:00 UTC
+var fixedNow = time.Date(2025, 10, 6, 8, 0, 0, 0, time.UTC)
+
+func testOptions() Options {
+	days, _ := ParseWeekdays([]string{"mon", "tue", "wed", "thu", "fri"})
+	return Options{
+		Now:             fixedNow,
+		Days:            2,
+		WorkStart:       9 * time.Hour,
+		WorkEnd:         17 * time.Hour,
+		WorkDays:        days,
+		DefaultEstimate: time.Hour,
+		MinBlock:        30 * time.Minute,
+	}
+}
+
+func at(day, hour, min int) string {
+	return time.Date(2025, 10, day, hour, min, 0, 0, time.UTC).Format(time.RFC3339)
+}
+
+func TestBuild_FillsFreeSlotsAroundEvents(t *testing.T) {
+	events := []models.CalendarEvent{
+		{ID: "e1", Title: "Standup", StartTime: at(6, 9, 0), EndTime: at(6, 10, 0)},
+	}
+	tasks := []models.Task{
+		{ID: "b", Title: "Later", Status: "to-do", Estimate: "1h", DueDate: at(8, 12, 0)},
+		{ID: "a", Title: "Sooner", Status: "to-do", Estimate: "2h", DueDate: at(7, 12, 0)},
+		{ID: "c", Title: "Closed", Status: "done", Estimate: "1h"},
+	}
+	plan := Build(tasks, events, testOptions())
+	if len(plan.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(plan.Days))
+	}
+	blocks := plan.Days[0].Blocks
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks on first day, got %+v", blocks)
+	}
+	if blocks[0].TaskID != "a" || blocks[0].Start.Hour() != 10 || blocks[0].End.Hour() != 12 {
+		t.Fatalf("earliest-due task should start after standup: %+v", blocks[0])
+	}
+	if blocks[1].TaskID != "b" || blocks[1].Start.Hour() != 12 {
+		t.Fatalf("second task should follow first: %+v", blocks[1])
+	}
+	if plan.Days[0].Free != 4*time.Hour {
+		t.Fatalf("expected 4h free, got %s", plan.Days[0].Free)
+	}
+	if len(plan.Unscheduled) != 0 {
+		t.Fatalf("unexpected unscheduled: %+v", plan.Unscheduled)
+	}
+}
+
+func TestBuild_RefusesToOverbook(t *testing.T) {
+	opts := testOptions()
+	opts.Days = 1
+	events := []models.CalendarEvent{
+		{ID: "e1", Title: "Workshop", StartTime: at(6, 9, 0), EndTime: at(6, 16, 0)},
+	}
+	tasks := []models.Task{
+		{ID: "a", Title: "Fits", Status: "to-do", Estimate: "1h"},
+		{ID: "b", Title: "Too big", Status: "to-do", Estimate: "3h"},
+	}
+	plan := Build(tasks, events, opts)
+	if len(plan.Days[0].Blocks) != 1 || plan.Days[0].Blocks[0].TaskID != "a" {
+		t.Fatalf("expected only task a planned, got %+v", plan.Days[0].Blocks)
+	}
+	if len(plan.Unscheduled) != 1 || plan.Unscheduled[0].Task.ID != "b" {
+		t.Fatalf("expected task b unscheduled, got %+v", plan.Unscheduled)
+	}
+}
+
+func TestBuild_SplitsAcrossDaysAndSkipsWeekends(t *testing.T) {
+	opts := testOptions()
+	opts.Now = time.Date(2025, 10, 10, 8, 0, 0, 0, time.UTC) // Friday
+	opts.Days = 4                                            // Fri..Mon
+	tasks := []models.Task{{ID: "a", Title: "Long", Status: "to-do", Estimate: "10h"}}
+	plan := Build(tasks, nil, opts)
+	if got := len(plan.Days[0].Blocks); got != 1 {
+		t.Fatalf("expected Friday block, got %d", got)
+	}
+	if len(plan.Days[1].Blocks)+len(plan.Days[2].Blocks) != 0 {
+		t.Fatalf("weekend must stay empty: %+v %+v", plan.Days[1].Blocks, plan.Days[2].Blocks)
+	}
+	mon := plan.Days[3].Blocks
+	if len(mon) != 1 || mon[0].End.Sub(mon[0].Start) != 2*time.Hour {
+		t.Fatalf("expected 2h remainder on Monday, got %+v", mon)
+	}
+}
+
+func TestBuild_IsDeterministicAndIgnoresOwnBlocks(t *testing.T) {
+	tasks := []models.Task{
+		{ID: "x", Title: "X", Status: "to-do", PriorityInt: 1},
+		{ID: "y", Title: "Y", Status: "to-do", PriorityInt: 3},
+	}
+	first := Build(tasks, nil, testOptions())
+	// Feeding the written plan back in must not consume the time it reserved.
+	second := Build(tasks, first.Events(), testOptions())
+	a, b := first.Events(), second.Events()
+	if len(a) != 2 || len(a) != len(b) {
+		t.Fatalf("expected identical plans, got %+v vs %+v", a, b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("plans differ at %d: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+	if a[0].Title != "Y" {
+		t.Fatalf("higher priority task should be planned first, got %s", a[0].Title)
+	}
+}
+
+func TestBuild_MarksLateBlocksAndStartsAtNow(t *testing.T) {
+	opts := testOptions()
+	opts.Now = time.Date(2025, 10, 6, 15, 30, 0, 0, time.UTC)
+	tasks := []models.Task{{ID: "a", Title: "Due today", Status: "to-do", Estimate: "1h", DueDate: at(6, 16, 0)}}
+	plan := Build(tasks, nil, opts)
+	b := plan.Days[0].Blocks
+	if len(b) != 1 || !b[0].Start.Equal(opts.Now) {
+		t.Fatalf("expected block starting at now, got %+v", b)
+	}
+	if !b[0].Late {
+		t.Fatalf("block ending after due date should be marked late")
+	}
+}
+
+func TestBuild_DateOnlyDueIsEndOfDayInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC-8", -8*60*60)
+	opts := testOptions()
+	opts.Now = time.Date(2025, 10, 6, 8, 0, 0, 0, loc)
+	tasks := []models.Task{
+		{ID: "a", Title: "Date only", Status: "to-do", Estimate: "1h", DueDate: "2025-10-07"},
+		{ID: "b", Title: "Tuesday evening", Status: "to-do", Estimate: "1h", DueDate: "2025-10-07T20:00:00-08:00"},
+		{ID: "c", Title: "All Monday", Status: "to-do", Estimate: "8h", DueDate: "2025-10-06"},
+	}
+	plan := Build(tasks, nil, opts)
+	mon, tue := plan.Days[0].Blocks, plan.Days[1].Blocks
+	if len(mon) != 1 || mon[0].TaskID != "c" || mon[0].Late {
+		t.Fatalf("work ending at 17:00 on the due date is not late: %+v", mon)
+	}
+	if len(tue) != 2 || tue[0].TaskID != "b" || tue[1].TaskID != "a" {
+		t.Fatalf("a date-only due date should sort after 20:00 that day: %+v", tue)
+	}
+}
+
+func TestParseClockAndWeekdays(t *testing.T) {
+	if d, err := ParseClock("09:30"); err != nil || d != 9*time.Hour+30*time.Minute {
+		t.Fatalf("ParseClock: %v %v", d, err)
+	}
+	if _, err := ParseClock("9am"); err == nil {
+		t.Fatalf("expected error for invalid clock")
+	}
+	days, err := ParseWeekdays([]string{"Mon", "friday"})
+	if err != nil || !days[time.Monday] || !days[time.Friday] || days[time.Sunday] {
+		t.Fatalf("ParseWeekdays: %v %v", days, err)
+	}
+	if _, err := ParseWeekdays([]string{"xyz"}); err == nil {
+		t.Fatalf("expected error for invalid weekday")
+	}
+}
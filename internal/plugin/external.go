@@ -0,0 +1,278 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// ExternalPrefix is the file name prefix of external plugin executables:
+// taskflow-<name> becomes the subcommand <name>.
+const ExternalPrefix = "taskflow-"
+
+// ProtocolVersion is the version of the JSON protocol spoken with external
+// plugins, sent in the first message.
+const ProtocolVersion = 1
+
+// infoTimeout bounds the `info` exchange, which runs for `plugin list`.
+const infoTimeout = 5 * time.Second
+
+// Info describes an external plugin, as returned by its info call.
+type Info struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	Usage       string `json:"usage,omitempty"`
+}
+
+// External is a plugin implemented by an executable that talks to taskflow
+// over stdin/stdout using newline-delimited JSON messages (see Message).
+type External struct {
+	name string
+	Path string
+	// Host serves the plugin's requests for tasks and events; Init sets it
+	// from the plugin context when it is nil.
+	Host Host
+
+	info    *Info
+	infoErr error
+}
+
+// NewExternal returns the external plugin for the executable at path.
+func NewExternal(path string) *External {
+	return &External{name: externalName(path), Path: path}
+}
+
+func (e *External) Name() string { return e.name }
+
+// Description is taken from the plugin's info call.
+func (e *External) Description() string {
+	info, err := e.Info()
+	if err != nil {
+		return fmt.Sprintf("external plugin (%v)", err)
+	}
+	if info.Description == "" {
+		return "external plugin"
+	}
+	return info.Description
+}
+
+// Init adds the plugin as a subcommand of ctx.Root unless a command of that
+// name already exists. Flags are passed to the plugin untouched.
+func (e *External) Init(ctx *Context) error {
+	if e.Host == nil {
+		e.Host = ctx.Storage
+	}
+	if ctx.Root == nil {
+		return nil
+	}
+	for _, c := range ctx.Root.Commands() {
+		if c.Name() == e.name || c.HasAlias(e.name) {
+			return fmt.Errorf("command %s already exists", e.name)
+		}
+	}
+	ctx.Root.AddCommand(&cobra.Command{
+		Use:                e.name,
+		Short:              "External plugin " + filepath.Base(e.Path),
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := e.Run(context.Background(), args, os.Stdout); err != nil {
+				fmt.Printf("Error running plugin %s: %v\n", e.name, err)
+			}
+		},
+	})
+	return nil
+}
+
+// Info asks the plugin to describe itself. The result is cached.
+func (e *External) Info() (Info, error) {
+	if e.info == nil {
+		info, err := e.fetchInfo()
+		e.info, e.infoErr = &info, err
+	}
+	return *e.info, e.infoErr
+}
+
+func (e *External) fetchInfo() (Info, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), infoTimeout)
+	defer cancel()
+	info := Info{Name: e.name}
+	var decodeErr error
+	err := e.session(ctx, Message{Method: "info", Params: mustJSON(runParams{Protocol: ProtocolVersion})}, io.Discard, func(m Message) bool {
+		if m.Method != "" {
+			return false
+		}
+		if m.Error != "" {
+			decodeErr = fmt.Errorf("%s", m.Error)
+		} else {
+			decodeErr = json.Unmarshal(m.Result, &info)
+		}
+		return true
+	})
+	if err == nil {
+		err = decodeErr
+	}
+	return info, err
+}
+
+// Run executes the plugin with args, serving its requests until it exits.
+// Text it prints (print messages, or any stdout line that is not JSON) goes
+// to out; its stderr is passed through.
+func (e *External) Run(ctx context.Context, args []string, out io.Writer) error {
+	first := Message{Method: "run", Params: mustJSON(runParams{Protocol: ProtocolVersion, Args: args})}
+	return e.session(ctx, first, out, nil)
+}
+
+type runParams struct {
+	Protocol int      `json:"protocol"`
+	Args     []string `json:"args,omitempty"`
+}
+
+// session starts the plugin, sends first and handles messages until the
+// plugin exits. stop, when set, sees every message first and can end the
+// session early by returning true.
+func (e *External) session(ctx context.Context, first Message, out io.Writer, stop func(Message) bool) error {
+	cmd := exec.CommandContext(ctx, e.Path)
+	if first.Method == "run" {
+		var p runParams
+		_ = json.Unmarshal(first.Params, &p)
+		cmd.Args = append(cmd.Args, p.Args...)
+	}
+	cmd.Env = append(os.Environ(), fmt.Sprintf("TASKFLOW_PLUGIN_PROTOCOL=%d", ProtocolVersion))
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(stdin)
+	if err := enc.Encode(first); err != nil {
+		_ = cmd.Wait()
+		return fmt.Errorf("sending %s: %w", first.Method, err)
+	}
+
+	sc := bufio.NewScanner(stdout)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		var m Message
+		if len(line) == 0 || line[0] != '{' || json.Unmarshal(line, &m) != nil {
+			fmt.Fprintln(out, string(line)) // plain output
+			continue
+		}
+		if stop != nil && stop(m) {
+			break
+		}
+		if m.Method == "print" {
+			var p struct {
+				Text string `json:"text"`
+			}
+			_ = json.Unmarshal(m.Params, &p)
+			fmt.Fprint(out, p.Text)
+			if !strings.HasSuffix(p.Text, "\n") {
+				fmt.Fprintln(out)
+			}
+			continue
+		}
+		if m.Method == "" {
+			continue // stray response
+		}
+		resp := Message{ID: m.ID}
+		result, err := Serve(e.Host, e.name, m)
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Result = mustJSON(result)
+		}
+		if err := enc.Encode(resp); err != nil {
+			break
+		}
+	}
+	_ = stdin.Close()
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return err
+	}
+	return sc.Err()
+}
+
+// Discover finds external plugin executables in dirs. When a name occurs in
+// several directories the first one wins; the result is sorted by name.
+func Discover(dirs []string) []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := externalName(entry.Name())
+			if !strings.HasPrefix(entry.Name(), ExternalPrefix) || name == "" || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool { return externalName(paths[i]) < externalName(paths[j]) })
+	return paths
+}
+
+// SearchDirs is where external plugins are looked for: the plugin directory
+// first, then every directory on PATH.
+func SearchDirs(pluginDir string) []string {
+	dirs := []string{}
+	if pluginDir != "" {
+		dirs = append(dirs, pluginDir)
+	}
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+func externalName(path string) string {
+	name := strings.TrimPrefix(filepath.Base(path), ExternalPrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
+}
+
+func isExecutable(path string) bool {
+	st, err := os.Stat(path)
+	if err != nil || st.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return st.Mode()&0111 != 0
+}
+
+func mustJSON(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return data
+}
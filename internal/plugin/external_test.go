@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+)
+
+type memHost struct {
+	tasks  []models.Task
+	events []models.CalendarEvent
+}
+
+func (h *memHost) ReadTasks() ([]models.Task, error) {
+	return append([]models.Task(nil), h.tasks...), nil
+}
+func (h *memHost) WriteTasks(ts []models.Task) error { h.tasks = ts; return nil }
+func (h *memHost) ReadCalendarEvents() ([]models.CalendarEvent, error) {
+	return append([]models.CalendarEvent(nil), h.events...), nil
+}
+func (h *memHost) WriteCalendarEvents(es []models.CalendarEvent) error { h.events = es; return nil }
+
+// TestPluginHelper is the fake external plugin; it only runs when started
+// through the wrapper script written by fakePlugin.
+func TestPluginHelper(t *testing.T) {
+	if os.Getenv("TASKFLOW_TEST_PLUGIN") != "1" {
+		return
+	}
+	in := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	var first Message
+	in.Scan()
+	_ = json.Unmarshal(in.Bytes(), &first)
+	if first.Method == "info" {
+		_ = out.Encode(Message{Result: mustJSON(Info{Name: "fake", Version: "0.1", Description: "A fake plugin"})})
+		os.Exit(0)
+	}
+	call := func(id int, method string, params any) Message {
+		_ = out.Encode(Message{ID: id, Method: method, Params: mustJSON(params)})
+		var resp Message
+		in.Scan()
+		_ = json.Unmarshal(in.Bytes(), &resp)
+		return resp
+	}
+	var p runParams
+	_ = json.Unmarshal(first.Params, &p)
+	call(1, "tasks.add", taskParams{Task: models.Task{Title: "From plugin " + strings.Join(p.Args, " ")}})
+	list := call(2, "tasks.list", nil)
+	var ts []models.Task
+	_ = json.Unmarshal(list.Result, &ts)
+	bad := call(3, "tasks.get", idParams{ID: "missing"})
+	_ = out.Encode(Message{Method: "print", Params: mustJSON(map[string]string{"text": fmt.Sprintf("%d tasks", len(ts))})})
+	fmt.Println("plain line")
+	fmt.Println("error: " + bad.Error)
+	os.Exit(0)
+}
+
+func fakePlugin(t *testing.T, dir, name string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell wrapper needs a POSIX shell")
+	}
+	path := filepath.Join(dir, ExternalPrefix+name)
+	script := fmt.Sprintf("#!/bin/sh\nTASKFLOW_TEST_PLUGIN=1 exec %q -test.run=TestPluginHelper -- \"$@\"\n", os.Args[0])
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	want := fakePlugin(t, first, "sync")
+	fakePlugin(t, second, "sync")
+	fakePlugin(t, second, "alpha")
+	_ = os.WriteFile(filepath.Join(second, ExternalPrefix+"noexec"), []byte("x"), 0644)
+	_ = os.WriteFile(filepath.Join(second, "other-tool"), []byte("x"), 0755)
+
+	got := Discover([]string{first, filepath.Join(first, "missing"), second})
+	if len(got) != 2 || externalName(got[0]) != "alpha" || got[1] != want {
+		t.Fatalf("unexpected plugins: %v", got)
+	}
+}
+
+func TestExternalInfoAndRun(t *testing.T) {
+	host := &memHost{tasks: []models.Task{{ID: "1", Title: "Existing"}}}
+	ext := NewExternal(fakePlugin(t, t.TempDir(), "fake"))
+	if err := ext.Init(&Context{Storage: host}); err != nil {
+		t.Fatal(err)
+	}
+	if ext.Name() != "fake" {
+		t.Fatalf("name = %q", ext.Name())
+	}
+	info, err := ext.Info()
+	if err != nil || info.Version != "0.1" || ext.Description() != "A fake plugin" {
+		t.Fatalf("info: %+v %v", info, err)
+	}
+
+	var out bytes.Buffer
+	if err := ext.Run(context.Background(), []string{"a", "b"}, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(host.tasks) != 2 || host.tasks[1].Title != "From plugin a b" || host.tasks[1].Source != "plugin:fake" || host.tasks[1].ID == "" {
+		t.Fatalf("task not added through host: %+v", host.tasks)
+	}
+	want := "2 tasks\nplain line\nerror: task missing not found\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestServeEvents(t *testing.T) {
+	host := &memHost{}
+	res, err := Serve(host, "x", Message{Method: "events.add", Params: mustJSON(eventParams{Event: models.CalendarEvent{Title: "Standup"}})})
+	if err != nil || len(host.events) != 1 || res.(models.CalendarEvent).ID == "" {
+		t.Fatalf("events.add: %v %+v", err, host.events)
+	}
+	if _, err := Serve(host, "x", Message{Method: "events.delete", Params: mustJSON(idParams{ID: host.events[0].ID})}); err != nil || len(host.events) != 0 {
+		t.Fatalf("events.delete: %v %+v", err, host.events)
+	}
+	if _, err := Serve(host, "x", Message{Method: "nope"}); err == nil {
+		t.Fatal("expected unknown method error")
+	}
+}
@@ -1,8 +1,12 @@
-
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"sort"
+	"taskflow/internal/models"
 
 	"github.com/spf13/cobra"
 )
@@ -11,33 +15,245 @@ import (
 type Plugin interface {
 	Name() string
 	Description() string
-	Init(rootCmd *cobra.Command)
+	// Init sets the plugin up: add commands to ctx.Root, read ctx.Config.
+	// An error disables the plugin (and the plugins that require it).
+	Init(ctx *Context) error
+}
+
+// Context is handed to a plugin's Init.
+type Context struct {
+	Root *cobra.Command
+	// Storage reads and writes tasks and events through the host's storage
+	// layer (the same access external plugins get).
+	Storage Host
+	// Config is the plugin's section of the config file, plugins.<name>.
+	Config map[string]any
+	// Logger writes to stderr, prefixed with the plugin name.
+	Logger *log.Logger
+}
+
+// Requirer is implemented by plugins that need other plugins to be
+// initialised first. A plugin whose requirements are missing, disabled or
+// failed is not initialised.
+type Requirer interface {
+	Requires() []string
+}
+
+// Shutdowner is implemented by plugins that need to clean up when taskflow
+// exits. Plugins are shut down in reverse initialisation order.
+type Shutdowner interface {
+	Shutdown() error
+}
+
+// TaskHooks is implemented by plugins that react to task mutations. The
+// callbacks run before the change is written: they may amend *t or return an
+// error to veto the whole write. Embed NoHooks to implement only some.
+type TaskHooks interface {
+	Plugin
+	OnAdd(t *models.Task) error
+	OnModify(prev models.Task, t *models.Task) error
+	OnComplete(t *models.Task) error
+	OnArchive(t *models.Task) error
 }
 
-var ( // Global plugin registry
-	plugins = make(map[string]Plugin)
+// NoHooks provides no-op TaskHooks callbacks for embedding.
+type NoHooks struct{}
+
+func (NoHooks) OnAdd(*models.Task) error                 { return nil }
+func (NoHooks) OnModify(models.Task, *models.Task) error { return nil }
+func (NoHooks) OnComplete(*models.Task) error            { return nil }
+func (NoHooks) OnArchive(*models.Task) error             { return nil }
+
+// State is what happened to a plugin during InitAll.
+type State int
+
+const (
+	Registered State = iota // InitAll has not run
+	Active                  // initialised
+	Disabled                // switched off in config
+	Failed                  // Init or a requirement failed; see Err
 )
 
-// RegisterPlugin registers a new plugin with the application.
-func RegisterPlugin(p Plugin) error {
-	if _, exists := plugins[p.Name()]; exists {
+func (s State) String() string {
+	return [...]string{"registered", "active", "disabled", "failed"}[s]
+}
+
+// Env is what the host provides to InitAll.
+type Env struct {
+	Root    *cobra.Command
+	Storage Host
+	// Config returns the plugins.<name> section; nil means no config.
+	Config func(name string) map[string]any
+	// Enabled reports whether a plugin is switched on; nil enables all.
+	Enabled func(name string) bool
+	// Log receives plugin log output; nil discards it.
+	Log io.Writer
+}
+
+// Registry holds plugins and their lifecycle. The package-level functions
+// use a default registry that plugins add themselves to from init().
+type Registry struct {
+	plugins map[string]Plugin
+	state   map[string]State
+	errs    map[string]error
+	active  []Plugin // in initialisation order
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{plugins: map[string]Plugin{}, state: map[string]State{}, errs: map[string]error{}}
+}
+
+// Register adds p to the registry.
+func (r *Registry) Register(p Plugin) error {
+	if _, exists := r.plugins[p.Name()]; exists {
 		return fmt.Errorf("plugin with name '%s' already registered", p.Name())
 	}
-	plugins[p.Name()] = p
+	r.plugins[p.Name()] = p
 	return nil
 }
 
-// GetPlugin returns a registered plugin by its name.
-func GetPlugin(name string) (Plugin, bool) {
-	p, ok := plugins[name]
+// Get returns a registered plugin by its name.
+func (r *Registry) Get(name string) (Plugin, bool) {
+	p, ok := r.plugins[name]
 	return p, ok
 }
 
-// GetAllPlugins returns all registered plugins.
-func GetAllPlugins() []Plugin {
-	var allPlugins []Plugin
-	for _, p := range plugins {
-		allPlugins = append(allPlugins, p)
+// All returns the registered plugins sorted by name.
+func (r *Registry) All() []Plugin {
+	all := make([]Plugin, 0, len(r.plugins))
+	for _, p := range r.plugins {
+		all = append(all, p)
 	}
-	return allPlugins
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
+
+// Active returns the initialised plugins in initialisation order.
+func (r *Registry) Active() []Plugin {
+	return append([]Plugin(nil), r.active...)
 }
+
+// State reports what InitAll did with the named plugin, and the error when
+// it failed.
+func (r *Registry) State(name string) (State, error) {
+	return r.state[name], r.errs[name]
+}
+
+// InitAll initialises the enabled plugins, each after the plugins it
+// requires, otherwise in name order. It returns one error per plugin that
+// could not be initialised; the others keep working.
+func (r *Registry) InitAll(env Env) []error {
+	var errs []error
+	fail := func(p Plugin, err error) {
+		r.state[p.Name()] = Failed
+		r.errs[p.Name()] = err
+		errs = append(errs, fmt.Errorf("plugin %s: %w", p.Name(), err))
+	}
+	out := env.Log
+	if out == nil {
+		out = io.Discard
+	}
+
+	// Visit in name order, depth-first through requirements.
+	visiting := map[string]bool{}
+	var visit func(p Plugin) bool
+	visit = func(p Plugin) bool {
+		name := p.Name()
+		switch r.state[name] {
+		case Active:
+			return true
+		case Disabled, Failed:
+			return false
+		}
+		if visiting[name] {
+			fail(p, errors.New("circular plugin requirement"))
+			return false
+		}
+		if env.Enabled != nil && !env.Enabled(name) {
+			r.state[name] = Disabled
+			return false
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		if req, ok := p.(Requirer); ok {
+			for _, dep := range req.Requires() {
+				d, ok := r.plugins[dep]
+				if !ok {
+					fail(p, fmt.Errorf("requires plugin %s, which is not installed", dep))
+					return false
+				}
+				if !visit(d) {
+					if r.state[name] != Failed {
+						fail(p, fmt.Errorf("requires plugin %s, which is %s", dep, r.state[dep]))
+					}
+					return false
+				}
+			}
+		}
+		ctx := &Context{
+			Root:    env.Root,
+			Storage: env.Storage,
+			Logger:  log.New(out, "["+name+"] ", 0),
+		}
+		if env.Config != nil {
+			ctx.Config = env.Config(name)
+		}
+		if ctx.Config == nil {
+			ctx.Config = map[string]any{}
+		}
+		if err := p.Init(ctx); err != nil {
+			fail(p, err)
+			return false
+		}
+		r.state[name] = Active
+		r.active = append(r.active, p)
+		return true
+	}
+	for _, p := range r.All() {
+		visit(p)
+	}
+	return errs
+}
+
+// ShutdownAll shuts the active plugins down in reverse initialisation order
+// and returns their errors.
+func (r *Registry) ShutdownAll() []error {
+	var errs []error
+	for i := len(r.active) - 1; i >= 0; i-- {
+		p := r.active[i]
+		if s, ok := p.(Shutdowner); ok {
+			if err := s.Shutdown(); err != nil {
+				errs = append(errs, fmt.Errorf("plugin %s: %w", p.Name(), err))
+			}
+		}
+		r.state[p.Name()] = Registered
+	}
+	r.active = nil
+	return errs
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry used by the package-level functions.
+func Default() *Registry { return defaultRegistry }
+
+// RegisterPlugin registers a new plugin with the application.
+func RegisterPlugin(p Plugin) error { return defaultRegistry.Register(p) }
+
+// MustRegister registers p and panics if the name is taken; it is meant for
+// the init() of compiled-in plugins, where a clash is a programming error.
+func MustRegister(p Plugin) {
+	if err := RegisterPlugin(p); err != nil {
+		panic(err)
+	}
+}
+
+// GetPlugin returns a registered plugin by its name.
+func GetPlugin(name string) (Plugin, bool) { return defaultRegistry.Get(name) }
+
+// GetAllPlugins returns all registered plugins, sorted by name.
+func GetAllPlugins() []Plugin { return defaultRegistry.All() }
+
+// ActivePlugins returns the initialised plugins in initialisation order.
+func ActivePlugins() []Plugin { return defaultRegistry.Active() }
@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// fake is a configurable in-process plugin that records its lifecycle calls.
+type fake struct {
+	name     string
+	requires []string
+	initErr  error
+	log      *[]string
+	config   map[string]any
+}
+
+func (f *fake) Name() string        { return f.name }
+func (f *fake) Description() string { return "fake " + f.name }
+func (f *fake) Requires() []string  { return f.requires }
+func (f *fake) Init(ctx *Context) error {
+	*f.log = append(*f.log, "init "+f.name)
+	f.config = ctx.Config
+	ctx.Logger.Printf("ready")
+	return f.initErr
+}
+func (f *fake) Shutdown() error {
+	*f.log = append(*f.log, "shutdown "+f.name)
+	if f.name == "bad-shutdown" {
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func newRegistry(t *testing.T, log *[]string, plugins ...*fake) *Registry {
+	t.Helper()
+	r := NewRegistry()
+	for _, p := range plugins {
+		p.log = log
+		if err := r.Register(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+func names(ps []Plugin) string {
+	var out []string
+	for _, p := range ps {
+		out = append(out, p.Name())
+	}
+	return strings.Join(out, ",")
+}
+
+func TestRegistryOrderAndShutdown(t *testing.T) {
+	var calls []string
+	r := newRegistry(t, &calls,
+		&fake{name: "zeta"},
+		&fake{name: "alpha", requires: []string{"storage"}},
+		&fake{name: "storage"},
+		&fake{name: "bad-shutdown"},
+	)
+	if err := r.Register(&fake{name: "alpha"}); err == nil {
+		t.Fatal("expected duplicate registration to fail")
+	}
+	if got := names(r.All()); got != "alpha,bad-shutdown,storage,zeta" {
+		t.Fatalf("All() = %s", got)
+	}
+
+	var logs bytes.Buffer
+	if errs := r.InitAll(Env{Root: &cobra.Command{}, Log: &logs}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if got := names(r.Active()); got != "storage,alpha,bad-shutdown,zeta" {
+		t.Fatalf("init order = %s", got)
+	}
+	if !strings.Contains(logs.String(), "[alpha] ready\n") {
+		t.Fatalf("logger output = %q", logs.String())
+	}
+
+	calls = nil
+	errs := r.ShutdownAll()
+	if got := strings.Join(calls, ","); got != "shutdown zeta,shutdown bad-shutdown,shutdown alpha,shutdown storage" {
+		t.Fatalf("shutdown order = %s", got)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "bad-shutdown: boom") {
+		t.Fatalf("shutdown errors = %v", errs)
+	}
+}
+
+func TestRegistryDependencies(t *testing.T) {
+	var calls []string
+	r := newRegistry(t, &calls,
+		&fake{name: "orphan", requires: []string{"missing"}},
+		&fake{name: "broken", initErr: errors.New("no token")},
+		&fake{name: "needs-broken", requires: []string{"broken"}},
+		&fake{name: "off"},
+		&fake{name: "needs-off", requires: []string{"off"}},
+		&fake{name: "loop-a", requires: []string{"loop-b"}},
+		&fake{name: "loop-b", requires: []string{"loop-a"}},
+		&fake{name: "fine"},
+	)
+	errs := r.InitAll(Env{Enabled: func(name string) bool { return name != "off" }})
+	if got := names(r.Active()); got != "fine" {
+		t.Fatalf("active = %s (errors %v)", got, errs)
+	}
+
+	want := map[string]string{
+		"orphan":       "requires plugin missing, which is not installed",
+		"broken":       "no token",
+		"needs-broken": "requires plugin broken, which is failed",
+		"needs-off":    "requires plugin off, which is disabled",
+		"loop-a":       "circular plugin requirement",
+	}
+	for name, msg := range want {
+		state, err := r.State(name)
+		if state != Failed || err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("%s: state %s, err %v; want failed with %q", name, state, err, msg)
+		}
+	}
+	if state, _ := r.State("off"); state != Disabled {
+		t.Errorf("off: state %s, want disabled", state)
+	}
+	if len(errs) != 6 { // loop-a and loop-b both fail
+		t.Errorf("got %d errors, want 6: %v", len(errs), errs)
+	}
+	for _, c := range calls {
+		if c != "init broken" && c != "init fine" {
+			t.Errorf("unexpected call %q", c)
+		}
+	}
+}
+
+func TestRegistryConfig(t *testing.T) {
+	var calls []string
+	withConfig := &fake{name: "sync"}
+	without := &fake{name: "other"}
+	r := newRegistry(t, &calls, withConfig, without)
+	r.InitAll(Env{Config: func(name string) map[string]any {
+		if name == "sync" {
+			return map[string]any{"url": "https://example.com"}
+		}
+		return nil
+	}})
+	if withConfig.config["url"] != "https://example.com" {
+		t.Fatalf("config not passed: %v", withConfig.config)
+	}
+	if without.config == nil {
+		t.Fatal("plugins without a config section should get an empty map")
+	}
+}
@@ -0,0 +1,189 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+
+	"github.com/google/uuid"
+)
+
+// Message is one line of the external plugin protocol, in either direction.
+//
+// The host starts the plugin and sends {"method":"info"} or
+// {"method":"run","params":{"protocol":1,"args":[...]}}. To info the plugin
+// answers {"result":{"name":...,"version":...,"description":...,"usage":...}}
+// and exits. While running, the plugin sends requests
+// {"id":1,"method":"tasks.list"} and the host answers {"id":1,"result":...}
+// or {"id":1,"error":"..."}; {"method":"print","params":{"text":"..."}}
+// shows text to the user. The session ends when the plugin exits.
+type Message struct {
+	ID     int             `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Host gives external plugins access to tasks and calendar events. Writes go
+// through the host's storage layer, so the usual validation applies.
+type Host interface {
+	ReadTasks() ([]models.Task, error)
+	WriteTasks([]models.Task) error
+	ReadCalendarEvents() ([]models.CalendarEvent, error)
+	WriteCalendarEvents([]models.CalendarEvent) error
+}
+
+// Methods lists the requests a plugin can make.
+var Methods = []string{
+	"tasks.list", "tasks.get", "tasks.add", "tasks.update", "tasks.delete",
+	"events.list", "events.add", "events.delete",
+}
+
+type idParams struct {
+	ID string `json:"id"`
+}
+
+type taskParams struct {
+	Task models.Task `json:"task"`
+}
+
+type eventParams struct {
+	Event models.CalendarEvent `json:"event"`
+}
+
+// Serve handles one request from the plugin called name.
+func Serve(h Host, name string, m Message) (any, error) {
+	if h == nil {
+		return nil, fmt.Errorf("no storage available")
+	}
+	switch m.Method {
+	case "tasks.list":
+		return h.ReadTasks()
+	case "tasks.get":
+		var p idParams
+		if err := decode(m, &p); err != nil {
+			return nil, err
+		}
+		all, err := h.ReadTasks()
+		if err != nil {
+			return nil, err
+		}
+		if i := taskIndex(all, p.ID); i >= 0 {
+			return all[i], nil
+		}
+		return nil, fmt.Errorf("task %s not found", p.ID)
+	case "tasks.add":
+		var p taskParams
+		if err := decode(m, &p); err != nil {
+			return nil, err
+		}
+		t := p.Task
+		if t.ID == "" {
+			t.ID = uuid.New().String()
+		}
+		if t.Status == "" {
+			t.Status = workflow.Current().Initial()
+		}
+		if t.Priority == "" {
+			t.Priority = workflow.Current().DefaultPriority()
+		}
+		if t.Source == "" {
+			t.Source = "plugin:" + name
+		}
+		all, err := h.ReadTasks()
+		if err != nil {
+			return nil, err
+		}
+		if err := h.WriteTasks(append(all, t)); err != nil {
+			return nil, err
+		}
+		return t, nil
+	case "tasks.update":
+		var p taskParams
+		if err := decode(m, &p); err != nil {
+			return nil, err
+		}
+		all, err := h.ReadTasks()
+		if err != nil {
+			return nil, err
+		}
+		i := taskIndex(all, p.Task.ID)
+		if i < 0 {
+			return nil, fmt.Errorf("task %s not found", p.Task.ID)
+		}
+		all[i] = p.Task
+		if err := h.WriteTasks(all); err != nil {
+			return nil, err
+		}
+		return all[i], nil
+	case "tasks.delete":
+		var p idParams
+		if err := decode(m, &p); err != nil {
+			return nil, err
+		}
+		all, err := h.ReadTasks()
+		if err != nil {
+			return nil, err
+		}
+		i := taskIndex(all, p.ID)
+		if i < 0 {
+			return nil, fmt.Errorf("task %s not found", p.ID)
+		}
+		return nil, h.WriteTasks(append(all[:i], all[i+1:]...))
+	case "events.list":
+		return h.ReadCalendarEvents()
+	case "events.add":
+		var p eventParams
+		if err := decode(m, &p); err != nil {
+			return nil, err
+		}
+		if p.Event.ID == "" {
+			p.Event.ID = uuid.New().String()
+		}
+		events, err := h.ReadCalendarEvents()
+		if err != nil {
+			return nil, err
+		}
+		if err := h.WriteCalendarEvents(append(events, p.Event)); err != nil {
+			return nil, err
+		}
+		return p.Event, nil
+	case "events.delete":
+		var p idParams
+		if err := decode(m, &p); err != nil {
+			return nil, err
+		}
+		events, err := h.ReadCalendarEvents()
+		if err != nil {
+			return nil, err
+		}
+		for i, ev := range events {
+			if ev.ID == p.ID {
+				return nil, h.WriteCalendarEvents(append(events[:i], events[i+1:]...))
+			}
+		}
+		return nil, fmt.Errorf("event %s not found", p.ID)
+	}
+	return nil, fmt.Errorf("unknown method %q", m.Method)
+}
+
+func decode(m Message, v any) error {
+	if len(m.Params) == 0 {
+		return fmt.Errorf("%s: missing params", m.Method)
+	}
+	if err := json.Unmarshal(m.Params, v); err != nil {
+		return fmt.Errorf("%s: invalid params: %w", m.Method, err)
+	}
+	return nil
+}
+
+func taskIndex(all []models.Task, id string) int {
+	for i, t := range all {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -18,17 +18,24 @@ func (p *HelloPlugin) Description() string {
 	return "A simple hello world plugin."
 }
 
-func (p *HelloPlugin) Init(rootCmd *cobra.Command) {
+// Init adds the hello command. The greeting can be changed with
+// plugins.hello.greeting in the config file.
+func (p *HelloPlugin) Init(ctx *plugin.Context) error {
+	greeting := "Hello from the plugin!"
+	if g, ok := ctx.Config["greeting"].(string); ok && g != "" {
+		greeting = g
+	}
 	helloCmd := &cobra.Command{
 		Use:   "hello",
 		Short: "Says hello",
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Hello from the plugin!")
+			fmt.Println(greeting)
 		},
 	}
-	rootCmd.AddCommand(helloCmd)
+	ctx.Root.AddCommand(helloCmd)
+	return nil
 }
 
 func init() {
-	plugin.RegisterPlugin(&HelloPlugin{})
+	plugin.MustRegister(&HelloPlugin{})
 }
@@ -0,0 +1,156 @@
+package reminders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os/exec"
+	"strings"
+	"taskflow/internal/webhooks"
+	"time"
+)
+
+// Notifier delivers reminders somewhere.
+type Notifier interface {
+	Name() string
+	Notify(r Reminder, now time.Time) error
+}
+
+// Stdout prints reminders as lines on W.
+type Stdout struct {
+	W io.Writer
+}
+
+func (Stdout) Name() string { return "stdout" }
+
+func (n Stdout) Notify(r Reminder, now time.Time) error {
+	_, err := fmt.Fprintf(n.W, "[%s] %s - %s\n", now.Local().Format("15:04"), r.Subject(now), r.Body())
+	return err
+}
+
+// Desktop shows freedesktop notifications through notify-send (or a
+// compatible Command), which talks to the notification daemon over D-Bus.
+type Desktop struct {
+	Command string
+}
+
+func (Desktop) Name() string { return "desktop" }
+
+func (n Desktop) Notify(r Reminder, now time.Time) error {
+	command := n.Command
+	if command == "" {
+		command = "notify-send"
+	}
+	urgency := "normal"
+	if r.Overdue {
+		urgency = "critical"
+	}
+	out, err := exec.Command(command, "--app-name=taskflow", "--urgency="+urgency, r.Subject(now), r.Body()).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %v: %s", command, err, msg)
+		}
+		return fmt.Errorf("%s: %w", command, err)
+	}
+	return nil
+}
+
+// Email sends reminders through an SMTP server without authentication,
+// typically a local relay or a test stand-in such as MailHog.
+type Email struct {
+	Addr string // host:port
+	From string
+	To   []string
+}
+
+func (Email) Name() string { return "email" }
+
+func (n Email) Notify(r Reminder, now time.Time) error {
+	if len(n.To) == 0 {
+		return fmt.Errorf("no recipients (notify.email.to)")
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerValue(r.Subject(now)))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n", r.Body())
+	return smtp.SendMail(n.Addr, nil, n.From, n.To, msg.Bytes())
+}
+
+// headerValue makes s safe for a mail header: line breaks (which would start
+// new headers) become spaces and non-ASCII text is MIME encoded.
+func headerValue(s string) string {
+	s = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(s)
+	return mime.QEncoding.Encode("utf-8", s)
+}
+
+// Webhook posts reminders as JSON with a Slack-compatible "text" field,
+// signed like the task webhooks when Secret is set.
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client // nil uses a client with a 10s timeout
+}
+
+func (Webhook) Name() string { return "webhook" }
+
+func (n Webhook) Notify(r Reminder, now time.Time) error {
+	body, err := json.Marshal(struct {
+		Text     string   `json:"text"`
+		Reminder Reminder `json:"reminder"`
+	}{r.Subject(now), r})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.Secret != "" {
+		req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(n.Secret, body))
+	}
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+// Notify delivers the pending reminders among rems through every notifier
+// and records them as fired. A reminder that no notifier managed to deliver
+// stays pending and is retried on the next call. It returns the number of
+// reminders delivered and the notifier errors.
+func Notify(s *State, notifiers []Notifier, rems []Reminder, now time.Time) (int, []error) {
+	sent := 0
+	var errs []error
+	for _, r := range s.Pending(rems, now) {
+		ok := false
+		for _, n := range notifiers {
+			if err := n.Notify(r, now); err != nil {
+				errs = append(errs, fmt.Errorf("%s notifier: %w", n.Name(), err))
+				continue
+			}
+			ok = true
+		}
+		if ok {
+			s.MarkFired(r, now)
+			sent++
+		}
+	}
+	return sent, errs
+}
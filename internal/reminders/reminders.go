@@ -0,0 +1,124 @@
+// Package reminders works out which task and event reminders are due,
+// remembers which have fired (and which were snoozed or acknowledged), and
+// delivers them through notifiers.
+package reminders
+
+import (
+	"fmt"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// Kinds of reminded items.
+const (
+	KindTask  = "task"
+	KindEvent = "event"
+)
+
+// Reminder is a task coming due or overdue, or an event about to start.
+type Reminder struct {
+	Kind     string    `json:"kind"`
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Priority string    `json:"priority,omitempty"`
+	When     time.Time `json:"when"` // due date or start time
+	Overdue  bool      `json:"overdue"`
+}
+
+// Ref identifies the reminded item, for snooze and ack.
+func (r Reminder) Ref() string { return Ref(r.Kind, r.ID) }
+
+// Ref returns the reference of a task or event.
+func Ref(kind, id string) string { return kind + ":" + id }
+
+// Key identifies one reminder: rescheduling an item, or it becoming overdue,
+// gives a new key, so it fires again.
+func (r Reminder) Key() string {
+	phase := "upcoming"
+	if r.Overdue {
+		phase = "overdue"
+	}
+	return fmt.Sprintf("%s:%s:%s", r.Ref(), r.When.UTC().Format(time.RFC3339), phase)
+}
+
+// Subject is a one-line summary, e.g. "Task due in 3h: Write report".
+func (r Reminder) Subject(now time.Time) string {
+	switch {
+	case r.Kind == KindEvent:
+		return fmt.Sprintf("Event starts in %s: %s", tasks.FormatDuration(r.When.Sub(now)), r.Title)
+	case r.Overdue:
+		return fmt.Sprintf("Task overdue by %s: %s", tasks.FormatDuration(now.Sub(r.When)), r.Title)
+	default:
+		return fmt.Sprintf("Task due in %s: %s", tasks.FormatDuration(r.When.Sub(now)), r.Title)
+	}
+}
+
+// Body gives the details: the due or start time and the priority.
+func (r Reminder) Body() string {
+	verb := "Due"
+	if r.Kind == KindEvent {
+		verb = "Starts"
+	}
+	body := fmt.Sprintf("%s %s", verb, r.When.Local().Format("Mon 2006-01-02 15:04"))
+	if r.Priority != "" {
+		body += " (priority " + r.Priority + ")"
+	}
+	return body
+}
+
+// LeadTimes says how long before its due date a task is reminded, by
+// priority; "default" applies to other priorities and "event" to calendar
+// events.
+type LeadTimes map[string]time.Duration
+
+// DefaultLeadTimes are used for keys missing from the config.
+var DefaultLeadTimes = LeadTimes{
+	"high":    24 * time.Hour,
+	"medium":  4 * time.Hour,
+	"low":     time.Hour,
+	"default": 4 * time.Hour,
+	"event":   15 * time.Minute,
+}
+
+func (l LeadTimes) get(key string) time.Duration {
+	if d, ok := l[key]; ok {
+		return d
+	}
+	if d, ok := DefaultLeadTimes[key]; ok {
+		return d
+	}
+	if key != "default" {
+		return l.get("default")
+	}
+	return 0
+}
+
+// Collect returns the reminders due at now: open tasks within their lead
+// time or past due, and events starting within the event lead time.
+func Collect(all []models.Task, events []models.CalendarEvent, leads LeadTimes, now time.Time) []Reminder {
+	var out []Reminder
+	wf := workflow.Current()
+	for _, t := range all {
+		if wf.IsTerminal(t.Status) {
+			continue
+		}
+		due, ok := tasks.ParseDue(t.DueDate, now.Location())
+		if !ok {
+			continue
+		}
+		if now.Before(due.Add(-leads.get(t.Priority))) {
+			continue
+		}
+		out = append(out, Reminder{Kind: KindTask, ID: t.ID, Title: t.Title, Priority: t.Priority, When: due, Overdue: due.Before(now)})
+	}
+	for _, e := range events {
+		start, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil || !start.After(now) || now.Before(start.Add(-leads.get(KindEvent))) {
+			continue
+		}
+		out = append(out, Reminder{Kind: KindEvent, ID: e.ID, Title: e.Title, When: start})
+	}
+	return out
+}
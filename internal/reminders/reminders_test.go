@@ -0,0 +1,254 @@
+package reminders
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/webhooks"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+
+func keys(rems []Reminder) string {
+	var out []string
+	for _, r := range rems {
+		s := r.ID
+		if r.Overdue {
+			s += "!"
+		}
+		out = append(out, s)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestCollectLeadTimes(t *testing.T) {
+	all := []models.Task{
+		{ID: "high-soon", Priority: "high", Status: "to-do", DueDate: at(20 * time.Hour)},
+		{ID: "low-soon", Priority: "low", Status: "to-do", DueDate: at(2 * time.Hour)},
+		{ID: "low-now", Priority: "low", Status: "to-do", DueDate: at(30 * time.Minute)},
+		{ID: "custom", Priority: "urgent", Status: "to-do", DueDate: at(3 * time.Hour)},
+		{ID: "late", Priority: "low", Status: "to-do", DueDate: "2025-09-30"},
+		{ID: "done", Priority: "high", Status: "done", DueDate: at(-time.Hour)},
+		{ID: "no-due", Status: "to-do"},
+	}
+	events := []models.CalendarEvent{
+		{ID: "standup", StartTime: at(10 * time.Minute)},
+		{ID: "later", StartTime: at(time.Hour)},
+		{ID: "past", StartTime: at(-time.Minute)},
+	}
+	got := Collect(all, events, LeadTimes{"low": 45 * time.Minute}, now)
+	if k := keys(got); k != "high-soon,low-now,custom,late!,standup" {
+		t.Fatalf("reminders = %s", k)
+	}
+	if s := got[3].Subject(now); s != "Task overdue by 12h: " {
+		t.Fatalf("subject = %q", s)
+	}
+	if s := got[4].Subject(now); s != "Event starts in 10m: " {
+		t.Fatalf("subject = %q", s)
+	}
+}
+
+func TestStateDedupSnoozeAck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify-state.json")
+	s, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := models.Task{ID: "1", Title: "Report", Priority: "high", Status: "to-do", DueDate: at(time.Hour)}
+	collect := func(at time.Time) []Reminder { return Collect([]models.Task{task}, nil, nil, at) }
+
+	// Fires once, then again when overdue.
+	rems := collect(now)
+	if p := s.Pending(rems, now); len(p) != 1 {
+		t.Fatalf("pending = %v", p)
+	}
+	s.MarkFired(rems[0], now)
+	if p := s.Pending(collect(now.Add(time.Minute)), now.Add(time.Minute)); len(p) != 0 {
+		t.Fatalf("fired twice: %v", p)
+	}
+	later := now.Add(2 * time.Hour)
+	rems = collect(later)
+	if p := s.Pending(rems, later); keys(p) != "1!" {
+		t.Fatalf("overdue reminder = %v", p)
+	}
+	s.MarkFired(rems[0], later)
+
+	// Snoozing silences the item, then lets it fire again.
+	s.Snooze(rems[0].Ref(), later.Add(time.Hour))
+	if p := s.Pending(rems, later.Add(30*time.Minute)); len(p) != 0 {
+		t.Fatalf("snoozed reminder fired: %v", p)
+	}
+	if p := s.Pending(rems, later.Add(2*time.Hour)); len(p) != 1 {
+		t.Fatalf("reminder did not come back after snooze: %v", p)
+	}
+
+	// Ack holds until the due date changes; state survives a reload.
+	s.Ack(rems[0].Ref(), rems[0].When)
+	s.Prune(rems, later)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if s, err = LoadState(path); err != nil {
+		t.Fatal(err)
+	}
+	if p := s.Pending(rems, later.Add(3*time.Hour)); len(p) != 0 {
+		t.Fatalf("acked reminder fired: %v", p)
+	}
+	task.DueDate = at(5 * time.Hour)
+	if p := s.Pending(collect(later), later); len(p) != 1 {
+		t.Fatalf("rescheduled task not reminded: %v", p)
+	}
+}
+
+type failing struct{}
+
+func (failing) Name() string                     { return "broken" }
+func (failing) Notify(Reminder, time.Time) error { return errors.New("offline") }
+
+func TestNotifyRetriesUndelivered(t *testing.T) {
+	s, _ := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	rems := []Reminder{{Kind: KindTask, ID: "1", Title: "Report", When: now.Add(time.Hour)}}
+
+	sent, errs := Notify(s, []Notifier{failing{}}, rems, now)
+	if sent != 0 || len(errs) != 1 || len(s.Pending(rems, now)) != 1 {
+		t.Fatalf("sent %d, errs %v", sent, errs)
+	}
+	var out bytes.Buffer
+	sent, errs = Notify(s, []Notifier{failing{}, Stdout{W: &out}}, rems, now)
+	if sent != 1 || len(errs) != 1 || len(s.Pending(rems, now)) != 0 {
+		t.Fatalf("sent %d, errs %v", sent, errs)
+	}
+	if !strings.Contains(out.String(), "Task due in 1h: Report - Due ") {
+		t.Fatalf("stdout = %q", out.String())
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var body []byte
+	var sig string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		sig = r.Header.Get(webhooks.SignatureHeader)
+	}))
+	defer srv.Close()
+
+	r := Reminder{Kind: KindTask, ID: "1", Title: "Report", When: now.Add(-time.Hour), Overdue: true}
+	if err := (Webhook{URL: srv.URL, Secret: "k", Client: srv.Client()}).Notify(r, now); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Text     string
+		Reminder Reminder
+	}
+	if err := json.Unmarshal(body, &got); err != nil || got.Text != "Task overdue by 1h: Report" || got.Reminder.ID != "1" {
+		t.Fatalf("body %s: %v", body, err)
+	}
+	if sig != webhooks.Sign("k", body) {
+		t.Fatalf("signature = %q", sig)
+	}
+}
+
+// fakeSMTP accepts one message and returns it on the channel.
+func fakeSMTP(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	msgs := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		in := bufio.NewReader(conn)
+		fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+		var data strings.Builder
+		for {
+			line, err := in.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				fmt.Fprint(conn, "250 localhost\r\n")
+			case cmd == "DATA":
+				fmt.Fprint(conn, "354 go ahead\r\n")
+				for {
+					l, err := in.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				msgs <- data.String()
+				fmt.Fprint(conn, "250 queued\r\n")
+			case cmd == "QUIT":
+				fmt.Fprint(conn, "221 bye\r\n")
+				return
+			default:
+				fmt.Fprint(conn, "250 ok\r\n")
+			}
+		}
+	}()
+	return ln.Addr().String(), msgs
+}
+
+func TestEmailNotifier(t *testing.T) {
+	addr, msgs := fakeSMTP(t)
+	r := Reminder{Kind: KindEvent, ID: "e", Title: "Standup", When: now.Add(15 * time.Minute)}
+	if err := (Email{Addr: addr, From: "taskflow@localhost", To: []string{"me@example.com"}}).Notify(r, now); err != nil {
+		t.Fatal(err)
+	}
+	msg := <-msgs
+	if !strings.Contains(msg, "Subject: Event starts in 15m: Standup\r\n") || !strings.Contains(msg, "To: me@example.com\r\n") {
+		t.Fatalf("message = %q", msg)
+	}
+	// A title cannot add headers, and non-ASCII titles are encoded.
+	addr, msgs = fakeSMTP(t)
+	r.Title = "Café\r\nBcc: spy@example.com"
+	if err := (Email{Addr: addr, From: "taskflow@localhost", To: []string{"me@example.com"}}).Notify(r, now); err != nil {
+		t.Fatal(err)
+	}
+	msg = <-msgs
+	if strings.Contains(msg, "\r\nBcc:") || !strings.Contains(msg, "Subject: =?utf-8?q?Event_starts_in_15m:_Caf=C3=A9_Bcc:_spy@example.com?=\r\n") {
+		t.Fatalf("message = %q", msg)
+	}
+}
+
+func TestDesktopNotifier(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell script")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "args")
+	script := filepath.Join(dir, "notify-send")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s\\n' \"$@\" > "+out+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r := Reminder{Kind: KindTask, ID: "1", Title: "Report", When: now.Add(-time.Hour), Overdue: true}
+	if err := (Desktop{Command: script}).Notify(r, now); err != nil {
+		t.Fatal(err)
+	}
+	args, _ := os.ReadFile(out)
+	if !strings.HasPrefix(string(args), "--app-name=taskflow\n--urgency=critical\nTask overdue by 1h: Report\nDue ") {
+		t.Fatalf("args = %q", args)
+	}
+}
@@ -0,0 +1,113 @@
+package reminders
+
+import (
+	"strings"
+	"taskflow/internal/statefile"
+	"time"
+)
+
+// State records which reminders fired and which items were snoozed or
+// acknowledged. It is kept as JSON in the storage directory.
+type State struct {
+	Fired   map[string]time.Time `json:"fired"`   // reminder key -> when it fired
+	Snoozed map[string]time.Time `json:"snoozed"` // item ref -> quiet until
+	Acked   map[string]time.Time `json:"acked"`   // item ref -> due/start acknowledged
+
+	path string
+}
+
+// LoadState reads the state file at path; a missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	s := &State{path: path}
+	if err := statefile.Load(path, s); err != nil {
+		return nil, err
+	}
+	if s.Fired == nil {
+		s.Fired = map[string]time.Time{}
+	}
+	if s.Snoozed == nil {
+		s.Snoozed = map[string]time.Time{}
+	}
+	if s.Acked == nil {
+		s.Acked = map[string]time.Time{}
+	}
+	return s, nil
+}
+
+// Save writes the state back to its file.
+func (s *State) Save() error { return statefile.Save(s.path, s) }
+
+// Pending filters rems down to those that should fire at now: not fired yet,
+// not snoozed and not acknowledged for this due date.
+func (s *State) Pending(rems []Reminder, now time.Time) []Reminder {
+	var out []Reminder
+	for _, r := range rems {
+		if until, ok := s.Snoozed[r.Ref()]; ok && now.Before(until) {
+			continue
+		}
+		if acked, ok := s.Acked[r.Ref()]; ok && acked.Equal(r.When) {
+			continue
+		}
+		if _, fired := s.Fired[r.Key()]; fired {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// MarkFired records that r was delivered at now.
+func (s *State) MarkFired(r Reminder, now time.Time) {
+	s.Fired[r.Key()] = now.UTC()
+	delete(s.Snoozed, r.Ref())
+}
+
+// Snooze silences the item until the given time; its reminders fire again
+// afterwards.
+func (s *State) Snooze(ref string, until time.Time) {
+	s.Snoozed[ref] = until.UTC()
+	s.forget(ref)
+}
+
+// Ack acknowledges the item's reminders for its current due or start time
+// (when); rescheduling the item brings them back.
+func (s *State) Ack(ref string, when time.Time) {
+	s.Acked[ref] = when.UTC()
+	delete(s.Snoozed, ref)
+}
+
+// Prune drops entries of items that no longer have a reminder, so the state
+// does not grow forever.
+func (s *State) Prune(current []Reminder, now time.Time) {
+	live := map[string]bool{}
+	keys := map[string]bool{}
+	for _, r := range current {
+		live[r.Ref()] = true
+		keys[r.Key()] = true
+	}
+	for key := range s.Fired {
+		if !keys[key] {
+			delete(s.Fired, key)
+		}
+	}
+	for ref, until := range s.Snoozed {
+		if !live[ref] && now.After(until) {
+			delete(s.Snoozed, ref)
+		}
+	}
+	for ref := range s.Acked {
+		if !live[ref] {
+			delete(s.Acked, ref)
+		}
+	}
+}
+
+// forget drops the fired marks of an item.
+func (s *State) forget(ref string) {
+	prefix := ref + ":"
+	for key := range s.Fired {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.Fired, key)
+		}
+	}
+}
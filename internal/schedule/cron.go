@@ -0,0 +1,145 @@
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"taskflow/internal/tasks"
+	"time"
+)
+
+// Markers around the managed block in the crontab; lines outside it are
+// left alone.
+const (
+	cronBegin = "# BEGIN taskflow schedule (managed by `taskflow schedule`, changes are overwritten)"
+	cronEnd   = "# END taskflow schedule"
+)
+
+// Cron installs jobs as entries in a block of the user's crontab.
+type Cron struct {
+	// Read returns the current crontab ("" when there is none); Write
+	// replaces it. The command wires these to `crontab -l` and `crontab -`.
+	Read  func() (string, error)
+	Write func(string) error
+}
+
+func (c Cron) Name() string { return "cron" }
+
+func (c Cron) Install(exe string, jobs []Job) error {
+	cur, err := c.Read()
+	if err != nil {
+		return err
+	}
+	lines := []string{cronBegin}
+	for _, j := range jobs {
+		spec, err := cronSpec(j.Every)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", j.Name, err)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s # %s every %s", spec, cronCommand(exe, j), j.Unit(), tasks.FormatDuration(j.Every)))
+	}
+	lines = append(lines, cronEnd)
+	base := stripBlock(cur)
+	if base != "" {
+		base += "\n"
+	}
+	return c.Write(base + strings.Join(lines, "\n") + "\n")
+}
+
+func (c Cron) Uninstall() error {
+	cur, err := c.Read()
+	if err != nil {
+		return err
+	}
+	base := stripBlock(cur)
+	if base != "" {
+		base += "\n"
+	}
+	return c.Write(base)
+}
+
+var cronLine = regexp.MustCompile(`# ` + Prefix + `(\S+) every (\S+)$`)
+
+func (c Cron) Status() ([]Status, error) {
+	cur, err := c.Read()
+	if err != nil {
+		return nil, err
+	}
+	var out []Status
+	in := false
+	for _, line := range strings.Split(cur, "\n") {
+		switch {
+		case line == cronBegin:
+			in = true
+		case line == cronEnd:
+			in = false
+		case in:
+			if m := cronLine.FindStringSubmatch(line); m != nil {
+				out = append(out, Status{Name: m[1], Every: m[2], Detail: "crontab entry"})
+			}
+		}
+	}
+	return out, nil
+}
+
+// stripBlock removes the managed block and trailing blank lines.
+func stripBlock(crontab string) string {
+	var keep []string
+	in := false
+	for _, line := range strings.Split(crontab, "\n") {
+		switch {
+		case line == cronBegin:
+			in = true
+		case line == cronEnd:
+			in = false
+		case !in:
+			keep = append(keep, line)
+		}
+	}
+	return strings.TrimRight(strings.Join(keep, "\n"), "\n")
+}
+
+// cronSpec turns an interval into a schedule. Cron can only repeat evenly
+// within an hour or a day, so the interval must divide one of them.
+func cronSpec(d time.Duration) (string, error) {
+	switch {
+	case d%time.Minute == 0 && d < time.Hour && time.Hour%d == 0:
+		if d == time.Minute {
+			return "* * * * *", nil
+		}
+		return fmt.Sprintf("*/%d * * * *", int(d.Minutes())), nil
+	case d%time.Hour == 0 && d < 24*time.Hour && (24*time.Hour)%d == 0:
+		if d == time.Hour {
+			return "0 * * * *", nil
+		}
+		return fmt.Sprintf("0 */%d * * *", int(d.Hours())), nil
+	case d == 24*time.Hour:
+		return "0 0 * * *", nil
+	}
+	return "", fmt.Errorf("cron cannot run every %s; use a divisor of an hour or a day", tasks.FormatDuration(d))
+}
+
+// cronCommand is the job's command line; cron treats % as a newline, so it
+// is escaped.
+func cronCommand(exe string, j Job) string {
+	var cmd string
+	if j.Command != "" {
+		cmd = "PATH=" + shQuote(searchPath(exe)) + " /bin/sh -c " + shQuote(j.Command)
+	} else {
+		args := []string{shQuote(exe)}
+		for _, a := range j.Args {
+			args = append(args, shQuote(a))
+		}
+		cmd = strings.Join(args, " ")
+	}
+	return strings.ReplaceAll(cmd, "%", `\%`)
+}
+
+var shSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+func shQuote(s string) string {
+	if shSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,81 @@
+// Package schedule installs taskflow's background jobs (reminders, gist
+// sync, calendar refresh, webhook delivery) as systemd user timers or, where
+// systemd is not available, as crontab entries.
+package schedule
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Job is a command run periodically.
+type Job struct {
+	Name  string
+	Every time.Duration
+	// Args are taskflow arguments; Command, a shell command line, replaces
+	// them for jobs that need a pipeline.
+	Args    []string
+	Command string
+}
+
+// Builtin are the jobs that only need an interval, by name.
+var Builtin = map[string][]string{
+	"notify":        {"notify", "--once"},
+	"gist-sync":     {"remote", "gist-sync"},
+	"calendar-sync": {"calendar", "sync"},
+	"webhooks":      {"webhooks", "run", "--once"},
+	"link-file":     {"link-file"},
+}
+
+// Prefix starts the names of the generated units and the crontab comments.
+const Prefix = "taskflow-"
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Validate checks the job's name, interval and command.
+func (j Job) Validate() error {
+	if !validName.MatchString(j.Name) {
+		return fmt.Errorf("job %q: names use lowercase letters, digits and dashes", j.Name)
+	}
+	if j.Every < time.Minute {
+		return fmt.Errorf("job %s: interval must be at least 1m", j.Name)
+	}
+	if len(j.Args) == 0 && strings.TrimSpace(j.Command) == "" {
+		return fmt.Errorf("job %s: no command (only %s are built in)", j.Name, builtinNames())
+	}
+	return nil
+}
+
+// Unit is the job's systemd unit name without suffix, e.g. taskflow-notify.
+func (j Job) Unit() string { return Prefix + j.Name }
+
+// Status describes an installed job.
+type Status struct {
+	Name   string
+	Every  string
+	Detail string // e.g. the timer state reported by systemctl
+}
+
+// Backend installs jobs somewhere.
+type Backend interface {
+	Name() string
+	// Install makes jobs the installed set, removing jobs installed before
+	// that are not in it. exe is the taskflow executable to run.
+	Install(exe string, jobs []Job) error
+	// Uninstall removes every installed job.
+	Uninstall() error
+	// Status lists the installed jobs by name.
+	Status() ([]Status, error)
+}
+
+func builtinNames() string {
+	names := make([]string, 0, len(Builtin))
+	for n := range Builtin {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
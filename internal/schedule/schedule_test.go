@@ -0,0 +1,151 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var jobs = []Job{
+	{Name: "notify", Every: 5 * time.Minute, Args: Builtin["notify"]},
+	{Name: "calendar", Every: time.Hour, Command: `gcalcli agenda "$(date +%F)" --tsv | taskflow calendar import gcal`},
+}
+
+const exe = "/opt/task flow/taskflow"
+
+func read(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSystemdInstallStatusUninstall(t *testing.T) {
+	dir := t.TempDir()
+	var calls []string
+	s := Systemd{Dir: dir, Systemctl: func(args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		return "active\n", nil
+	}}
+	// A hand-written unit with our prefix is never touched.
+	own := filepath.Join(dir, "taskflow-mine.timer")
+	_ = os.WriteFile(own, []byte("[Timer]\nOnCalendar=daily\n"), 0644)
+
+	if err := s.Install(exe, jobs); err != nil {
+		t.Fatal(err)
+	}
+	service := read(t, filepath.Join(dir, "taskflow-notify.service"))
+	if !strings.Contains(service, "Type=oneshot\nExecStart=\"/opt/task flow/taskflow\" notify --once\n") {
+		t.Fatalf("notify service:\n%s", service)
+	}
+	service = read(t, filepath.Join(dir, "taskflow-calendar.service"))
+	want := `ExecStart=/bin/sh -c "gcalcli agenda \"$$(date +%%F)\" --tsv | taskflow calendar import gcal"`
+	if !strings.Contains(service, want) || !strings.Contains(service, `Environment="PATH=/opt/task flow:/usr/local/bin:/usr/bin:/bin"`) {
+		t.Fatalf("calendar service:\n%s", service)
+	}
+	timer := read(t, filepath.Join(dir, "taskflow-notify.timer"))
+	if !strings.Contains(timer, "OnUnitActiveSec=5m\n") || !strings.Contains(timer, "WantedBy=timers.target") {
+		t.Fatalf("timer:\n%s", timer)
+	}
+	if got := strings.Join(calls, "|"); got != "daemon-reload|enable --now taskflow-notify.timer taskflow-calendar.timer" {
+		t.Fatalf("systemctl calls = %s", got)
+	}
+
+	status, err := s.Status()
+	if err != nil || len(status) != 2 || status[0] != (Status{Name: "calendar", Every: "1h", Detail: "timer active"}) {
+		t.Fatalf("status = %+v, %v", status, err)
+	}
+
+	// Reinstalling without the calendar job removes its units.
+	calls = nil
+	if err := s.Install(exe, jobs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "taskflow-calendar.service")); !os.IsNotExist(err) {
+		t.Fatalf("stale service kept: %v", err)
+	}
+	if calls[0] != "disable --now taskflow-calendar.timer" {
+		t.Fatalf("systemctl calls = %v", calls)
+	}
+
+	if err := s.Uninstall(); err != nil {
+		t.Fatal(err)
+	}
+	left, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(left) != 1 || left[0] != own {
+		t.Fatalf("files left: %v", left)
+	}
+}
+
+func TestCronInstallStatusUninstall(t *testing.T) {
+	original := "MAILTO=me@example.com\n0 3 * * * backup.sh\n"
+	crontab := original
+	c := Cron{
+		Read:  func() (string, error) { return crontab, nil },
+		Write: func(s string) error { crontab = s; return nil },
+	}
+	if err := c.Install(exe, jobs); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		original + cronBegin + "\n",
+		"*/5 * * * * '/opt/task flow/taskflow' notify --once # taskflow-notify every 5m\n",
+		`0 * * * * PATH='/opt/task flow:/usr/local/bin:/usr/bin:/bin' /bin/sh -c 'gcalcli agenda "$(date +\%F)" --tsv | taskflow calendar import gcal' # taskflow-calendar every 1h` + "\n",
+		cronEnd + "\n",
+	} {
+		if !strings.Contains(crontab, want) {
+			t.Fatalf("crontab lacks %q:\n%s", want, crontab)
+		}
+	}
+
+	if err := c.Install(exe, jobs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	status, err := c.Status()
+	if err != nil || len(status) != 1 || status[0] != (Status{Name: "notify", Every: "5m", Detail: "crontab entry"}) {
+		t.Fatalf("status = %+v, %v", status, err)
+	}
+
+	if err := c.Uninstall(); err != nil || crontab != original {
+		t.Fatalf("uninstall left %q (%v)", crontab, err)
+	}
+	if err := c.Install(exe, []Job{{Name: "odd", Every: 7 * time.Minute, Args: []string{"x"}}}); err == nil {
+		t.Fatal("expected 7m to be rejected for cron")
+	}
+}
+
+func TestCronSpec(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		time.Minute:      "* * * * *",
+		15 * time.Minute: "*/15 * * * *",
+		time.Hour:        "0 * * * *",
+		6 * time.Hour:    "0 */6 * * *",
+		24 * time.Hour:   "0 0 * * *",
+		90 * time.Minute: "",
+		48 * time.Hour:   "",
+	} {
+		got, err := cronSpec(d)
+		if got != want || (want == "") != (err != nil) {
+			t.Errorf("cronSpec(%s) = %q, %v; want %q", d, got, err, want)
+		}
+	}
+	if _, err := cronSpec(90 * time.Minute); err == nil || !strings.Contains(err.Error(), "every 1h30m;") {
+		t.Errorf("cronSpec error should name the interval: %v", err)
+	}
+}
+
+func TestJobValidate(t *testing.T) {
+	for _, j := range []Job{
+		{Name: "Bad Name", Every: time.Hour, Args: []string{"x"}},
+		{Name: "fast", Every: time.Second, Args: []string{"x"}},
+		{Name: "empty", Every: time.Hour},
+	} {
+		if err := j.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", j)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package schedule
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"taskflow/internal/tasks"
+)
+
+// unitMarker is the first line of generated units; only files carrying it
+// are replaced or removed.
+const unitMarker = "# Generated by taskflow schedule; changes are overwritten."
+
+// Systemd installs jobs as a oneshot service plus a timer per job, in the
+// user unit directory Dir (normally ~/.config/systemd/user).
+type Systemd struct {
+	Dir string
+	// Systemctl runs `systemctl --user` with args and returns its output.
+	// When nil the unit files are only written, not activated.
+	Systemctl func(args ...string) (string, error)
+}
+
+func (s Systemd) Name() string { return "systemd" }
+
+func (s Systemd) Install(exe string, jobs []Job) error {
+	installed, err := s.installed()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	keep := map[string]bool{}
+	for _, j := range jobs {
+		if err := os.WriteFile(filepath.Join(s.Dir, j.Unit()+".service"), []byte(serviceUnit(exe, j)), 0644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(s.Dir, j.Unit()+".timer"), []byte(timerUnit(j)), 0644); err != nil {
+			return err
+		}
+		keep[j.Name] = true
+	}
+	var stale []string
+	for _, name := range installed {
+		if !keep[name] {
+			stale = append(stale, name)
+		}
+	}
+	if err := s.remove(stale); err != nil {
+		return err
+	}
+	if _, err := s.systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	var names []string
+	for _, j := range jobs {
+		names = append(names, j.Name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	_, err = s.systemctl(append([]string{"enable", "--now"}, timers(names)...)...)
+	return err
+}
+
+func (s Systemd) Uninstall() error {
+	installed, err := s.installed()
+	if err != nil {
+		return err
+	}
+	if err := s.remove(installed); err != nil {
+		return err
+	}
+	_, err = s.systemctl("daemon-reload")
+	return err
+}
+
+func (s Systemd) Status() ([]Status, error) {
+	installed, err := s.installed()
+	if err != nil {
+		return nil, err
+	}
+	var out []Status
+	for _, name := range installed {
+		st := Status{Name: name, Detail: "unit files only"}
+		if every, err := unitValue(filepath.Join(s.Dir, Prefix+name+".timer"), "OnUnitActiveSec"); err == nil {
+			st.Every = every
+		}
+		if s.Systemctl != nil {
+			// is-active exits non-zero for inactive timers; the output says which.
+			state, _ := s.Systemctl("is-active", Prefix+name+".timer")
+			st.Detail = "timer " + strings.TrimSpace(state)
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// installed returns the names of the jobs with generated timers in Dir.
+func (s Systemd) installed() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.Dir, Prefix+"*.timer"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range paths {
+		if generated(p) {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(p), Prefix), ".timer"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// remove stops and deletes the units of the named jobs.
+func (s Systemd) remove(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if _, err := s.systemctl(append([]string{"disable", "--now"}, timers(names)...)...); err != nil {
+		return err
+	}
+	for _, name := range names {
+		for _, suffix := range []string{".timer", ".service"} {
+			p := filepath.Join(s.Dir, Prefix+name+suffix)
+			if !generated(p) {
+				continue
+			}
+			if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s Systemd) systemctl(args ...string) (string, error) {
+	if s.Systemctl == nil {
+		return "", nil
+	}
+	out, err := s.Systemctl(args...)
+	if err != nil {
+		return out, fmt.Errorf("systemctl --user %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(out))
+	}
+	return out, nil
+}
+
+func timers(names []string) []string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		out[i] = Prefix + n + ".timer"
+	}
+	return out
+}
+
+func serviceUnit(exe string, j Job) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n[Unit]\nDescription=taskflow %s\n\n[Service]\nType=oneshot\n", unitMarker, j.Name)
+	if j.Command != "" {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote("PATH="+searchPath(exe)))
+		fmt.Fprintf(&b, "ExecStart=/bin/sh -c %s\n", systemdQuote(j.Command))
+		return b.String()
+	}
+	args := []string{systemdQuote(exe)}
+	for _, a := range j.Args {
+		args = append(args, systemdQuote(a))
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(args, " "))
+	return b.String()
+}
+
+func timerUnit(j Job) string {
+	return fmt.Sprintf("%s\n[Unit]\nDescription=Run taskflow %s every %s\n\n[Timer]\nOnActiveSec=1min\nOnUnitActiveSec=%s\n\n[Install]\nWantedBy=timers.target\n",
+		unitMarker, j.Name, tasks.FormatDuration(j.Every), tasks.FormatDuration(j.Every))
+}
+
+// systemdQuote quotes an ExecStart argument; systemd expands % specifiers
+// and $ variables even inside quotes, so those are doubled.
+func systemdQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\$%;") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$", "%", "%%").Replace(s) + `"`
+}
+
+// searchPath is the PATH for shell commands: the directory of the taskflow
+// executable first, so `taskflow` works in them.
+func searchPath(exe string) string {
+	return filepath.Dir(exe) + ":/usr/local/bin:/usr/bin:/bin"
+}
+
+func generated(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	return strings.TrimSpace(line) == unitMarker
+}
+
+func unitValue(path, key string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if v, ok := strings.CutPrefix(line, key+"="); ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("%s: no %s", path, key)
+}
@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// dueLayouts are free-text date spellings Repair can still understand.
+var dueLayouts = []string{
+	"2006-01-02 15:04", "2006-01-02T15:04", "2006/01/02", "02.01.2006",
+	"Jan 2 2006", "Jan 2, 2006", "2 Jan 2006", "January 2 2006", "January 2, 2006",
+}
+
+// Repair fixes the problems Validate marks as fixable and returns a
+// description of each change: missing and duplicate IDs get fresh UUIDs (the
+// first task keeps a duplicated ID), unreadable due dates are rewritten as
+// YYYY-MM-DD when possible and otherwise moved into the notes, invalid
+// estimates and timestamps are cleared, and dependencies on unknown tasks are
+// dropped. known lists IDs outside l that dependencies may point to.
+func Repair(l *models.TaskList, known map[string]bool) []string {
+	var fixes []string
+	logf := func(i int, format string, args ...any) {
+		fixes = append(fixes, fmt.Sprintf("task %s: ", label(l.Tasks[i], i))+fmt.Sprintf(format, args...))
+	}
+
+	seen := map[string]bool{}
+	for i := range l.Tasks {
+		t := &l.Tasks[i]
+		t.ID = strings.TrimSpace(t.ID)
+		if t.ID == "" || seen[t.ID] {
+			old := t.ID
+			t.ID = uuid.New().String()
+			if old == "" {
+				logf(i, "assigned missing id")
+			} else {
+				logf(i, "duplicate of %s, assigned new id", old)
+			}
+		}
+		seen[t.ID] = true
+	}
+
+	for i := range l.Tasks {
+		t := &l.Tasks[i]
+		if !tasks.ValidDue(t.DueDate) {
+			if d, ok := parseLenient(t.DueDate); ok {
+				logf(i, "due %q rewritten as %s", t.DueDate, d)
+				t.DueDate = d
+			} else {
+				logf(i, "unreadable due %q moved to notes", t.DueDate)
+				t.Notes = strings.TrimSpace(t.Notes + "\n\nDue (unreadable): " + t.DueDate)
+				t.DueDate = ""
+			}
+		}
+		if t.Estimate != "" {
+			if _, err := time.ParseDuration(t.Estimate); err != nil {
+				logf(i, "cleared invalid estimate %q", t.Estimate)
+				t.Estimate = ""
+			}
+		}
+		for _, f := range []struct {
+			name string
+			v    *string
+		}{
+			{"created_at", &t.CreatedAt}, {"updated_at", &t.UpdatedAt}, {"started_at", &t.StartedAt},
+			{"completed_at", &t.CompletedAt}, {"archived_at", &t.ArchivedAt},
+		} {
+			if !validStamp(*f.v) {
+				logf(i, "cleared invalid %s %q", f.name, *f.v)
+				*f.v = ""
+			}
+		}
+		if len(t.DependsOn) > 0 {
+			var deps []string
+			for _, d := range t.DependsOn {
+				switch {
+				case d == t.ID:
+					logf(i, "dropped dependency on itself")
+				case !seen[d] && !known[d]:
+					logf(i, "dropped dependency on unknown task %s", d)
+				default:
+					deps = append(deps, d)
+				}
+			}
+			t.DependsOn = deps
+		}
+	}
+	return fixes
+}
+
+// CheckIDs rejects task lists with missing or duplicate IDs.
+func CheckIDs(all []models.Task) error {
+	seen := make(map[string]bool, len(all))
+	for i, t := range all {
+		if strings.TrimSpace(t.ID) == "" {
+			return fmt.Errorf("task %s has no id", label(t, i))
+		}
+		if seen[t.ID] {
+			return fmt.Errorf("duplicate task id %s", t.ID)
+		}
+		seen[t.ID] = true
+	}
+	return nil
+}
+
+// CheckTask validates the free-text fields of a write of t over its previous
+// version prev (nil for a new task). As with workflow.CheckTask only changed
+// values are checked, so legacy data does not block unrelated edits.
+func CheckTask(prev *models.Task, t models.Task) error {
+	if (prev == nil || t.DueDate != prev.DueDate) && !tasks.ValidDue(t.DueDate) {
+		return fmt.Errorf("task %s: invalid due date %q (want YYYY-MM-DD or RFC3339)", t.ID, t.DueDate)
+	}
+	if t.Estimate != "" && (prev == nil || t.Estimate != prev.Estimate) {
+		if _, err := time.ParseDuration(t.Estimate); err != nil {
+			return fmt.Errorf("task %s: invalid estimate %q (e.g. 1h30m)", t.ID, t.Estimate)
+		}
+	}
+	return nil
+}
+
+func parseLenient(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dueLayouts {
+		if d, err := time.Parse(layout, s); err == nil {
+			if strings.Contains(layout, "15:04") {
+				return d.Format(time.RFC3339), true
+			}
+			return d.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+func label(t models.Task, i int) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
@@ -0,0 +1,57 @@
+// Package schema versions the tasks file format. It migrates older files
+// forward, validates raw YAML with line/column positions and repairs the
+// problems that can be fixed without a human (see `taskflow doctor`).
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+)
+
+// Version is the schema version written by this build. Files without a
+// version field predate versioning and are treated as version 1.
+const Version = 2
+
+// migrations[v] upgrades a list from version v to v+1 in place.
+var migrations = map[int]func(l *models.TaskList){
+	1: migrateV1,
+}
+
+// Migrate upgrades l to the current Version. It reports whether anything was
+// migrated and fails for files written by a newer taskflow.
+func Migrate(l *models.TaskList) (bool, error) {
+	if l.Version == 0 {
+		l.Version = 1
+	}
+	if l.Version > Version {
+		return false, fmt.Errorf("tasks file has schema version %d, newer than this taskflow supports (%d); please upgrade", l.Version, Version)
+	}
+	from := l.Version
+	for v := from; v < Version; v++ {
+		migrations[v](l)
+	}
+	l.Version = Version
+	return from != Version, nil
+}
+
+// legacyPriorities maps priorities older versions wrote to the default
+// workflow's: `task prioritize` used to set "highest".
+var legacyPriorities = map[string]string{"highest": "high"}
+
+// migrateV1 stores statuses under their canonical workflow spelling, so the
+// legacy "todo" becomes "to-do" and "In-Progress" becomes "in-progress", and
+// replaces legacy priorities the workflow does not define.
+func migrateV1(l *models.TaskList) {
+	wf := workflow.Current()
+	for i := range l.Tasks {
+		t := &l.Tasks[i]
+		t.Status = wf.Canonical(t.Status)
+		if p, ok := legacyPriorities[strings.ToLower(t.Priority)]; ok {
+			if _, known := wf.Priority(t.Priority); !known {
+				t.Priority = p
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+)
+
+const broken = `version: 1
+tasks:
+  - id: a
+    title: First
+    status: todo
+    due: next friday
+    depends_on: [b, ghost]
+  - id: b
+    title: Second
+    status: bogus
+    estimate: soon
+    created_at: yesterday
+  - id: a
+    title: Dup
+    tags: urgent
+  - title: No id
+    depends_on: [old]
+`
+
+func TestValidatePositions(t *testing.T) {
+	problems := Validate([]byte(broken), Options{KnownIDs: map[string]bool{"old": true}})
+	want := []string{
+		"1:10: version: schema version 1 will be migrated to 2",
+		`6:10: task a: due: invalid date "next friday"`,
+		`7:21: task a: depends_on: unknown task "ghost"`,
+		`10:13: task b: status: unknown status "bogus"`,
+		`11:15: task b: estimate: invalid duration "soon"`,
+		`12:17: task b: created_at: invalid timestamp "yesterday"`,
+		"15:11: task a: tags: must be a list",
+		"13:9: task a: id: duplicate id",
+		"16:5: task #4: id: missing id",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems: %v", len(problems), problems)
+	}
+	for i, p := range problems {
+		if !strings.HasPrefix(p.String(), want[i]) {
+			t.Errorf("problem %d = %q, want prefix %q", i, p, want[i])
+		}
+	}
+	if problems[3].Fixable || !problems[1].Fixable {
+		t.Fatalf("unexpected fixable flags: %+v", problems)
+	}
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	problems := Validate([]byte("tasks:\n  - id: a\n    title: x: y\n"), Options{})
+	if len(problems) != 1 || problems[0].Line != 3 || strings.Contains(problems[0].Message, "line") {
+		t.Fatalf("expected one problem on line 3, got %v", problems)
+	}
+	if got := Validate(nil, Options{}); len(got) != 0 {
+		t.Fatalf("empty file: %v", got)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	l := models.TaskList{Tasks: []models.Task{{ID: "1", Status: "todo", Priority: "highest"}, {ID: "2", Status: "In-Progress", Priority: "low"}}}
+	migrated, err := Migrate(&l)
+	if err != nil || !migrated || l.Version != Version {
+		t.Fatalf("migrate: %v %v %d", migrated, err, l.Version)
+	}
+	if l.Tasks[0].Status != "to-do" || l.Tasks[1].Status != "in-progress" {
+		t.Fatalf("statuses not canonical: %+v", l.Tasks)
+	}
+	if l.Tasks[0].Priority != "high" || l.Tasks[1].Priority != "low" {
+		t.Fatalf("legacy priority not mapped: %+v", l.Tasks)
+	}
+	if migrated, _ := Migrate(&l); migrated {
+		t.Fatal("current version should not migrate again")
+	}
+	if _, err := Migrate(&models.TaskList{Version: Version + 1}); err == nil {
+		t.Fatal("expected error for a newer schema")
+	}
+}
+
+func TestRepair(t *testing.T) {
+	l := models.TaskList{Tasks: []models.Task{
+		{ID: "a", DueDate: "2025/10/05", DependsOn: []string{"a", "b", "ghost", "old"}},
+		{ID: "b", DueDate: "someday", Notes: "n", Estimate: "soon", CreatedAt: "yesterday"},
+		{ID: "a", Title: "dup"},
+		{ID: " "},
+	}}
+	fixes := Repair(&l, map[string]bool{"old": true})
+	if len(fixes) != 8 {
+		t.Fatalf("expected 8 fixes, got %d: %v", len(fixes), fixes)
+	}
+	if err := CheckIDs(l.Tasks); err != nil {
+		t.Fatalf("ids still invalid: %v", err)
+	}
+	if l.Tasks[0].ID != "a" || l.Tasks[2].ID == "a" {
+		t.Fatalf("first task should keep the duplicated id: %+v", l.Tasks)
+	}
+	if l.Tasks[0].DueDate != "2025-10-05" || strings.Join(l.Tasks[0].DependsOn, ",") != "b,old" {
+		t.Fatalf("task a not repaired: %+v", l.Tasks[0])
+	}
+	b := l.Tasks[1]
+	if b.DueDate != "" || !strings.Contains(b.Notes, "someday") || b.Estimate != "" || b.CreatedAt != "" {
+		t.Fatalf("task b not repaired: %+v", b)
+	}
+	if again := Repair(&l, map[string]bool{"old": true}); len(again) != 0 {
+		t.Fatalf("repair should be idempotent: %v", again)
+	}
+}
+
+func TestCheckTask(t *testing.T) {
+	legacy := models.Task{ID: "1", DueDate: "someday"}
+	if err := CheckTask(&legacy, models.Task{ID: "1", DueDate: "someday", Title: "edit"}); err != nil {
+		t.Fatalf("unchanged legacy due should pass: %v", err)
+	}
+	if err := CheckTask(&legacy, models.Task{ID: "1", DueDate: "tomorrow"}); err == nil {
+		t.Fatal("expected error for a new free-text due date")
+	}
+	if err := CheckTask(nil, models.Task{ID: "2", Estimate: "2 hours"}); err == nil {
+		t.Fatal("expected error for an invalid estimate")
+	}
+	if err := CheckIDs([]models.Task{{ID: "1"}, {ID: "1"}}); err == nil {
+		t.Fatal("expected duplicate id error")
+	}
+}
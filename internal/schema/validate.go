@@ -0,0 +1,276 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is one issue found in a tasks file. Line and Column are 1-based
+// and point at the offending value (0 when unknown).
+type Problem struct {
+	Line    int
+	Column  int
+	TaskID  string
+	Field   string
+	Message string
+	Fixable bool // Repair can fix it
+}
+
+func (p Problem) String() string {
+	var b strings.Builder
+	if p.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", p.Line, p.Column)
+	}
+	if p.TaskID != "" {
+		fmt.Fprintf(&b, "task %s: ", p.TaskID)
+	}
+	if p.Field != "" {
+		b.WriteString(p.Field + ": ")
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// Options tune validation.
+type Options struct {
+	// Workflow checks statuses and priorities; nil uses workflow.Current().
+	Workflow *workflow.Workflow
+	// KnownIDs are IDs that exist outside the file being checked (e.g. the
+	// archive) and may be referenced by depends_on.
+	KnownIDs map[string]bool
+}
+
+// Field kinds of a task mapping, used for type checks.
+const (
+	kindScalar = iota
+	kindScalarList
+	kindMappingList
+)
+
+var taskFields = map[string]int{
+	"id": kindScalar, "title": kindScalar, "description": kindScalar, "due": kindScalar,
+	"estimate": kindScalar, "status": kindScalar, "priority": kindScalar, "source": kindScalar,
+	"link": kindScalar, "notes": kindScalar, "updated_at": kindScalar, "created_at": kindScalar,
+	"started_at": kindScalar, "completed_at": kindScalar, "archived_at": kindScalar,
+	"tags": kindScalarList, "depends_on": kindScalarList,
+	"history": kindMappingList, "worklog": kindMappingList,
+}
+
+// timestampFields are stamped by taskflow and must be RFC3339.
+var timestampFields = []string{"created_at", "updated_at", "started_at", "completed_at", "archived_at"}
+
+var lineRe = regexp.MustCompile(`^line (\d+)`)
+
+// Validate checks the raw YAML of a tasks (or archive) file. Unknown keys are
+// allowed; everything taskflow reads is checked for type and value.
+func Validate(data []byte, opts Options) []Problem {
+	wf := opts.Workflow
+	if wf == nil {
+		wf = workflow.Current()
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		p := Problem{Message: msg}
+		if m := lineRe.FindStringSubmatch(msg); m != nil {
+			p.Line, _ = strconv.Atoi(m[1])
+			p.Column = 1
+			p.Message = strings.TrimPrefix(msg[len(m[0]):], ": ")
+		}
+		return []Problem{p}
+	}
+	if len(doc.Content) == 0 {
+		return nil // empty file
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []Problem{at(root, "", "", "top level must be a mapping with a tasks key", false)}
+	}
+
+	var problems []Problem
+	var list *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "version":
+			v, err := strconv.Atoi(val.Value)
+			switch {
+			case val.Kind != yaml.ScalarNode || err != nil || v < 1:
+				problems = append(problems, at(val, "", "version", fmt.Sprintf("invalid schema version %q", val.Value), false))
+			case v > Version:
+				problems = append(problems, at(val, "", "version", fmt.Sprintf("schema version %d is newer than this taskflow supports (%d)", v, Version), false))
+			case v < Version:
+				problems = append(problems, at(val, "", "version", fmt.Sprintf("schema version %d will be migrated to %d", v, Version), true))
+			}
+		case "tasks":
+			list = val
+		}
+	}
+	if list == nil || (list.Kind == yaml.ScalarNode && list.Tag == "!!null") {
+		return problems
+	}
+	if list.Kind != yaml.SequenceNode {
+		return append(problems, at(list, "", "tasks", "must be a list of tasks", false))
+	}
+
+	// First pass: collect IDs so references can be checked in any order.
+	ids := map[string]int{}
+	for _, item := range list.Content {
+		if id := scalar(item, "id"); id != "" {
+			ids[id]++
+		}
+	}
+	seen := map[string]bool{}
+	for n, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			problems = append(problems, at(item, "", "", fmt.Sprintf("task #%d is not a mapping", n+1), false))
+			continue
+		}
+		problems = append(problems, validateTask(item, n, ids, seen, wf, opts.KnownIDs)...)
+	}
+	return problems
+}
+
+func validateTask(item *yaml.Node, n int, ids map[string]int, seen map[string]bool, wf *workflow.Workflow, known map[string]bool) []Problem {
+	var problems []Problem
+	id := scalar(item, "id")
+	label := id
+	if label == "" {
+		label = fmt.Sprintf("#%d", n+1)
+	}
+	add := func(node *yaml.Node, field, msg string, fixable bool) {
+		problems = append(problems, at(node, label, field, msg, fixable))
+	}
+
+	fields := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key, val := item.Content[i], item.Content[i+1]
+		fields[key.Value] = val
+		kind, ok := taskFields[key.Value]
+		if !ok || val.Tag == "!!null" {
+			continue
+		}
+		switch {
+		case kind == kindScalar && val.Kind != yaml.ScalarNode:
+			add(val, key.Value, "must be a single value", false)
+		case kind != kindScalar && val.Kind != yaml.SequenceNode:
+			add(val, key.Value, "must be a list", false)
+		case kind == kindScalarList:
+			for _, el := range val.Content {
+				if el.Kind != yaml.ScalarNode {
+					add(el, key.Value, "list entries must be single values", false)
+				}
+			}
+		case kind == kindMappingList:
+			for _, el := range val.Content {
+				if el.Kind != yaml.MappingNode {
+					add(el, key.Value, "list entries must be mappings", false)
+				}
+			}
+		}
+	}
+
+	switch {
+	case id == "":
+		add(nodeOr(fields["id"], item), "id", "missing id", true)
+	case ids[id] > 1 && seen[id]:
+		add(fields["id"], "id", "duplicate id", true)
+	}
+	seen[id] = true
+
+	if v := fields["title"]; v == nil || strings.TrimSpace(v.Value) == "" {
+		add(nodeOr(v, item), "title", "missing title", false)
+	}
+	if v := fields["status"]; v != nil && v.Kind == yaml.ScalarNode && strings.TrimSpace(v.Value) != "" {
+		if err := wf.CheckStatus(v.Value); err != nil {
+			add(v, "status", err.Error(), false)
+		}
+	}
+	if v := fields["priority"]; v != nil && v.Kind == yaml.ScalarNode && strings.TrimSpace(v.Value) != "" {
+		if err := wf.CheckPriority(v.Value); err != nil {
+			add(v, "priority", err.Error(), false)
+		}
+	}
+	if v := fields["due"]; v != nil && v.Kind == yaml.ScalarNode && !tasks.ValidDue(v.Value) {
+		add(v, "due", fmt.Sprintf("invalid date %q (want YYYY-MM-DD or RFC3339)", v.Value), true)
+	}
+	if v := fields["estimate"]; v != nil && v.Kind == yaml.ScalarNode && v.Value != "" {
+		if _, err := time.ParseDuration(v.Value); err != nil {
+			add(v, "estimate", fmt.Sprintf("invalid duration %q (e.g. 1h30m)", v.Value), true)
+		}
+	}
+	for _, f := range timestampFields {
+		if v := fields[f]; v != nil && v.Kind == yaml.ScalarNode && !validStamp(v.Value) {
+			add(v, f, fmt.Sprintf("invalid timestamp %q (want RFC3339)", v.Value), true)
+		}
+	}
+	if v := fields["depends_on"]; v != nil && v.Kind == yaml.SequenceNode {
+		for _, el := range v.Content {
+			switch {
+			case el.Value == id:
+				add(el, "depends_on", "task depends on itself", true)
+			case ids[el.Value] == 0 && !known[el.Value]:
+				add(el, "depends_on", fmt.Sprintf("unknown task %q", el.Value), true)
+			}
+		}
+	}
+	if v := fields["worklog"]; v != nil && v.Kind == yaml.SequenceNode {
+		for _, el := range v.Content {
+			start, end := child(el, "start"), child(el, "end")
+			if start == nil || start.Value == "" || !validStamp(start.Value) {
+				add(nodeOr(start, el), "worklog", "entry needs an RFC3339 start", false)
+			}
+			if end != nil && !validStamp(end.Value) {
+				add(end, "worklog", fmt.Sprintf("invalid end %q (want RFC3339)", end.Value), false)
+			}
+		}
+	}
+	return problems
+}
+
+func at(node *yaml.Node, taskID, field, msg string, fixable bool) Problem {
+	return Problem{Line: node.Line, Column: node.Column, TaskID: taskID, Field: field, Message: msg, Fixable: fixable}
+}
+
+// child returns the value node of key in a mapping node, or nil.
+func child(m *yaml.Node, key string) *yaml.Node {
+	if m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalar returns the value of key in a mapping node, or "".
+func scalar(m *yaml.Node, key string) string {
+	if v := child(m, key); v != nil && v.Kind == yaml.ScalarNode {
+		return strings.TrimSpace(v.Value)
+	}
+	return ""
+}
+
+func nodeOr(n, fallback *yaml.Node) *yaml.Node {
+	if n != nil {
+		return n
+	}
+	return fallback
+}
+
+func validStamp(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
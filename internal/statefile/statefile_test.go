@@ -0,0 +1,44 @@
+package statefile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "state.json")
+	got := map[string]int{"kept": 1}
+	if err := Load(path, &got); err != nil || got["kept"] != 1 {
+		t.Fatalf("missing file: %v, %v", got, err)
+	}
+	if err := Save(path, map[string]int{"a": 1, "b": 2}); err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	if err := Load(path, &got); err != nil || !reflect.DeepEqual(got, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("state = %v, %v", got, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("leftover temporary files: %v", entries)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Load(path, &got); err == nil {
+		t.Fatal("corrupt file loaded")
+	}
+}
+
+func TestWriteFileKeepsMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	if err := WriteFile(path, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("mode = %v, %v", info.Mode(), err)
+	}
+}
@@ -0,0 +1,36 @@
+package stats
+
+import "strings"
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled to the maximum.
+func Sparkline(values []int) string {
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if max == 0 || v <= 0 {
+			b.WriteRune(sparkTicks[0])
+			continue
+		}
+		b.WriteRune(sparkTicks[(v*(len(sparkTicks)-1)+max-1)/max])
+	}
+	return b.String()
+}
+
+// Bar renders a horizontal bar of at most width cells for value relative to max.
+func Bar(value, max, width int) string {
+	if max <= 0 || value <= 0 || width <= 0 {
+		return ""
+	}
+	n := value * width / max
+	if n == 0 {
+		n = 1
+	}
+	return strings.Repeat("█", n)
+}
@@ -0,0 +1,207 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// Options bounds the report window. Zero Since/Until leave that side open.
+type Options struct {
+	Since time.Time
+	Until time.Time
+	Now   time.Time
+	Weeks int // throughput weeks shown when Since is zero (default 8)
+}
+
+// Count is a labelled counter used for breakdowns.
+type Count struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// WeekCount is the number of tasks completed in the week starting at Week (Monday).
+type WeekCount struct {
+	Week  string `json:"week"`
+	Count int    `json:"count"`
+}
+
+// Report summarises active and archived tasks.
+type Report struct {
+	Total           int           `json:"total"`
+	Completed       int           `json:"completed"`
+	Pending         int           `json:"pending"`
+	Archived        int           `json:"archived"`
+	Overdue         int           `json:"overdue"`
+	ByStatus        []Count       `json:"by_status"`
+	ByPriority      []Count       `json:"by_priority"`
+	ByTag           []Count       `json:"by_tag"`
+	Throughput      []WeekCount   `json:"throughput"`
+	LeadTimeSamples int           `json:"lead_time_samples"`
+	AvgLeadTime     time.Duration `json:"-"`
+	AvgLeadHours    float64       `json:"avg_lead_time_hours"`
+}
+
+// Compute builds a report from active tasks and tasks read from the archive.
+//
+// A task is in scope when it existed during the window: created before Until
+// (tasks without CreatedAt are assumed to be old) and not completed before
+// Since. Throughput and lead time only count completions inside the window.
+func Compute(active, archived []models.Task, opts Options) Report {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	until := opts.Until
+	if until.IsZero() {
+		until = opts.Now
+	}
+
+	var r Report
+	status := map[string]int{}
+	priority := map[string]int{}
+	tags := map[string]int{}
+	weekly := map[string]int{}
+	var leadTotal time.Duration
+
+	all := make([]models.Task, 0, len(active)+len(archived))
+	all = append(all, active...)
+	all = append(all, archived...)
+	for i, t := range all {
+		created, hasCreated := parse(t.CreatedAt)
+		completed, hasCompleted := parse(t.CompletedAt)
+		done := workflow.Current().IsTerminal(t.Status)
+		if hasCreated && !created.Before(until) {
+			continue
+		}
+		if done && hasCompleted && !opts.Since.IsZero() && completed.Before(opts.Since) {
+			continue
+		}
+
+		r.Total++
+		if i >= len(active) {
+			r.Archived++
+		}
+		if done {
+			r.Completed++
+		} else {
+			r.Pending++
+			if due, ok := tasks.ParseDue(t.DueDate, opts.Now.Location()); ok && due.Before(opts.Now) {
+				r.Overdue++
+			}
+		}
+		status[label(t.Status, "(none)")]++
+		priority[label(t.Priority, "(none)")]++
+		for _, tag := range t.Tags {
+			tags[tag]++
+		}
+
+		if done && hasCompleted && completed.Before(until) {
+			weekly[weekStart(completed).Format("2006-01-02")]++
+			if hasCreated && !completed.Before(created) {
+				leadTotal += completed.Sub(created)
+				r.LeadTimeSamples++
+			}
+		}
+	}
+
+	r.ByStatus = sorted(status, statusOrder())
+	r.ByPriority = sorted(priority, workflow.Current().PriorityNames())
+	r.ByTag = sorted(tags, nil)
+	if r.LeadTimeSamples > 0 {
+		r.AvgLeadTime = leadTotal / time.Duration(r.LeadTimeSamples)
+		r.AvgLeadHours = r.AvgLeadTime.Hours()
+	}
+
+	// Throughput buckets run from Since (or the last N weeks) to Until, including empty weeks.
+	start := opts.Since
+	if start.IsZero() {
+		weeks := opts.Weeks
+		if weeks <= 0 {
+			weeks = 8
+		}
+		start = weekStart(until).AddDate(0, 0, -7*(weeks-1))
+	}
+	for w := weekStart(start); w.Before(until); w = w.AddDate(0, 0, 7) {
+		key := w.Format("2006-01-02")
+		r.Throughput = append(r.Throughput, WeekCount{Week: key, Count: weekly[key]})
+	}
+	return r
+}
+
+// statusOrder lists the workflow's statuses, each followed by its aliases.
+func statusOrder() []string {
+	var order []string
+	for _, st := range workflow.Current().Statuses {
+		order = append(order, st.Name)
+		order = append(order, st.Aliases...)
+	}
+	return order
+}
+
+// sorted orders counters by a preferred label order, then by count and label.
+func sorted(m map[string]int, order []string) []Count {
+	rank := map[string]int{}
+	for i, l := range order {
+		rank[l] = i + 1
+	}
+	out := make([]Count, 0, len(m))
+	for l, c := range m {
+		out = append(out, Count{Label: l, Count: c})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ri, rj := rank[out[i].Label], rank[out[j].Label]
+		if (ri > 0) != (rj > 0) {
+			return ri > 0
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out
+}
+
+func label(s, fallback string) string {
+	if strings.TrimSpace(s) == "" {
+		return fallback
+	}
+	return s
+}
+
+// weekStart returns midnight UTC of the Monday on or before t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func parse(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// ParseBound parses a --since value (YYYY-MM-DD or RFC3339).
+func ParseBound(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// ParseUntil parses an inclusive --until value: a date means up to the end of
+// that day, so the returned bound is the following midnight.
+func ParseUntil(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t.AddDate(0, 0, 1), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
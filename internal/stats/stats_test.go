@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+// Wednesday 2025-10-15 12:00 UTC
+var now = time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func ts(days int) string { return now.AddDate(0, 0, days).Format(time.RFC3339) }
+
+func TestCompute_BreakdownsAndOverdue(t *testing.T) {
+	active := []models.Task{
+		{ID: "1", Status: "to-do", Priority: "high", Tags: []string{"ops", "web"}, DueDate: ts(-1)},
+		{ID: "2", Status: "in-progress", Priority: "high", Tags: []string{"web"}, DueDate: ts(2)},
+		{ID: "3", Status: "done", Priority: "low"},
+	}
+	archived := []models.Task{{ID: "4", Status: "done"}}
+	r := Compute(active, archived, Options{Now: now})
+	if r.Total != 4 || r.Completed != 2 || r.Pending != 2 || r.Archived != 1 || r.Overdue != 1 {
+		t.Fatalf("unexpected totals: %+v", r)
+	}
+	if r.ByStatus[0].Label != "to-do" || r.ByStatus[len(r.ByStatus)-1].Label != "done" || r.ByStatus[len(r.ByStatus)-1].Count != 2 {
+		t.Fatalf("status breakdown not in workflow order: %+v", r.ByStatus)
+	}
+	if r.ByPriority[0] != (Count{Label: "high", Count: 2}) || r.ByPriority[2].Label != "(none)" {
+		t.Fatalf("unexpected priority breakdown: %+v", r.ByPriority)
+	}
+	if r.ByTag[0] != (Count{Label: "web", Count: 2}) || r.ByTag[1] != (Count{Label: "ops", Count: 1}) {
+		t.Fatalf("unexpected tag breakdown: %+v", r.ByTag)
+	}
+}
+
+func TestCompute_ThroughputLeadTimeAndWindow(t *testing.T) {
+	active := []models.Task{
+		{ID: "1", Status: "done", CreatedAt: ts(-4), CompletedAt: ts(-2)},   // this week (Mon 13th)
+		{ID: "2", Status: "done", CreatedAt: ts(-10), CompletedAt: ts(-8)},  // previous week
+		{ID: "3", Status: "done", CreatedAt: ts(-40), CompletedAt: ts(-30)}, // before --since
+		{ID: "4", Status: "to-do", CreatedAt: ts(1)},                        // after --until
+	}
+	since := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	r := Compute(active, nil, Options{Now: now, Since: since})
+	if r.Total != 2 || r.Completed != 2 {
+		t.Fatalf("window should keep only tasks 1 and 2: %+v", r)
+	}
+	if r.LeadTimeSamples != 2 || r.AvgLeadTime != 48*time.Hour || r.AvgLeadHours != 48 {
+		t.Fatalf("unexpected lead time: %v over %d", r.AvgLeadTime, r.LeadTimeSamples)
+	}
+	want := []WeekCount{{"2025-09-29", 0}, {"2025-10-06", 1}, {"2025-10-13", 1}}
+	if len(r.Throughput) != len(want) {
+		t.Fatalf("unexpected throughput buckets: %+v", r.Throughput)
+	}
+	for i := range want {
+		if r.Throughput[i] != want[i] {
+			t.Fatalf("bucket %d: want %+v got %+v", i, want[i], r.Throughput[i])
+		}
+	}
+}
+
+func TestCompute_DefaultWeeks(t *testing.T) {
+	r := Compute(nil, nil, Options{Now: now, Weeks: 3})
+	if len(r.Throughput) != 3 || r.Throughput[2].Week != "2025-10-13" {
+		t.Fatalf("expected 3 weeks ending this week: %+v", r.Throughput)
+	}
+}
+
+func TestSparklineAndBar(t *testing.T) {
+	if got := Sparkline([]int{0, 1, 4, 8}); got != "▁▂▅█" {
+		t.Fatalf("sparkline: %q", got)
+	}
+	if got := Bar(5, 10, 10); got != "█████" {
+		t.Fatalf("bar: %q", got)
+	}
+	if got := Bar(1, 100, 10); got != "█" {
+		t.Fatalf("small values should still show a cell: %q", got)
+	}
+	if Bar(0, 10, 10) != "" {
+		t.Fatalf("zero should render empty")
+	}
+}
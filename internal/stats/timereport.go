@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"time"
+)
+
+// TimeOptions selects and groups worklog entries for TimeReport.
+type TimeOptions struct {
+	By       string // "task" (default), "tag" or "day"
+	Since    time.Time
+	Until    time.Time
+	Now      time.Time      // end of running entries
+	Location *time.Location // day boundaries for By == "day" (default time.Local)
+}
+
+// TimeTotal is the time logged against one group.
+type TimeTotal struct {
+	Key      string        `json:"key"` // task ID, tag or YYYY-MM-DD
+	Label    string        `json:"label"`
+	Duration time.Duration `json:"-"`
+	Entries  int           `json:"entries"`
+}
+
+// TimeReport totals worklog entries that start inside the window. With By ==
+// "tag" an entry counts once for every tag of its task, so tag totals can add
+// up to more than the time logged.
+func TimeReport(all []models.Task, opts TimeOptions) ([]TimeTotal, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	by := opts.By
+	if by == "" {
+		by = "task"
+	}
+	if by != "task" && by != "tag" && by != "day" {
+		return nil, fmt.Errorf("unknown grouping %q (want task, tag or day)", opts.By)
+	}
+
+	totals := map[string]*TimeTotal{}
+	add := func(key, label string, d time.Duration) {
+		tt, ok := totals[key]
+		if !ok {
+			tt = &TimeTotal{Key: key, Label: label}
+			totals[key] = tt
+		}
+		tt.Duration += d
+		tt.Entries++
+	}
+	for _, t := range all {
+		for _, e := range t.Worklog {
+			start, ok := parse(e.Start)
+			if !ok {
+				continue
+			}
+			if !opts.Since.IsZero() && start.Before(opts.Since) {
+				continue
+			}
+			if !opts.Until.IsZero() && !start.Before(opts.Until) {
+				continue
+			}
+			d := tasks.EntryDuration(e, opts.Now)
+			switch by {
+			case "task":
+				add(t.ID, t.Title, d)
+			case "day":
+				day := start.In(opts.Location).Format("2006-01-02")
+				add(day, day, d)
+			case "tag":
+				if len(t.Tags) == 0 {
+					add("", "(untagged)", d)
+				}
+				for _, tag := range t.Tags {
+					add(tag, tag, d)
+				}
+			}
+		}
+	}
+
+	out := make([]TimeTotal, 0, len(totals))
+	for _, tt := range totals {
+		out = append(out, *tt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if by == "day" {
+			return out[i].Key < out[j].Key
+		}
+		if out[i].Duration != out[j].Duration {
+			return out[i].Duration > out[j].Duration
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+func entry(startDays, hours int) models.WorkEntry {
+	start := now.AddDate(0, 0, startDays)
+	return models.WorkEntry{Start: start.Format(time.RFC3339), End: start.Add(time.Duration(hours) * time.Hour).Format(time.RFC3339)}
+}
+
+func TestTimeReport_Groupings(t *testing.T) {
+	all := []models.Task{
+		{ID: "1", Title: "A", Tags: []string{"client", "web"}, Worklog: []models.WorkEntry{entry(-1, 2), entry(0, 1)}},
+		{ID: "2", Title: "B", Worklog: []models.WorkEntry{entry(-1, 3), entry(-20, 5)}},
+	}
+	since := now.AddDate(0, 0, -7)
+
+	byTask, err := TimeReport(all, TimeOptions{Since: since, Now: now})
+	if err != nil || len(byTask) != 2 {
+		t.Fatalf("by task: %+v %v", byTask, err)
+	}
+	if byTask[0].Key != "1" || byTask[0].Duration != 3*time.Hour || byTask[0].Entries != 2 || byTask[1].Duration != 3*time.Hour {
+		t.Fatalf("unexpected task totals: %+v", byTask)
+	}
+
+	byTag, _ := TimeReport(all, TimeOptions{By: "tag", Since: since, Now: now})
+	if len(byTag) != 3 || byTag[0].Label != "(untagged)" || byTag[1].Label != "client" || byTag[2].Duration != 3*time.Hour {
+		t.Fatalf("unexpected tag totals: %+v", byTag)
+	}
+
+	byDay, _ := TimeReport(all, TimeOptions{By: "day", Since: since, Now: now, Location: time.UTC})
+	if len(byDay) != 2 || byDay[0] != (TimeTotal{Key: "2025-10-14", Label: "2025-10-14", Duration: 5 * time.Hour, Entries: 2}) {
+		t.Fatalf("unexpected day totals: %+v", byDay)
+	}
+
+	if _, err := TimeReport(all, TimeOptions{By: "week"}); err == nil {
+		t.Fatalf("expected error for unknown grouping")
+	}
+}
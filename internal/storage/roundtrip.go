@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"taskflow/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultIndent matches what yaml.Marshal has always produced.
+const defaultIndent = 4
+
+// encode renders list as YAML merged into the document previously on disk
+// (prev, possibly empty). Comments, unknown keys, key order, the formatting
+// of unchanged values, the order of existing tasks and the file's
+// indentation survive; new tasks are appended and removed ones dropped.
+func encode(list models.TaskList, prev []byte) ([]byte, error) {
+	var fresh yaml.Node
+	if err := fresh.Encode(list); err != nil {
+		return nil, err
+	}
+	out := &fresh
+	var doc yaml.Node
+	if yaml.Unmarshal(prev, &doc) == nil && len(doc.Content) == 1 && doc.Content[0].Kind == yaml.MappingNode {
+		mergeMapping(doc.Content[0], &fresh, false, mergeTop)
+		out = &doc
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(detectIndent(prev))
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func mergeTop(key string, old, upd *yaml.Node) *yaml.Node {
+	if key == "tasks" {
+		return mergeTasks(old, upd)
+	}
+	return mergeValue(key, old, upd)
+}
+
+// mergeTasks matches tasks by ID, keeping the old node (and its comments) of
+// every task that is still present, in the old order.
+func mergeTasks(old, upd *yaml.Node) *yaml.Node {
+	if old.Kind != yaml.SequenceNode || old.Style&yaml.FlowStyle != 0 || upd.Kind != yaml.SequenceNode {
+		return keepComments(old, upd)
+	}
+	pending := map[string][]*yaml.Node{}
+	for _, item := range upd.Content {
+		id := idOf(item)
+		pending[id] = append(pending[id], item)
+	}
+	used := map[*yaml.Node]bool{}
+	var content []*yaml.Node
+	for _, item := range old.Content {
+		id := idOf(item)
+		queue := pending[id]
+		if item.Kind != yaml.MappingNode || len(queue) == 0 {
+			continue // deleted (or archived) task
+		}
+		pending[id] = queue[1:]
+		used[queue[0]] = true
+		mergeMapping(item, queue[0], true, mergeValue)
+		content = append(content, item)
+	}
+	for _, item := range upd.Content {
+		if !used[item] {
+			content = append(content, item)
+		}
+	}
+	old.Content = content
+	return old
+}
+
+// mergeMapping updates old in place to hold the keys of upd. Existing keys
+// keep their position and comments; new keys are inserted after the closest
+// key that precedes them in upd, except empty strings. A key inserted first
+// takes over the head comment, so a file's header stays on top. Keys missing
+// from upd are removed when prune is set.
+func mergeMapping(old, upd *yaml.Node, prune bool, merge func(key string, old, upd *yaml.Node) *yaml.Node) {
+	want := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(upd.Content); i += 2 {
+		want[upd.Content[i].Value] = upd.Content[i+1]
+	}
+	var content []*yaml.Node
+	have := map[string]bool{}
+	for i := 0; i+1 < len(old.Content); i += 2 {
+		k, v := old.Content[i], old.Content[i+1]
+		nv, ok := want[k.Value]
+		if !ok {
+			if !prune {
+				content = append(content, k, v)
+			}
+			continue
+		}
+		have[k.Value] = true
+		content = append(content, k, merge(k.Value, v, nv))
+	}
+	for i := 0; i+1 < len(upd.Content); i += 2 {
+		k, v := upd.Content[i], upd.Content[i+1]
+		if have[k.Value] || (v.Kind == yaml.ScalarNode && v.Tag == "!!str" && v.Value == "") {
+			continue // an absent key already reads as ""
+		}
+		pos := 0
+		for j := i - 2; j >= 0; j -= 2 {
+			if p := keyIndex(content, upd.Content[j].Value); p >= 0 {
+				pos = p + 2
+				break
+			}
+		}
+		if pos == 0 && len(content) > 0 {
+			k.HeadComment, content[0].HeadComment = content[0].HeadComment, ""
+		}
+		content = append(content[:pos], append([]*yaml.Node{k, v}, content[pos:]...)...)
+		have[k.Value] = true
+	}
+	old.Content = content
+}
+
+// mergeValue keeps old when the value is unchanged, merges nested mappings
+// and otherwise takes upd with old's comments.
+func mergeValue(_ string, old, upd *yaml.Node) *yaml.Node {
+	switch {
+	case sameNode(old, upd):
+		return old
+	case old.Kind == yaml.MappingNode && upd.Kind == yaml.MappingNode:
+		mergeMapping(old, upd, true, mergeValue)
+		return old
+	}
+	return keepComments(old, upd)
+}
+
+func keepComments(old, upd *yaml.Node) *yaml.Node {
+	if upd.HeadComment == "" {
+		upd.HeadComment = old.HeadComment
+	}
+	if upd.LineComment == "" {
+		upd.LineComment = old.LineComment
+	}
+	if upd.FootComment == "" {
+		upd.FootComment = old.FootComment
+	}
+	return upd
+}
+
+// sameNode reports whether a and b hold the same data. Scalars compare by
+// value only, so `id: 1` and `id: "1"` are the same to a string field.
+func sameNode(a, b *yaml.Node) bool {
+	if a.Kind == yaml.AliasNode {
+		a = a.Alias
+	}
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.ScalarNode {
+		return a.Value == b.Value
+	}
+	for i := range a.Content {
+		if !sameNode(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func keyIndex(content []*yaml.Node, key string) int {
+	for i := 0; i+1 < len(content); i += 2 {
+		if content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func idOf(item *yaml.Node) string {
+	if item.Kind != yaml.MappingNode {
+		return ""
+	}
+	if i := keyIndex(item.Content, "id"); i >= 0 {
+		return item.Content[i+1].Value
+	}
+	return ""
+}
+
+// detectIndent returns the indentation of the first indented line in prev,
+// so hand-formatted files are not reindented on every write.
+func detectIndent(prev []byte) int {
+	sc := bufio.NewScanner(bytes.NewReader(prev))
+	for sc.Scan() {
+		line := sc.Text()
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n > 0 {
+			if n < 2 || n > 8 {
+				break
+			}
+			return n
+		}
+	}
+	return defaultIndent
+}
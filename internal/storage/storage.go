@@ -1,10 +1,15 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"taskflow/internal/models"
+	"taskflow/internal/schema"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,6 +17,24 @@ import (
 // Storage handles reading from and writing to the YAML file.
 type Storage struct {
 	filePath string
+	opts     Options
+}
+
+// Options are what a Storage does around writes besides validating and
+// persisting them. The zero value does nothing more; commands get theirs
+// from config (see internal/taskstore).
+type Options struct {
+	// History controls the per-task change history kept by WriteTasks.
+	History tasks.TrackOptions
+	// BeforeWrite runs once a write passed validation, with the stored
+	// tasks by ID. It may amend all or veto the write by failing.
+	BeforeWrite func(prev map[string]*models.Task, all []models.Task) error
+	// BeforeArchive runs for each task Archive is about to append. It may
+	// amend the task or veto the archiving by failing.
+	BeforeArchive func(t *models.Task) error
+	// AfterWrite runs once a write is saved, e.g. to notify others. Its
+	// error is reported, but the write stands.
+	AfterWrite func(prev map[string]*models.Task, all []models.Task, now time.Time) error
 }
 
 // NewStorage creates a new Storage instance.
@@ -19,6 +42,15 @@ func NewStorage(filePath string) (*Storage, error) {
 	return &Storage{filePath: filePath}, nil
 }
 
+// WithOptions sets the storage's options and returns it.
+func (s *Storage) WithOptions(opts Options) *Storage {
+	s.opts = opts
+	return s
+}
+
+// Options returns the storage's options, e.g. to give the archive the same.
+func (s *Storage) Options() Options { return s.opts }
+
 // ReadTasks reads all tasks from the YAML file.
 func (s *Storage) ReadTasks() ([]models.Task, error) {
 	data, err := os.ReadFile(s.filePath)
@@ -29,15 +61,16 @@ func (s *Storage) ReadTasks() ([]models.Task, error) {
 		return nil, fmt.Errorf("failed to read tasks file: %w", err)
 	}
 
-	var taskList models.TaskList
-	if err := yaml.Unmarshal(data, &taskList); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	taskList, err := decode(data)
+	if err != nil {
+		return nil, err
 	}
 
 	// Populate the internal fields of Task
+	wf := workflow.Current()
 	for i := range taskList.Tasks {
-		taskList.Tasks[i].Completed = taskList.Tasks[i].Status == "done"
-		taskList.Tasks[i].PriorityInt = convertPriority(taskList.Tasks[i].Priority)
+		taskList.Tasks[i].Completed = wf.IsTerminal(taskList.Tasks[i].Status)
+		taskList.Tasks[i].PriorityInt = wf.PriorityWeight(taskList.Tasks[i].Priority)
 		if taskList.Tasks[i].Description == "" {
 			taskList.Tasks[i].Description = taskList.Tasks[i].Link
 		}
@@ -46,41 +79,173 @@ func (s *Storage) ReadTasks() ([]models.Task, error) {
 	return taskList.Tasks, nil
 }
 
-func convertPriority(priority string) int {
-	switch priority {
-	case "high":
-		return 3
-	case "medium":
-		return 2
-	case "low":
-		return 1
-	default:
-		return 0
+// WriteTasks writes all tasks to the YAML file. Lifecycle timestamps (and the
+// per-task history when Options.History asks for it) are maintained by
+// comparing each task with the version currently on disk. IDs must be
+// unique, and changed statuses, priorities, due dates and estimates are
+// checked before and after Options.BeforeWrite runs; nothing is written if a
+// check fails or BeforeWrite vetoes. The file is stamped with the current
+// schema version and merged into its existing contents, so comments, custom
+// keys and the order of tasks survive. Options.AfterWrite runs last.
+func (s *Storage) WriteTasks(all []models.Task) error {
+	prev, err := s.ReadStored()
+	if err != nil && !errors.Is(err, errUnparsable) {
+		return err
 	}
+	byID := make(map[string]*models.Task, len(prev))
+	for i := range prev {
+		byID[prev[i].ID] = &prev[i]
+	}
+	if err := check(byID, all); err != nil {
+		return err
+	}
+	if s.opts.BeforeWrite != nil {
+		if err := s.opts.BeforeWrite(byID, all); err != nil {
+			return err
+		}
+		// BeforeWrite may have amended tasks.
+		if err := check(byID, all); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	for i := range all {
+		tasks.TrackChanges(byID[all[i].ID], &all[i], now, s.opts.History)
+	}
+
+	// ReadTasks shows the link in an empty description; do not store that copy.
+	stored := make([]models.Task, len(all))
+	copy(stored, all)
+	for i := range stored {
+		if p := byID[stored[i].ID]; p != nil && p.Description == "" && stored[i].Description == stored[i].Link {
+			stored[i].Description = ""
+		}
+	}
+	if err := s.save(models.TaskList{Version: schema.Version, Tasks: stored}); err != nil {
+		return err
+	}
+	if s.opts.AfterWrite != nil {
+		if err := s.opts.AfterWrite(byID, all, now); err != nil {
+			return fmt.Errorf("tasks saved, but %w", err)
+		}
+	}
+	return nil
 }
 
-// WriteTasks writes all tasks to the YAML file.
-func (s *Storage) WriteTasks(tasks []models.Task) error {
-	// Sort tasks by ID for consistent ordering
-	sort.SliceStable(tasks, func(i, j int) bool {
-		return tasks[i].ID < tasks[j].ID
-	})
+// check validates a write of all over the stored tasks (by ID).
+func check(byID map[string]*models.Task, all []models.Task) error {
+	if err := schema.CheckIDs(all); err != nil {
+		return err
+	}
+	wf := workflow.Current()
+	for i := range all {
+		if err := wf.CheckTask(byID[all[i].ID], all[i]); err != nil {
+			return err
+		}
+		if err := schema.CheckTask(byID[all[i].ID], all[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errUnparsable = errors.New("tasks file cannot be parsed")
 
-	taskList := models.TaskList{
-		Tasks: tasks,
+// ReadStored returns the tasks currently on disk without normalisation (no
+// description filled in from the link, no derived fields), or nothing when
+// the file does not exist.
+func (s *Storage) ReadStored() ([]models.Task, error) {
+	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	taskList, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnparsable, err)
 	}
+	return taskList.Tasks, nil
+}
+
+// decode parses a tasks file and migrates it to the current schema version.
+func decode(data []byte) (models.TaskList, error) {
+	var taskList models.TaskList
+	if err := yaml.Unmarshal(data, &taskList); err != nil {
+		return taskList, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	}
+	if _, err := schema.Migrate(&taskList); err != nil {
+		return taskList, err
+	}
+	return taskList, nil
+}
 
-	data, err := yaml.Marshal(taskList)
+// Rewrite replaces the tasks in the file with ts as-is: no lifecycle
+// tracking or workflow checks. It is meant for repairs (see `taskflow doctor`).
+func (s *Storage) Rewrite(ts []models.Task) error {
+	return s.save(models.TaskList{Version: schema.Version, Tasks: ts})
+}
+
+// save writes list merged into the file's current contents (see encode), so
+// hand edits such as comments and custom keys survive.
+func (s *Storage) save(list models.TaskList) error {
+	prev, err := os.ReadFile(s.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	data, err := encode(list, prev)
 	if err != nil {
 		return fmt.Errorf("failed to marshal tasks: %w", err)
 	}
-
 	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write tasks file: %w", err)
 	}
 	return nil
 }
 
+// Archive appends tasks to this storage's file (used with the archive path),
+// stamping ArchivedAt and closing running timers. Existing entries keep their
+// order, and tasks whose ID is already archived are skipped, so archiving
+// again after an undo does not duplicate them. Options.BeforeArchive runs
+// first; nothing is written if it vetoes.
+func (s *Storage) Archive(archived []models.Task) error {
+	existing, err := s.ReadStored()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		seen[t.ID] = true
+	}
+	stamp := time.Now().UTC().Format(time.RFC3339)
+	history := s.opts.History.History
+	for _, t := range archived {
+		if seen[t.ID] {
+			continue
+		}
+		seen[t.ID] = true
+		t.ArchivedAt = stamp
+		// Worklogs travel with the task; a timer left running stops here.
+		t.Worklog = append([]models.WorkEntry(nil), t.Worklog...)
+		for i := range t.Worklog {
+			if t.Worklog[i].End == "" {
+				t.Worklog[i].End = stamp
+			}
+		}
+		if history {
+			t.History = append(t.History, models.Change{At: stamp, Field: "archived"})
+		}
+		if s.opts.BeforeArchive != nil {
+			if err := s.opts.BeforeArchive(&t); err != nil {
+				return err
+			}
+		}
+		existing = append(existing, t)
+	}
+	return s.save(models.TaskList{Version: schema.Version, Tasks: existing})
+}
+
 // UpdateTask updates a single task in the YAML file.
 func (s *Storage) UpdateTask(tasks []models.Task, updatedTask models.Task) error {
 	for i, task := range tasks {
@@ -32,11 +32,12 @@ func TestTasksYAMLFormat(t *testing.T) {
 	if !regexp.MustCompile(`(?m)^tasks:`).MatchString(content) {
 		t.Fatalf("missing top-level tasks key: %s", content)
 	}
-	// IDs should be sorted (1 before 2) because WriteTasks sorts by ID
+	// Tasks keep the order they were written in (2 before 1); WriteTasks no
+	// longer sorts by ID
 	firstIdx := regexp.MustCompile(`id: "1"`).FindStringIndex(content)
 	secondIdx := regexp.MustCompile(`id: "2"`).FindStringIndex(content)
-	if firstIdx == nil || secondIdx == nil || firstIdx[0] > secondIdx[0] {
-		t.Fatalf("expected id 1 before id 2; content=%s", content)
+	if firstIdx == nil || secondIdx == nil || secondIdx[0] > firstIdx[0] {
+		t.Fatalf("expected id 2 before id 1; content=%s", content)
 	}
 	// Ensure omitted internal fields are not serialized
 	if regexp.MustCompile(`Completed:`).MatchString(content) {
@@ -46,7 +47,7 @@ func TestTasksYAMLFormat(t *testing.T) {
 		t.Fatalf("internal field PriorityInt serialized: %s", content)
 	}
 	// Ensure optional empty fields are omitted: we did not set Description for task 2
-	block2 := content[secondIdx[0]:]
+	block2 := content[secondIdx[0]:firstIdx[0]]
 	if regexp.MustCompile(`description:`).MatchString(block2) {
 		t.Fatalf("unexpected empty description field present in second task block: %s", block2)
 	}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"testing"
+	"time"
+)
+
+func TestWriteTasks_StampsLifecycleAndHistory(t *testing.T) {
+	st, _ := NewStorage(filepath.Join(t.TempDir(), "tasks.yaml"))
+	st.WithOptions(Options{History: tasks.TrackOptions{History: true}})
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "A", Status: "to-do"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	got, _ := st.ReadTasks()
+	if got[0].CreatedAt == "" || len(got[0].History) != 1 {
+		t.Fatalf("new task not stamped: %+v", got[0])
+	}
+
+	// Rebuild the task without timestamps, as an importer might.
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "A", Status: "done"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	again, _ := st.ReadTasks()
+	if again[0].CreatedAt != got[0].CreatedAt || again[0].CompletedAt == "" {
+		t.Fatalf("lifecycle not maintained: %+v", again[0])
+	}
+	if len(again[0].History) != 2 || again[0].History[1].Field != "status" {
+		t.Fatalf("status change not recorded: %+v", again[0].History)
+	}
+}
+
+func TestArchive_AppendsAndStamps(t *testing.T) {
+	st, _ := NewStorage(filepath.Join(t.TempDir(), "archive.yaml"))
+	running := []models.WorkEntry{{Start: "2025-10-01T09:00:00Z"}}
+	if err := st.Archive([]models.Task{{ID: "1", Status: "done", Worklog: running}}); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if err := st.Archive([]models.Task{{ID: "0", Status: "done"}}); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	got, _ := st.ReadTasks()
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "0" {
+		t.Fatalf("archive should append in order: %+v", got)
+	}
+	if err := st.Archive([]models.Task{{ID: "1", Status: "done"}}); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if again, _ := st.ReadTasks(); len(again) != 2 {
+		t.Fatalf("archiving an archived ID again should be skipped: %+v", again)
+	}
+	if got[0].ArchivedAt == "" {
+		t.Fatalf("ArchivedAt not set: %+v", got[0])
+	}
+	if len(got[0].Worklog) != 1 || got[0].Worklog[0].End != got[0].ArchivedAt || running[0].End != "" {
+		t.Fatalf("worklog should be kept and its timer closed: %+v", got[0].Worklog)
+	}
+}
+
+func TestWriteTasks_EnforcesWorkflow(t *testing.T) {
+	workflow.Use(&workflow.Workflow{
+		Statuses:    []workflow.Status{{Name: "open"}, {Name: "closed", Terminal: true}},
+		Priorities:  []workflow.Priority{{Name: "p1"}},
+		Transitions: map[string][]string{"closed": {}},
+		Enforce:     true,
+	})
+	defer workflow.Use(nil)
+
+	st, _ := NewStorage(filepath.Join(t.TempDir(), "tasks.yaml"))
+	if err := st.WriteTasks([]models.Task{{ID: "1", Status: "open", Priority: "p1"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := st.WriteTasks([]models.Task{{ID: "1", Status: "closed", Priority: "p1"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	got, _ := st.ReadTasks()
+	if !got[0].Completed || got[0].CompletedAt == "" || got[0].PriorityInt != 1 {
+		t.Fatalf("terminal status not applied: %+v", got[0])
+	}
+	if err := st.WriteTasks([]models.Task{{ID: "1", Status: "open", Priority: "p1"}}); err == nil {
+		t.Fatal("expected disallowed transition to fail")
+	}
+	if err := st.WriteTasks([]models.Task{got[0], {ID: "2", Status: "todo"}}); err == nil {
+		t.Fatal("expected unknown status to fail")
+	}
+	again, _ := st.ReadTasks()
+	if len(again) != 1 || again[0].Status != "closed" {
+		t.Fatalf("rejected write changed the file: %+v", again)
+	}
+}
+
+func TestWriteTasks_OptionsVetoAmendAndNotify(t *testing.T) {
+	var notified []string
+	opts := Options{
+		BeforeWrite: func(prev map[string]*models.Task, all []models.Task) error {
+			for i := range all {
+				if prev[all[i].ID] != nil && workflow.Current().IsTerminal(all[i].Status) {
+					return errors.New("tests are red")
+				}
+				all[i].Tags = []string{"seen"}
+			}
+			return nil
+		},
+		BeforeArchive: func(*models.Task) error { return errors.New("still referenced") },
+		AfterWrite: func(prev map[string]*models.Task, all []models.Task, now time.Time) error {
+			notified = append(notified, fmt.Sprintf("%d>%d", len(prev), len(all)))
+			return nil
+		},
+	}
+	st, _ := NewStorage(filepath.Join(t.TempDir(), "tasks.yaml"))
+	st.WithOptions(opts)
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship", Status: "to-do"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship", Status: "done"}})
+	if err == nil || !strings.Contains(err.Error(), "tests are red") {
+		t.Fatalf("expected veto, got %v", err)
+	}
+	got, _ := st.ReadTasks()
+	if got[0].Status != "to-do" || len(got[0].Tags) != 1 || strings.Join(notified, " ") != "0>1" {
+		t.Fatalf("tasks %+v, notified %v", got, notified)
+	}
+
+	archive, _ := NewStorage(filepath.Join(t.TempDir(), "archive.yaml"))
+	if err := archive.WithOptions(st.Options()).Archive(got); err == nil {
+		t.Fatal("expected archive veto")
+	}
+	if rest, _ := archive.ReadTasks(); len(rest) != 0 {
+		t.Fatalf("vetoed archive wrote tasks: %+v", rest)
+	}
+
+	// Without options a storage only persists.
+	plain, _ := NewStorage(filepath.Join(t.TempDir(), "tasks.yaml"))
+	if err := plain.WriteTasks([]models.Task{{ID: "1", Title: "Ship", Status: "done"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
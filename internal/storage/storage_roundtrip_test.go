@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+)
+
+const handEdited = `# My tasks
+version: 2
+owner: me # custom top-level key
+tasks:
+  # urgent stuff first
+  - id: b
+    title: Second
+    status: to-do
+    tags: [ops, api]
+    estimate_points: 3 # custom field
+    updated_at: "2025-10-01T00:00:00Z"
+  - id: a
+    title: First
+    status: to-do
+    updated_at: "2025-10-01T00:00:00Z"
+`
+
+func TestWriteTasks_PreservesHandEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	if err := os.WriteFile(path, []byte(handEdited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	st, _ := NewStorage(path)
+	ts, err := st.ReadTasks()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if ts[0].Extra["estimate_points"] != 3 {
+		t.Fatalf("custom field not kept in Extra: %#v", ts[0].Extra)
+	}
+	ts[1].Title = "First, renamed"
+	ts = append(ts, models.Task{ID: "c", Title: "Third", Status: "to-do"})
+	if err := st.WriteTasks(ts); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{
+		"# My tasks\n",
+		"owner: me # custom top-level key\n",
+		"  # urgent stuff first\n  - id: b\n",
+		"    tags: [ops, api]\n",
+		"    estimate_points: 3 # custom field\n",
+		"    title: First, renamed\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("missing %q in:\n%s", want, got)
+		}
+	}
+	if b, a, c := strings.Index(got, "id: b"), strings.Index(got, "id: a"), strings.Index(got, "id: c"); !(b < a && a < c) {
+		t.Fatalf("task order not kept (b, a, then new c):\n%s", got)
+	}
+	// Untouched tasks are not rewritten at all.
+	if !strings.Contains(got, "  - id: b\n    title: Second\n    status: to-do\n    tags:") {
+		t.Fatalf("untouched task changed:\n%s", got)
+	}
+}
+
+func TestWriteTasks_KeepsHeaderAndLinkOnlyTasks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	legacy := "# my tasks\ntasks:\n  - id: a\n    title: Read\n    status: to-do\n    link: https://example.com/post\n"
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	st, _ := NewStorage(path)
+	ts, _ := st.ReadTasks()
+	if ts[0].Description != ts[0].Link {
+		t.Fatalf("description should show the link: %+v", ts[0])
+	}
+	ts[0].Status = "in-progress"
+	if err := st.WriteTasks(ts); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	if !strings.HasPrefix(got, "# my tasks\nversion: 2\ntasks:\n") {
+		t.Fatalf("header not kept on top:\n%s", got)
+	}
+	if strings.Contains(got, "description:") {
+		t.Fatalf("link written as description:\n%s", got)
+	}
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/schema"
+	"testing"
+)
+
+func TestReadTasks_MigratesAndWriteStampsVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	legacy := "tasks:\n  - id: \"1\"\n    title: Old\n    status: todo\n    due: someday\n"
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	st, _ := NewStorage(path)
+	ts, err := st.ReadTasks()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if ts[0].Status != "to-do" {
+		t.Fatalf("expected migrated status, got %q", ts[0].Status)
+	}
+	// Legacy free-text due dates do not block unrelated edits.
+	ts[0].Title = "Renamed"
+	if err := st.WriteTasks(ts); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), fmt.Sprintf("version: %d\n", schema.Version)) {
+		t.Fatalf("expected version stamp, got:\n%s", data)
+	}
+
+	if err := os.WriteFile(path, []byte("version: 99\ntasks: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.ReadTasks(); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Fatalf("expected error for newer schema, got %v", err)
+	}
+}
+
+func TestWriteTasks_RejectsDuplicateIDsAndBadDates(t *testing.T) {
+	st, _ := NewStorage(filepath.Join(t.TempDir(), "tasks.yaml"))
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "a"}, {ID: "1", Title: "b"}}); err == nil {
+		t.Fatal("expected duplicate id error")
+	}
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "a", DueDate: "next friday"}}); err == nil {
+		t.Fatal("expected invalid due date error")
+	}
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "a", DueDate: "2025-10-05"}}); err != nil {
+		t.Fatalf("valid write failed: %v", err)
+	}
+}
@@ -35,13 +35,13 @@ func TestWriteAndReadTasks_RoundTrip(t *testing.T) {
 	if err != nil {
 		t.Fatalf("read error: %v", err)
 	}
-	// Should be sorted by ID ascending: 1,2
-	if len(out) != 2 || out[0].ID != "1" || out[1].ID != "2" {
+	// Order is kept as written: 2,1
+	if len(out) != 2 || out[0].ID != "2" || out[1].ID != "1" {
 		t.Fatalf("unexpected ordering: %#v", out)
 	}
 	// Completed and PriorityInt computed
-	if !out[0].Completed || out[0].PriorityInt != 3 { // high => 3
-		t.Fatalf("expected task 1 computed fields, got %#v", out[0])
+	if !out[1].Completed || out[1].PriorityInt != 3 { // high => 3
+		t.Fatalf("expected task 1 computed fields, got %#v", out[1])
 	}
 }
 
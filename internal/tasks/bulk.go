@@ -0,0 +1,134 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// BulkAction identifies an operation applied to several tasks at once.
+type BulkAction string
+
+const (
+	BulkSetStatus   BulkAction = "Set status"
+	BulkSetPriority BulkAction = "Set priority"
+	BulkAddTags     BulkAction = "Add tags"
+	BulkRemoveTags  BulkAction = "Remove tags"
+	BulkSetDue      BulkAction = "Set due date"
+	BulkArchive     BulkAction = "Archive"
+	BulkDelete      BulkAction = "Delete"
+)
+
+// BulkActions lists the bulk actions in menu order.
+var BulkActions = []BulkAction{BulkSetStatus, BulkSetPriority, BulkAddTags, BulkRemoveTags, BulkSetDue, BulkArchive, BulkDelete}
+
+// ApplyBulk applies action with value to every task in all whose ID is in ids.
+// It returns the tasks that remain in the active list and, for archive and
+// delete, the tasks taken out of it. The input slice is not modified.
+//
+// Tag values are comma separated. A due date is YYYY-MM-DD or RFC3339; an
+// empty value clears it.
+func ApplyBulk(all []models.Task, ids map[string]bool, action BulkAction, value string, now time.Time) (kept, removed []models.Task, err error) {
+	value = strings.TrimSpace(value)
+	switch action {
+	case BulkSetStatus:
+		if err := workflow.Current().CheckStatus(value); err != nil {
+			return nil, nil, err
+		}
+		value = workflow.Current().Canonical(value)
+	case BulkSetPriority:
+		if err := workflow.Current().CheckPriority(value); err != nil {
+			return nil, nil, err
+		}
+	case BulkAddTags, BulkRemoveTags:
+		if len(splitList(value)) == 0 {
+			return nil, nil, fmt.Errorf("no tags given")
+		}
+	case BulkSetDue:
+		if value != "" {
+			if _, ok := ParseDue(value, time.UTC); !ok {
+				return nil, nil, fmt.Errorf("invalid due date %q (want YYYY-MM-DD or RFC3339)", value)
+			}
+		}
+	case BulkArchive, BulkDelete:
+	default:
+		return nil, nil, fmt.Errorf("unknown bulk action %q", action)
+	}
+
+	stamp := now.UTC().Format(time.RFC3339)
+	kept = make([]models.Task, 0, len(all))
+	for _, t := range all {
+		if !ids[t.ID] {
+			kept = append(kept, t)
+			continue
+		}
+		switch action {
+		case BulkArchive, BulkDelete:
+			removed = append(removed, t)
+			continue
+		case BulkSetStatus:
+			SetStatus(&t, value, now)
+		case BulkSetPriority:
+			t.Priority = value
+			t.UpdatedAt = stamp
+		case BulkAddTags:
+			t.Tags = addTags(t.Tags, splitList(value))
+			t.UpdatedAt = stamp
+		case BulkRemoveTags:
+			t.Tags = removeTags(t.Tags, splitList(value))
+			t.UpdatedAt = stamp
+		case BulkSetDue:
+			t.DueDate = value
+			t.UpdatedAt = stamp
+		}
+		kept = append(kept, t)
+	}
+	return kept, removed, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// addTags appends tags not already present (case-insensitively), keeping order.
+func addTags(tags, add []string) []string {
+	out := append([]string(nil), tags...)
+	for _, a := range add {
+		found := false
+		for _, t := range out {
+			if strings.EqualFold(t, a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func removeTags(tags, remove []string) []string {
+	var out []string
+	for _, t := range tags {
+		drop := false
+		for _, r := range remove {
+			if strings.EqualFold(t, r) {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			out = append(out, t)
+		}
+	}
+	return out
+}
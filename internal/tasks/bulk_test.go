@@ -0,0 +1,65 @@
+package tasks
+
+import (
+	"taskflow/internal/models"
+	"testing"
+)
+
+func bulkFixture() []models.Task {
+	return []models.Task{
+		{ID: "1", Title: "A", Status: "to-do", Tags: []string{"web"}},
+		{ID: "2", Title: "B", Status: "to-do", Tags: []string{"Web", "ops"}},
+		{ID: "3", Title: "C", Status: "to-do"},
+	}
+}
+
+func TestApplyBulk_UpdatesOnlyTargets(t *testing.T) {
+	all := bulkFixture()
+	ids := map[string]bool{"1": true, "2": true}
+
+	kept, removed, err := ApplyBulk(all, ids, BulkSetStatus, "done", t0)
+	if err != nil || len(kept) != 3 || removed != nil {
+		t.Fatalf("set status: %v %d %v", err, len(kept), removed)
+	}
+	if kept[0].Status != "done" || kept[0].CompletedAt == "" || kept[2].Status != "to-do" {
+		t.Fatalf("status not applied to targets only: %+v", kept)
+	}
+	if all[0].Status != "to-do" {
+		t.Fatalf("input slice was modified")
+	}
+
+	kept, _, _ = ApplyBulk(all, ids, BulkAddTags, "web, sprint-9", t0)
+	if got := kept[1].Tags; len(got) != 3 || got[2] != "sprint-9" {
+		t.Fatalf("add tags should skip duplicates case-insensitively: %v", got)
+	}
+	kept, _, _ = ApplyBulk(all, ids, BulkRemoveTags, "WEB", t0)
+	if len(kept[0].Tags) != 0 || len(kept[1].Tags) != 1 || kept[1].Tags[0] != "ops" {
+		t.Fatalf("remove tags: %+v", kept)
+	}
+	kept, _, _ = ApplyBulk(all, ids, BulkSetDue, "2025-11-01", t0)
+	if kept[0].DueDate != "2025-11-01" || kept[2].DueDate != "" {
+		t.Fatalf("set due: %+v", kept)
+	}
+}
+
+func TestApplyBulk_RemovesAndValidates(t *testing.T) {
+	all := bulkFixture()
+	kept, removed, err := ApplyBulk(all, map[string]bool{"2": true, "3": true}, BulkArchive, "", t0)
+	if err != nil || len(kept) != 1 || len(removed) != 2 || removed[0].ID != "2" {
+		t.Fatalf("archive: %v kept=%+v removed=%+v", err, kept, removed)
+	}
+
+	for _, c := range []struct {
+		action BulkAction
+		value  string
+	}{
+		{BulkSetStatus, "finished"},
+		{BulkSetPriority, "urgent"},
+		{BulkAddTags, " , "},
+		{BulkSetDue, "next week"},
+	} {
+		if _, _, err := ApplyBulk(all, map[string]bool{"1": true}, c.action, c.value, t0); err == nil {
+			t.Fatalf("%s %q should be rejected", c.action, c.value)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package tasks
 import (
 	"strings"
 	"taskflow/internal/models"
+	"taskflow/internal/workflow"
 )
 
 type FilterOptions struct {
@@ -22,8 +23,8 @@ func ApplyFilters(all []models.Task, opts FilterOptions) []models.Task {
 
 TaskLoop:
 	for _, t := range all {
-		// Status
-		if opts.Status != "" && t.Status != opts.Status {
+		// Status (aliases such as the legacy "todo" match their status)
+		if opts.Status != "" && !workflow.Current().SameStatus(t.Status, opts.Status) {
 			continue
 		}
 		// Priority
@@ -0,0 +1,174 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// SetStatus changes a task's status and keeps its timestamps in step:
+// UpdatedAt is always refreshed, StartedAt is set the first time work starts
+// (an active status in the workflow), CompletedAt is set when the task reaches
+// a terminal status and cleared when reopened.
+func SetStatus(t *models.Task, status string, now time.Time) {
+	wf := workflow.Current()
+	stamp := now.UTC().Format(time.RFC3339)
+	if wf.IsActive(status) && t.StartedAt == "" {
+		t.StartedAt = stamp
+	}
+	if wf.IsTerminal(status) && !wf.IsTerminal(t.Status) {
+		t.CompletedAt = stamp
+	} else if !wf.IsTerminal(status) {
+		t.CompletedAt = ""
+	}
+	t.Status = status
+	t.UpdatedAt = stamp
+}
+
+// TrackOptions controls TrackChanges.
+type TrackOptions struct {
+	History      bool // append field changes to Task.History
+	HistoryLimit int  // keep at most this many history entries (0 = unlimited)
+}
+
+// TrackChanges maintains lifecycle timestamps on next, comparing it with the
+// previously stored version prev (nil for a new task). It fills CreatedAt for
+// new tasks, StartedAt/CompletedAt on status transitions, refreshes UpdatedAt
+// when a field changed and the caller did not already do so, and optionally
+// records each change in the task history. It reports whether anything changed.
+func TrackChanges(prev, next *models.Task, now time.Time, opts TrackOptions) bool {
+	wf := workflow.Current()
+	stamp := now.UTC().Format(time.RFC3339)
+
+	if prev == nil {
+		if next.CreatedAt == "" {
+			next.CreatedAt = stamp
+		}
+		if next.UpdatedAt == "" {
+			next.UpdatedAt = next.CreatedAt
+		}
+		if wf.IsActive(next.Status) && next.StartedAt == "" {
+			next.StartedAt = stamp
+		}
+		if opts.History && len(next.History) == 0 {
+			next.History = append(next.History, models.Change{At: stamp, Field: "created", To: next.Title})
+		}
+		return true
+	}
+
+	// Timestamps owned by the lifecycle survive callers that rebuilt the task from scratch.
+	if next.CreatedAt == "" {
+		next.CreatedAt = prev.CreatedAt
+	}
+	if next.StartedAt == "" {
+		next.StartedAt = prev.StartedAt
+	}
+	if next.ArchivedAt == "" {
+		next.ArchivedAt = prev.ArchivedAt
+	}
+	if len(next.History) < len(prev.History) {
+		next.History = prev.History
+	}
+
+	changes := diffTask(prev, next)
+	if len(changes) == 0 {
+		return false
+	}
+
+	if next.Status != prev.Status {
+		if wf.IsActive(next.Status) && next.StartedAt == "" {
+			next.StartedAt = stamp
+		}
+		if wf.IsTerminal(next.Status) && (next.CompletedAt == "" || next.CompletedAt == prev.CompletedAt) {
+			next.CompletedAt = stamp
+		}
+		if wf.IsTerminal(prev.Status) && !wf.IsTerminal(next.Status) {
+			next.CompletedAt = ""
+		}
+	}
+	if next.UpdatedAt == prev.UpdatedAt || next.UpdatedAt == "" {
+		next.UpdatedAt = stamp
+	}
+
+	if opts.History {
+		for _, c := range changes {
+			c.At = stamp
+			next.History = append(next.History, c)
+		}
+		if opts.HistoryLimit > 0 && len(next.History) > opts.HistoryLimit {
+			next.History = next.History[len(next.History)-opts.HistoryLimit:]
+		}
+	}
+	return true
+}
+
+// Changed reports whether next differs from prev in a field users edit
+// (timestamps, history and worklog are not compared).
+func Changed(prev, next *models.Task) bool {
+	return len(diffTask(prev, next)) > 0
+}
+
+// diffTask lists user-visible field changes between two versions of a task.
+func diffTask(a, b *models.Task) []models.Change {
+	var out []models.Change
+	add := func(field, from, to string) {
+		if from != to {
+			out = append(out, models.Change{Field: field, From: from, To: to})
+		}
+	}
+	add("title", a.Title, b.Title)
+	// ReadTasks fills an empty description from the link; that is not an edit.
+	if !(a.Description == "" && b.Description == b.Link) {
+		add("description", a.Description, b.Description)
+	}
+	add("status", a.Status, b.Status)
+	add("priority", a.Priority, b.Priority)
+	add("due", a.DueDate, b.DueDate)
+	add("estimate", a.Estimate, b.Estimate)
+	add("source", a.Source, b.Source)
+	add("link", a.Link, b.Link)
+	add("tags", strings.Join(a.Tags, ", "), strings.Join(b.Tags, ", "))
+	add("depends_on", strings.Join(a.DependsOn, ", "), strings.Join(b.DependsOn, ", "))
+	add("notes", a.Notes, b.Notes)
+	return out
+}
+
+// FindByID returns the index of the task whose ID equals ref or, failing
+// that, the only task whose ID starts with ref.
+func FindByID(all []models.Task, ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, fmt.Errorf("empty task id")
+	}
+	for i, t := range all {
+		if t.ID == ref {
+			return i, nil
+		}
+	}
+	match := -1
+	for i, t := range all {
+		if strings.HasPrefix(t.ID, ref) {
+			if match >= 0 {
+				return -1, fmt.Errorf("task id %q is ambiguous", ref)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("no task with id %q", ref)
+	}
+	return match, nil
+}
+
+// FormatChange renders a history entry as a single line.
+func FormatChange(c models.Change) string {
+	switch c.Field {
+	case "created":
+		return fmt.Sprintf("%s created", c.At)
+	case "archived":
+		return fmt.Sprintf("%s archived", c.At)
+	}
+	return fmt.Sprintf("%s %s: %q → %q", c.At, c.Field, c.From, c.To)
+}
@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func TestTrackChanges_NewTask(t *testing.T) {
+	next := models.Task{ID: "1", Title: "New", Status: "in-progress"}
+	TrackChanges(nil, &next, t0, TrackOptions{History: true})
+	stamp := t0.Format(time.RFC3339)
+	if next.CreatedAt != stamp || next.UpdatedAt != stamp || next.StartedAt != stamp {
+		t.Fatalf("timestamps not stamped: %+v", next)
+	}
+	if len(next.History) != 1 || next.History[0].Field != "created" {
+		t.Fatalf("expected created history entry: %+v", next.History)
+	}
+}
+
+func TestTrackChanges_TransitionsAndHistory(t *testing.T) {
+	prev := models.Task{ID: "1", Title: "A", Status: "to-do", CreatedAt: "2025-01-01T00:00:00Z", UpdatedAt: "2025-01-01T00:00:00Z"}
+
+	// A caller that rebuilt the task loses CreatedAt; it must be preserved.
+	next := models.Task{ID: "1", Title: "A", Status: "done", UpdatedAt: prev.UpdatedAt}
+	if !TrackChanges(&prev, &next, t0, TrackOptions{History: true, HistoryLimit: 2}) {
+		t.Fatalf("expected a change")
+	}
+	stamp := t0.Format(time.RFC3339)
+	if next.CreatedAt != prev.CreatedAt || next.CompletedAt != stamp || next.UpdatedAt != stamp {
+		t.Fatalf("unexpected timestamps: %+v", next)
+	}
+	if len(next.History) != 1 || next.History[0] != (models.Change{At: stamp, Field: "status", From: "to-do", To: "done"}) {
+		t.Fatalf("unexpected history: %+v", next.History)
+	}
+
+	// Reopening clears CompletedAt; the history limit keeps the newest entries.
+	done := next
+	reopened := done
+	reopened.Status = "in-progress"
+	reopened.Title = "B"
+	TrackChanges(&done, &reopened, t0.Add(time.Hour), TrackOptions{History: true, HistoryLimit: 2})
+	if reopened.CompletedAt != "" || reopened.StartedAt == "" {
+		t.Fatalf("reopen should clear CompletedAt and set StartedAt: %+v", reopened)
+	}
+	if len(reopened.History) != 2 || reopened.History[0].Field != "title" || reopened.History[1].Field != "status" {
+		t.Fatalf("history limit not applied: %+v", reopened.History)
+	}
+}
+
+func TestTrackChanges_NoChange(t *testing.T) {
+	prev := models.Task{ID: "1", Title: "A", Link: "http://x", UpdatedAt: "2025-01-01T00:00:00Z"}
+	next := prev
+	next.Description = next.Link // filled in by ReadTasks
+	if TrackChanges(&prev, &next, t0, TrackOptions{History: true}) {
+		t.Fatalf("unchanged task reported as changed: %+v", next)
+	}
+	if next.UpdatedAt != prev.UpdatedAt || len(next.History) != 0 {
+		t.Fatalf("unchanged task should not be touched: %+v", next)
+	}
+}
+
+func TestFindByID(t *testing.T) {
+	all := []models.Task{{ID: "abc123"}, {ID: "abd456"}, {ID: "ab"}}
+	if i, err := FindByID(all, "ab"); err != nil || i != 2 {
+		t.Fatalf("exact match should win: %d %v", i, err)
+	}
+	if i, err := FindByID(all, "abd"); err != nil || i != 1 {
+		t.Fatalf("unique prefix: %d %v", i, err)
+	}
+	if _, err := FindByID(all, "abx"); err == nil {
+		t.Fatalf("expected not found")
+	}
+	all = all[:2]
+	if _, err := FindByID(all, "ab"); err == nil {
+		t.Fatalf("expected ambiguous prefix error")
+	}
+}
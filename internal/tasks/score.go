@@ -0,0 +1,206 @@
+package tasks
+
+import (
+	"math"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// ScoreWeights scales each scoring component. Components are normalised to
+// roughly 0..1 before weighting, so a weight is the most a component can add.
+type ScoreWeights struct {
+	Due          float64
+	Age          float64
+	Dependencies float64
+	Calendar     float64
+	Tags         map[string]float64 // per-tag bonus (or penalty), keys lowercased
+}
+
+// ScoreThresholds maps a total score onto the high/medium/low scale.
+type ScoreThresholds struct {
+	High   float64
+	Medium float64
+}
+
+// dueHalfLife is how far out a due date halves its urgency.
+const dueHalfLife = 48 * time.Hour
+
+// ageHorizon is the age at which the age component saturates.
+const ageHorizon = 30 * 24 * time.Hour
+
+// calendarWindow bounds how far ahead calendar load is considered.
+const calendarWindow = 7 * 24 * time.Hour
+
+// ScoreBreakdown holds the weighted contribution of each component.
+type ScoreBreakdown struct {
+	Due          float64
+	Age          float64
+	Tags         float64
+	Dependencies float64
+	Calendar     float64
+}
+
+// Total returns the sum of all components.
+func (b ScoreBreakdown) Total() float64 {
+	return b.Due + b.Age + b.Tags + b.Dependencies + b.Calendar
+}
+
+// ScoreTasks scores every open task in all. Done tasks are not scored.
+func ScoreTasks(all []models.Task, events []models.CalendarEvent, w ScoreWeights, now time.Time) map[string]ScoreBreakdown {
+	open := map[string]bool{}
+	blocks := map[string]int{} // task ID -> number of open tasks depending on it
+	for _, t := range all {
+		if !workflow.Current().IsTerminal(t.Status) {
+			open[t.ID] = true
+		}
+	}
+	for _, t := range all {
+		if !open[t.ID] {
+			continue
+		}
+		for _, dep := range t.DependsOn {
+			blocks[dep]++
+		}
+	}
+
+	out := make(map[string]ScoreBreakdown, len(open))
+	for _, t := range all {
+		if !open[t.ID] {
+			continue
+		}
+		var b ScoreBreakdown
+		due, hasDue := ParseDue(t.DueDate, now.Location())
+		if hasDue {
+			b.Due = w.Due * dueUrgency(due, now)
+		}
+		if age, ok := taskAge(t, now); ok {
+			b.Age = w.Age * math.Min(float64(age)/float64(ageHorizon), 1)
+		}
+		for _, tag := range t.Tags {
+			b.Tags += w.Tags[strings.ToLower(tag)]
+		}
+		dep := math.Min(float64(blocks[t.ID]), 3) / 3
+		for _, id := range t.DependsOn {
+			if open[id] {
+				dep -= 1 // blocked: nothing to gain by bumping it yet
+				break
+			}
+		}
+		b.Dependencies = w.Dependencies * dep
+		b.Calendar = w.Calendar * calendarPressure(t, due, hasDue, events, now)
+		out[t.ID] = b
+	}
+	return out
+}
+
+// PriorityForScore maps a total score onto the high/medium/low scale. A
+// workflow without those names gets its most urgent, middle and least
+// urgent priorities instead.
+func PriorityForScore(score float64, th ScoreThresholds) string {
+	wf := workflow.Current()
+	names := wf.PriorityNames()
+	pick := func(name, fallback string) string {
+		if _, ok := wf.Priority(name); ok {
+			return name
+		}
+		return fallback
+	}
+	switch {
+	case score >= th.High:
+		return pick("high", names[0])
+	case score >= th.Medium:
+		return pick("medium", wf.DefaultPriority())
+	default:
+		return pick("low", names[len(names)-1])
+	}
+}
+
+// dueUrgency is 1 for due or overdue tasks and halves every dueHalfLife.
+func dueUrgency(due, now time.Time) float64 {
+	left := due.Sub(now)
+	if left <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(left)/float64(dueHalfLife))
+}
+
+// calendarPressure is 1 when an event with the task's title starts within a
+// day, otherwise the share of time until the due date already booked by events.
+func calendarPressure(t models.Task, due time.Time, hasDue bool, events []models.CalendarEvent, now time.Time) float64 {
+	in24Hours := now.Add(24 * time.Hour)
+	title := strings.ToLower(strings.TrimSpace(t.Title))
+	for _, e := range events {
+		if strings.ToLower(strings.TrimSpace(e.Title)) != title {
+			continue
+		}
+		if start, err := time.Parse(time.RFC3339, e.StartTime); err == nil && start.After(now) && start.Before(in24Hours) {
+			return 1
+		}
+	}
+	if !hasDue || !due.After(now) {
+		return 0
+	}
+	end := due
+	if end.Sub(now) > calendarWindow {
+		end = now.Add(calendarWindow)
+	}
+	var booked time.Duration
+	for _, e := range events {
+		start, err1 := time.Parse(time.RFC3339, e.StartTime)
+		stop, err2 := time.Parse(time.RFC3339, e.EndTime)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if start.Before(now) {
+			start = now
+		}
+		if stop.After(end) {
+			stop = end
+		}
+		if stop.After(start) {
+			booked += stop.Sub(start)
+		}
+	}
+	return math.Min(float64(booked)/float64(end.Sub(now)), 1)
+}
+
+// taskAge returns how long ago the task was created. Tasks from before
+// creation times were recorded fall back to their last update.
+func taskAge(t models.Task, now time.Time) (time.Duration, bool) {
+	since := t.CreatedAt
+	if since == "" {
+		since = t.UpdatedAt
+	}
+	if since == "" {
+		return 0, false
+	}
+	at, err := time.Parse(time.RFC3339, since)
+	if err != nil || at.After(now) {
+		return 0, false
+	}
+	return now.Sub(at), true
+}
+
+// ValidDue reports whether s is a due date taskflow understands: empty,
+// RFC3339 or YYYY-MM-DD.
+func ValidDue(s string) bool {
+	_, ok := ParseDue(s, time.UTC)
+	return ok || s == ""
+}
+
+// ParseDue accepts RFC3339 timestamps and plain dates, which are due at the
+// end of that day in loc (usually the location of the caller's now).
+func ParseDue(s string, loc *time.Location) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,87 @@
+package tasks
+
+import (
+	"math"
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var scoreNow = time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC)
+
+func rfc(t time.Time) string { return t.Format(time.RFC3339) }
+
+func TestScoreTasks_DueProximity(t *testing.T) {
+	all := []models.Task{
+		{ID: "overdue", Status: "to-do", DueDate: rfc(scoreNow.Add(-time.Hour))},
+		{ID: "soon", Status: "to-do", DueDate: rfc(scoreNow.Add(48 * time.Hour))},
+		{ID: "none", Status: "to-do"},
+		{ID: "closed", Status: "done", DueDate: rfc(scoreNow)},
+	}
+	s := ScoreTasks(all, nil, ScoreWeights{Due: 4}, scoreNow)
+	if _, ok := s["closed"]; ok {
+		t.Fatalf("done tasks must not be scored")
+	}
+	if s["overdue"].Due != 4 {
+		t.Fatalf("overdue task should get full due weight, got %v", s["overdue"].Due)
+	}
+	if math.Abs(s["soon"].Due-2) > 1e-9 {
+		t.Fatalf("task due in one half-life should get half weight, got %v", s["soon"].Due)
+	}
+	if s["none"].Total() != 0 {
+		t.Fatalf("task without signals should score 0, got %+v", s["none"])
+	}
+}
+
+func TestScoreTasks_TagsAgeDependencies(t *testing.T) {
+	all := []models.Task{
+		{ID: "base", Status: "to-do", Tags: []string{"Urgent"}, UpdatedAt: rfc(scoreNow.Add(-60 * 24 * time.Hour))},
+		{ID: "child1", Status: "to-do", DependsOn: []string{"base"}},
+		{ID: "child2", Status: "to-do", DependsOn: []string{"base"}},
+		{ID: "child3", Status: "to-do", DependsOn: []string{"base"}},
+		{ID: "free", Status: "to-do", DependsOn: []string{"gone"}},
+		{ID: "edited", Status: "to-do", CreatedAt: rfc(scoreNow.Add(-60 * 24 * time.Hour)), UpdatedAt: rfc(scoreNow)},
+	}
+	w := ScoreWeights{Age: 1, Dependencies: 3, Tags: map[string]float64{"urgent": 2}}
+	s := ScoreTasks(all, nil, w, scoreNow)
+	if s["base"].Tags != 2 || s["base"].Age != 1 || s["base"].Dependencies != 3 {
+		t.Fatalf("unexpected base breakdown: %+v", s["base"])
+	}
+	if s["edited"].Age != 1 {
+		t.Fatalf("age should count from creation, not the last edit: %+v", s["edited"])
+	}
+	if s["child1"].Dependencies >= 0 {
+		t.Fatalf("blocked task should be penalised, got %+v", s["child1"])
+	}
+	if s["free"].Dependencies != 0 {
+		t.Fatalf("dependency on unknown task should not block, got %+v", s["free"])
+	}
+}
+
+func TestScoreTasks_Calendar(t *testing.T) {
+	due := scoreNow.Add(10 * time.Hour)
+	all := []models.Task{
+		{ID: "match", Title: "Board Meeting", Status: "to-do"},
+		{ID: "load", Title: "Prep", Status: "to-do", DueDate: rfc(due)},
+	}
+	events := []models.CalendarEvent{
+		{Title: "board meeting", StartTime: rfc(scoreNow.Add(3 * time.Hour)), EndTime: rfc(scoreNow.Add(8 * time.Hour))},
+	}
+	s := ScoreTasks(all, events, ScoreWeights{Calendar: 2}, scoreNow)
+	if s["match"].Calendar != 2 {
+		t.Fatalf("title match within 24h should get full weight, got %+v", s["match"])
+	}
+	if math.Abs(s["load"].Calendar-1) > 1e-9 { // 5h of 10h booked
+		t.Fatalf("expected half calendar load, got %+v", s["load"])
+	}
+}
+
+func TestPriorityForScore(t *testing.T) {
+	th := ScoreThresholds{High: 3, Medium: 1.5}
+	cases := map[float64]string{5: "high", 3: "high", 2: "medium", 1.5: "medium", 0: "low", -1: "low"}
+	for score, want := range cases {
+		if got := PriorityForScore(score, th); got != want {
+			t.Fatalf("score %v: want %s got %s", score, want, got)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package tasks
+
+import (
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"unicode"
+)
+
+// Query is a parsed search string. Bare words are fuzzy-matched; "#tag",
+// "@status" and "!priority" words filter on those fields by prefix.
+type Query struct {
+	Terms      []string // fuzzy terms, lowercased
+	Tags       []string // every tag must be present
+	Statuses   []string // any status may match
+	Priorities []string // any priority may match
+}
+
+// Empty reports whether the query matches every task.
+func (q Query) Empty() bool {
+	return len(q.Terms)+len(q.Tags)+len(q.Statuses)+len(q.Priorities) == 0
+}
+
+// ParseQuery splits a search string into fuzzy terms and field filters. A
+// lone prefix character (still being typed) is ignored.
+func ParseQuery(s string) Query {
+	var q Query
+	for _, w := range strings.Fields(strings.ToLower(s)) {
+		switch {
+		case w == "#" || w == "@" || w == "!":
+		case w[0] == '#':
+			q.Tags = append(q.Tags, w[1:])
+		case w[0] == '@':
+			q.Statuses = append(q.Statuses, w[1:])
+		case w[0] == '!':
+			q.Priorities = append(q.Priorities, w[1:])
+		default:
+			q.Terms = append(q.Terms, w)
+		}
+	}
+	return q
+}
+
+// SearchResult is a task matched by Search with its score and the rune
+// positions of matched characters in the title (for highlighting).
+type SearchResult struct {
+	Task  models.Task
+	Score int
+	Title []int
+}
+
+// searchFields are the fuzzy-searched fields with their score weights.
+var searchFields = []struct {
+	weight int
+	get    func(t models.Task) string
+}{
+	{3, func(t models.Task) string { return t.Title }},
+	{2, func(t models.Task) string { return strings.Join(t.Tags, " ") }},
+	{1, func(t models.Task) string { return t.Notes }},
+	{1, func(t models.Task) string { return t.Link }},
+}
+
+// Search returns the tasks matching q. With fuzzy terms the results are
+// ranked best first (ties keep the input order); otherwise the input order
+// is kept. Every term must match at least one of title, tags, notes or link.
+func Search(all []models.Task, q Query) []SearchResult {
+	var out []SearchResult
+	for _, t := range all {
+		if !matchFilters(t, q) {
+			continue
+		}
+		r := SearchResult{Task: t}
+		ok := true
+		for _, term := range q.Terms {
+			best := 0
+			for i, f := range searchFields {
+				score, pos, matched := FuzzyMatch(term, f.get(t))
+				if !matched {
+					continue
+				}
+				if i == 0 {
+					r.Title = append(r.Title, pos...)
+				}
+				if s := score * f.weight; s > best {
+					best = s
+				}
+			}
+			if best == 0 {
+				ok = false
+				break
+			}
+			r.Score += best
+		}
+		if ok {
+			sort.Ints(r.Title)
+			out = append(out, r)
+		}
+	}
+	if len(q.Terms) > 0 {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	}
+	return out
+}
+
+// matchFilters applies the #tag, @status and !priority parts of q.
+func matchFilters(t models.Task, q Query) bool {
+	for _, want := range q.Tags {
+		found := false
+		for _, tag := range t.Tags {
+			if strings.HasPrefix(strings.ToLower(tag), want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(q.Statuses) > 0 && !anyPrefix(statusNames(t.Status), q.Statuses) {
+		return false
+	}
+	if len(q.Priorities) > 0 && !anyPrefix([]string{strings.ToLower(t.Priority)}, q.Priorities) {
+		return false
+	}
+	return true
+}
+
+// anyPrefix reports whether one of names starts with any of prefixes.
+func anyPrefix(names, prefixes []string) bool {
+	for _, n := range names {
+		for _, p := range prefixes {
+			if strings.HasPrefix(n, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// statusNames returns the lowercase spellings of status in the workflow: its
+// name and aliases, so "@todo" finds "to-do" tasks as `task list --status
+// todo` does. Unknown statuses only have themselves.
+func statusNames(status string) []string {
+	names := []string{status}
+	if s, ok := workflow.Current().Status(status); ok {
+		names = append([]string{s.Name}, s.Aliases...)
+	}
+	for i, n := range names {
+		names[i] = strings.ToLower(n)
+	}
+	return names
+}
+
+// Fuzzy scoring, loosely after fzf: every matched character scores, more so
+// at word starts and right after the previous match; gaps cost a little.
+const (
+	scoreMatch       = 16
+	bonusBoundary    = 8
+	bonusConsecutive = 6
+	bonusFirstChar   = 4
+	penaltyGapStart  = 3
+	penaltyGapExtend = 1
+)
+
+// FuzzyMatch reports whether every character of pattern appears in text in
+// order (case-insensitively), with a score and the matched rune positions in
+// text. An empty pattern matches with score 0.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	pat := []rune(strings.ToLower(pattern))
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	txt := []rune(strings.ToLower(text))
+
+	// Find the first window containing the pattern, then walk back from its
+	// end to the latest possible start so the match is as tight as possible.
+	pi, end := 0, -1
+	for i, r := range txt {
+		if r == pat[pi] {
+			pi++
+			if pi == len(pat) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	pos := make([]int, len(pat))
+	pi = len(pat) - 1
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if txt[i] == pat[pi] {
+			pos[pi] = i
+			pi--
+		}
+	}
+
+	score := 0
+	for k, i := range pos {
+		score += scoreMatch
+		if i == 0 || !isWordRune(txt[i-1]) {
+			score += bonusBoundary
+			if k == 0 {
+				score += bonusFirstChar
+			}
+		}
+		if k > 0 {
+			if gap := i - pos[k-1] - 1; gap == 0 {
+				score += bonusConsecutive
+			} else {
+				score -= penaltyGapStart + penaltyGapExtend*(gap-1)
+			}
+		}
+	}
+	if score < 1 {
+		score = 1 // still a match, just a poor one
+	}
+	return score, pos, true
+}
+
+func isWordRune(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
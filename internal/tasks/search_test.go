@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"reflect"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	score, pos, ok := FuzzyMatch("dpl", "Deploy staging")
+	if !ok || !reflect.DeepEqual(pos, []int{0, 2, 3}) {
+		t.Fatalf("expected match at 0,2,3, got %v %v", pos, ok)
+	}
+	if _, _, ok := FuzzyMatch("xyz", "Deploy staging"); ok {
+		t.Fatal("expected no match")
+	}
+	if s, _, ok := FuzzyMatch("", "anything"); !ok || s != 0 {
+		t.Fatalf("empty pattern should match with 0, got %d %v", s, ok)
+	}
+
+	// Contiguous and word-start matches outrank scattered ones.
+	tight, _, _ := FuzzyMatch("stag", "Deploy staging")
+	loose, _, _ := FuzzyMatch("stag", "Setup the tag manager")
+	if tight <= loose {
+		t.Fatalf("expected tight match to score higher: %d <= %d", tight, loose)
+	}
+	if score <= 0 {
+		t.Fatalf("expected positive score, got %d", score)
+	}
+
+	// The match is tightened backwards from its end.
+	_, pos, _ = FuzzyMatch("ab", "a xab")
+	if !reflect.DeepEqual(pos, []int{3, 4}) {
+		t.Fatalf("expected tightened positions 3,4, got %v", pos)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q := ParseQuery("Deploy #Work @todo !hi # api")
+	want := Query{Terms: []string{"deploy", "api"}, Tags: []string{"work"}, Statuses: []string{"todo"}, Priorities: []string{"hi"}}
+	if !reflect.DeepEqual(q, want) {
+		t.Fatalf("got %#v", q)
+	}
+	if !ParseQuery("  # ").Empty() {
+		t.Fatal("expected empty query")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	all := []models.Task{
+		{ID: "1", Title: "Setup the tag manager", Status: "to-do", Priority: "low"},
+		{ID: "2", Title: "Deploy staging", Status: "in-progress", Priority: "high", Tags: []string{"ops"}},
+		{ID: "3", Title: "Write docs", Status: "todo", Priority: "high", Notes: "mention staging env"},
+		{ID: "4", Title: "Fix login", Status: "done", Priority: "medium", Link: "https://example.com/stag"},
+	}
+	ids := func(rs []SearchResult) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.Task.ID)
+		}
+		return out
+	}
+
+	// Ranked: title word-start match first, notes/link matches after.
+	res := Search(all, ParseQuery("stag"))
+	if got := ids(res); !reflect.DeepEqual(got, []string{"2", "1", "3", "4"}) {
+		t.Fatalf("unexpected ranking %v", got)
+	}
+	if !reflect.DeepEqual(res[0].Title, []int{7, 8, 9, 10}) {
+		t.Fatalf("expected title positions, got %v", res[0].Title)
+	}
+
+	if got := ids(Search(all, ParseQuery("@todo"))); !reflect.DeepEqual(got, []string{"1", "3"}) {
+		t.Fatalf("status filter (incl. legacy todo): %v", got)
+	}
+	if got := ids(Search(all, ParseQuery("!high #op"))); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Fatalf("priority+tag filter: %v", got)
+	}
+	if got := ids(Search(all, ParseQuery("@in @done"))); !reflect.DeepEqual(got, []string{"2", "4"}) {
+		t.Fatalf("statuses are ORed: %v", got)
+	}
+	if got := ids(Search(all, ParseQuery("docs stag"))); !reflect.DeepEqual(got, []string{"3"}) {
+		t.Fatalf("terms are ANDed: %v", got)
+	}
+	if got := ids(Search(all, Query{})); len(got) != 4 {
+		t.Fatalf("empty query keeps all: %v", got)
+	}
+}
+
+func TestSearchStatusAliases(t *testing.T) {
+	workflow.Use(&workflow.Workflow{
+		Statuses:   []workflow.Status{{Name: "open"}, {Name: "review", Aliases: []string{"qa"}}, {Name: "closed", Terminal: true}},
+		Priorities: []workflow.Priority{{Name: "p1"}},
+	})
+	defer workflow.Use(nil)
+
+	all := []models.Task{{ID: "1", Status: "open"}, {ID: "2", Status: "Review"}, {ID: "3", Status: "qa"}}
+	for query, want := range map[string]int{"@qa": 2, "@rev": 2, "@q": 2, "@open": 1, "@closed": 0} {
+		if got := Search(all, ParseQuery(query)); len(got) != want {
+			t.Errorf("%s matched %d tasks, want %d", query, len(got), want)
+		}
+	}
+}
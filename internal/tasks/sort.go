@@ -0,0 +1,120 @@
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// SortKey is one field of a multi-key sort. Ascending order is the natural
+// reading order of the field: most urgent priority first, workflow order for
+// status (both from the workflow), earliest date first, A–Z for title.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+func (k SortKey) String() string {
+	if k.Desc {
+		return "-" + k.Field
+	}
+	return k.Field
+}
+
+// dateFields parse the date of a task used by the date sort keys.
+var dateFields = map[string]func(t models.Task) (time.Time, bool){
+	"due":     func(t models.Task) (time.Time, bool) { return ParseDue(t.DueDate, time.Local) },
+	"created": func(t models.Task) (time.Time, bool) { return parseStamp(t.CreatedAt) },
+	"updated": func(t models.Task) (time.Time, bool) { return parseStamp(t.UpdatedAt) },
+}
+
+// sortFields compare two tasks on the non-date fields.
+var sortFields = map[string]func(a, b models.Task) int{
+	"priority": func(a, b models.Task) int {
+		return workflow.Current().PriorityRank(a.Priority) - workflow.Current().PriorityRank(b.Priority)
+	},
+	"status": func(a, b models.Task) int {
+		return workflow.Current().StatusRank(a.Status) - workflow.Current().StatusRank(b.Status)
+	},
+	"title": func(a, b models.Task) int { return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) },
+	"id":    func(a, b models.Task) int { return strings.Compare(a.ID, b.ID) },
+}
+
+// SortFields returns the field names accepted by ParseSort.
+func SortFields() []string {
+	var names []string
+	for n := range sortFields {
+		names = append(names, n)
+	}
+	for n := range dateFields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseSort parses a comma-separated sort spec such as "due,-priority,title".
+// A leading "-" sorts that field descending.
+func ParseSort(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		k := SortKey{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		_, plain := sortFields[k.Field]
+		if _, date := dateFields[k.Field]; !plain && !date {
+			return nil, fmt.Errorf("unknown sort field %q (want one of %s)", k.Field, strings.Join(SortFields(), ", "))
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Sort orders ts in place by keys, keeping the existing order for ties.
+func Sort(ts []models.Task, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(ts, func(i, j int) bool {
+		for _, k := range keys {
+			if c := compareKey(k, ts[i], ts[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareKey compares a and b on one key. Tasks without a date sort after
+// those with one in both directions.
+func compareKey(k SortKey, a, b models.Task) int {
+	var c int
+	if get, ok := dateFields[k.Field]; ok {
+		ta, okA := get(a)
+		tb, okB := get(b)
+		switch {
+		case okA != okB && okA:
+			return -1
+		case okA != okB:
+			return 1
+		case okA:
+			c = ta.Compare(tb)
+		}
+	} else {
+		c = sortFields[k.Field](a, b)
+	}
+	if k.Desc {
+		c = -c
+	}
+	return c
+}
+
+func parseStamp(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
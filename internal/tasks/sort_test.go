@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"reflect"
+	"taskflow/internal/models"
+	"testing"
+)
+
+func sortedIDs(ts []models.Task, spec string, t *testing.T) []string {
+	t.Helper()
+	keys, err := ParseSort(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := append([]models.Task(nil), ts...)
+	Sort(cp, keys)
+	var ids []string
+	for _, x := range cp {
+		ids = append(ids, x.ID)
+	}
+	return ids
+}
+
+func TestSortSemanticOrders(t *testing.T) {
+	all := []models.Task{
+		{ID: "1", Title: "beta", Priority: "low", Status: "done", DueDate: "2025-10-03"},
+		{ID: "2", Title: "Alpha", Priority: "high", Status: "todo"},
+		{ID: "3", Title: "gamma", Priority: "medium", Status: "in-progress", DueDate: "2025-10-01T09:00:00Z", UpdatedAt: "2025-10-02T00:00:00Z"},
+		{ID: "4", Title: "delta", Priority: "", Status: "on-hold", DueDate: "2025-10-02", UpdatedAt: "2025-10-01T00:00:00Z"},
+		{ID: "5", Title: "epsilon", Priority: "high", Status: "to-do"},
+	}
+	cases := []struct {
+		spec string
+		want []string
+	}{
+		{"priority", []string{"2", "5", "3", "1", "4"}},
+		{"-priority", []string{"4", "1", "3", "2", "5"}},
+		{"status", []string{"2", "5", "3", "4", "1"}},
+		{"due", []string{"3", "4", "1", "2", "5"}},
+		{"-due", []string{"1", "4", "3", "2", "5"}}, // undated stay last
+		{"-updated", []string{"3", "4", "1", "2", "5"}},
+		{"title", []string{"2", "1", "4", "5", "3"}},
+		{"priority,-title", []string{"5", "2", "3", "1", "4"}},
+		{"due, -priority ,title", []string{"3", "4", "1", "2", "5"}},
+	}
+	for _, c := range cases {
+		if got := sortedIDs(all, c.spec, t); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v want %v", c.spec, got, c.want)
+		}
+	}
+}
+
+func TestParseSortErrors(t *testing.T) {
+	if _, err := ParseSort("due,bogus"); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+	keys, err := ParseSort("-Due,title")
+	if err != nil || !reflect.DeepEqual(keys, []SortKey{{Field: "due", Desc: true}, {Field: "title"}}) {
+		t.Fatalf("got %v %v", keys, err)
+	}
+	if keys[0].String() != "-due" {
+		t.Fatalf("String() = %q", keys[0].String())
+	}
+}
@@ -0,0 +1,125 @@
+package tasks
+
+import (
+	"fmt"
+	"taskflow/internal/models"
+	"taskflow/internal/workflow"
+	"time"
+)
+
+// ActiveTimer returns the index of the task with a running timer, or -1.
+func ActiveTimer(all []models.Task) int {
+	for i := range all {
+		if running(&all[i]) >= 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// running returns the index of the open worklog entry of t, or -1.
+func running(t *models.Task) int {
+	for i := len(t.Worklog) - 1; i >= 0; i-- {
+		if t.Worklog[i].End == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// RunningEntry returns the open worklog entry of t, if any.
+func RunningEntry(t models.Task) (models.WorkEntry, bool) {
+	if i := running(&t); i >= 0 {
+		return t.Worklog[i], true
+	}
+	return models.WorkEntry{}, false
+}
+
+// StartTimer opens a worklog entry on all[idx]. Only one timer may run at a
+// time across all tasks. A task still in the initial status moves to the
+// workflow's started status (in-progress by default).
+func StartTimer(all []models.Task, idx int, now time.Time) error {
+	if active := ActiveTimer(all); active >= 0 {
+		return fmt.Errorf("a timer is already running on %q; stop it first", all[active].Title)
+	}
+	t := &all[idx]
+	t.Worklog = append(t.Worklog, models.WorkEntry{Start: now.UTC().Format(time.RFC3339)})
+	if wf := workflow.Current(); wf.IsInitial(t.Status) {
+		SetStatus(t, wf.Started(), now)
+	}
+	return nil
+}
+
+// StopTimer closes the running timer and returns the task index and the
+// length of the entry that was closed.
+func StopTimer(all []models.Task, now time.Time, note string) (int, time.Duration, error) {
+	idx := ActiveTimer(all)
+	if idx < 0 {
+		return -1, 0, fmt.Errorf("no timer is running")
+	}
+	t := &all[idx]
+	e := &t.Worklog[running(t)]
+	e.End = now.UTC().Format(time.RFC3339)
+	if note != "" {
+		e.Note = note
+	}
+	return idx, EntryDuration(*e, now), nil
+}
+
+// LogTime records d of work on t that ended at now.
+func LogTime(t *models.Task, d time.Duration, note string, now time.Time) error {
+	if d <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+	t.Worklog = append(t.Worklog, models.WorkEntry{
+		Start: now.Add(-d).UTC().Format(time.RFC3339),
+		End:   now.UTC().Format(time.RFC3339),
+		Note:  note,
+	})
+	return nil
+}
+
+// EntryDuration is the length of e; a running entry counts up to now.
+func EntryDuration(e models.WorkEntry, now time.Time) time.Duration {
+	start, err := time.Parse(time.RFC3339, e.Start)
+	if err != nil {
+		return 0
+	}
+	end := now
+	if e.End != "" {
+		if end, err = time.Parse(time.RFC3339, e.End); err != nil {
+			return 0
+		}
+	}
+	if end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// TimeSpent totals the worklog of t.
+func TimeSpent(t models.Task, now time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range t.Worklog {
+		total += EntryDuration(e, now)
+	}
+	return total
+}
+
+// FormatDuration prints d to the minute the way estimates and logged time are
+// written, e.g. 5m, 1h or 25h30m. There is no day unit: totals stay in hours.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh%dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+func TestTimer_SingleActiveAndStop(t *testing.T) {
+	all := []models.Task{{ID: "1", Title: "A", Status: "to-do"}, {ID: "2", Title: "B", Status: "to-do"}}
+	if err := StartTimer(all, 0, t0); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if all[0].Status != "in-progress" || ActiveTimer(all) != 0 {
+		t.Fatalf("timer not running on task 1: %+v", all[0])
+	}
+	if err := StartTimer(all, 1, t0); err == nil {
+		t.Fatalf("second timer should be refused")
+	}
+	idx, d, err := StopTimer(all, t0.Add(90*time.Minute), "review")
+	if err != nil || idx != 0 || d != 90*time.Minute {
+		t.Fatalf("stop: %d %v %v", idx, d, err)
+	}
+	if all[0].Worklog[0].Note != "review" || ActiveTimer(all) != -1 {
+		t.Fatalf("entry not closed: %+v", all[0].Worklog)
+	}
+	if _, _, err := StopTimer(all, t0, ""); err == nil {
+		t.Fatalf("stop without a running timer should fail")
+	}
+}
+
+func TestLogTimeAndTimeSpent(t *testing.T) {
+	task := models.Task{ID: "1"}
+	if err := LogTime(&task, 0, "", t0); err == nil {
+		t.Fatalf("zero duration should be rejected")
+	}
+	_ = LogTime(&task, time.Hour, "", t0)
+	task.Worklog = append(task.Worklog, models.WorkEntry{Start: t0.Format(time.RFC3339)}) // running
+	if got := TimeSpent(task, t0.Add(30*time.Minute)); got != 90*time.Minute {
+		t.Fatalf("time spent: %v", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		90 * time.Minute:              "1h30m",
+		10 * time.Minute:              "10m",
+		2 * time.Hour:                 "2h",
+		25*time.Hour + 30*time.Minute: "25h30m",
+		48 * time.Hour:                "48h",
+		90*time.Second + time.Second:  "2m",
+		0:                             "0m",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
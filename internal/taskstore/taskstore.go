@@ -0,0 +1,56 @@
+// Package taskstore opens the tasks file the way commands write it: with the
+// lifecycle hooks, change history and webhooks from config. Package storage
+// itself only validates and persists.
+package taskstore
+
+import (
+	"fmt"
+	"taskflow/internal/config"
+	"taskflow/internal/hooks"
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+	"taskflow/internal/tasks"
+	"taskflow/internal/webhooks"
+	"time"
+)
+
+// Open returns the storage of the tasks (or archive) file at path with
+// Options.
+func Open(path string) (*storage.Storage, error) {
+	s, err := storage.NewStorage(path)
+	if err != nil {
+		return nil, err
+	}
+	return s.WithOptions(Options()), nil
+}
+
+// Options are the storage options from config: the add/modify/complete and
+// archive hooks (see internal/hooks) run before writes, history is kept as
+// history.enabled and history.limit say, and created and completed tasks are
+// queued for the configured webhooks after writes.
+func Options() storage.Options {
+	return storage.Options{
+		History:     tasks.TrackOptions{History: config.HistoryEnabled(), HistoryLimit: config.HistoryLimit()},
+		BeforeWrite: hooks.Apply,
+		BeforeArchive: func(t *models.Task) error {
+			return hooks.Run(hooks.OnArchive, nil, t)
+		},
+		AfterWrite: queueWebhooks,
+	}
+}
+
+// queueWebhooks queues the created and completed events of a write for the
+// configured webhooks; the worker delivers them.
+func queueWebhooks(prev map[string]*models.Task, all []models.Task, now time.Time) error {
+	endpoints, err := config.GetWebhooks()
+	if err != nil || len(endpoints) == 0 {
+		return err
+	}
+	q := webhooks.NewQueue(config.GetWebhookQueueDir())
+	for _, c := range webhooks.Changes(prev, all) {
+		if err := q.Enqueue(endpoints, c, now); err != nil {
+			return fmt.Errorf("queueing webhooks failed: %w", err)
+		}
+	}
+	return nil
+}
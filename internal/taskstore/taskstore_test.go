@@ -0,0 +1,80 @@
+package taskstore
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/webhooks"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestOpen_HooksVetoAndArchive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("hooks.on_complete", "echo 'tests are red' >&2; exit 1")
+	viper.Set("hooks.on_archive", "exit 1")
+	viper.Set("history.enabled", true)
+
+	st, _ := Open(filepath.Join(t.TempDir(), "tasks.yaml"))
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship", Status: "to-do"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship", Status: "done"}})
+	if err == nil || !strings.Contains(err.Error(), "tests are red") {
+		t.Fatalf("expected on_complete veto, got %v", err)
+	}
+	got, _ := st.ReadTasks()
+	if got[0].Status != "to-do" || len(got[0].History) != 1 {
+		t.Fatalf("vetoed write changed the file: %+v", got[0])
+	}
+
+	archive, _ := Open(filepath.Join(t.TempDir(), "archive.yaml"))
+	if err := archive.Archive(got); err == nil {
+		t.Fatal("expected on_archive veto")
+	}
+	if rest, _ := archive.ReadTasks(); len(rest) != 0 {
+		t.Fatalf("vetoed archive wrote tasks: %+v", rest)
+	}
+}
+
+func TestOpen_QueuesWebhooks(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	queueDir := t.TempDir()
+	viper.Set("webhooks.queue_dir", queueDir)
+	viper.Set("webhooks.endpoints", []map[string]any{
+		{"name": "all", "url": "http://example.com/hook"},
+		{"name": "done", "url": "http://example.com/done", "events": []string{"completed"}},
+	})
+
+	st, _ := Open(filepath.Join(t.TempDir(), "tasks.yaml"))
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship", Status: "to-do"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship it", Status: "to-do"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Ship it", Status: "done"}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	pending, err := webhooks.NewQueue(queueDir).Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range pending {
+		got = append(got, fmt.Sprintf("%s>%s", d.Event, d.Endpoint))
+	}
+	want := "task.created>all task.completed>all task.completed>done"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("queued %v, want %s", got, want)
+	}
+}
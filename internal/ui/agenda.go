@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/planner"
+	"taskflow/internal/storage"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// loadEvents reads calendar events when the calendar file changed since the
+// last read. Missing or unreadable calendars leave the agenda task-only.
+func (m *Model) loadEvents() {
+	path := config.GetCalendarStoragePath()
+	if path == "" {
+		return
+	}
+	fi, err := os.Stat(path)
+	if err != nil || !fi.ModTime().After(m.calendarMod) {
+		return
+	}
+	s, err := storage.NewStorage(path)
+	if err != nil {
+		return
+	}
+	events, err := s.ReadCalendarEvents()
+	if err != nil {
+		return
+	}
+	m.events = events
+	m.calendarMod = fi.ModTime()
+}
+
+// agendaRange returns the first day and number of days shown.
+func (m *Model) agendaRange() (time.Time, int) {
+	day := m.agendaDay
+	if day.IsZero() {
+		day = time.Now()
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	if !m.agendaWeek {
+		return day, 1
+	}
+	offset := (int(day.Weekday()) + 6) % 7 // weeks start on Monday
+	return day.AddDate(0, 0, -offset), 7
+}
+
+// agendaDays returns the agenda for the current range, honouring the list filter.
+func (m *Model) agendaDays() []planner.AgendaDay {
+	from, days := m.agendaRange()
+	return planner.Agenda(m.view, m.events, from, days)
+}
+
+func flattenAgenda(days []planner.AgendaDay) []planner.AgendaItem {
+	var out []planner.AgendaItem
+	for _, d := range days {
+		out = append(out, d.Items...)
+	}
+	return out
+}
+
+func (m *Model) openAgenda() {
+	m.agendaMode = true
+	m.boardMode = false
+	m.agendaWeek = true
+	m.agendaDay = time.Now()
+	m.agendaCursor = 0
+	m.loadEvents()
+}
+
+// handleAgendaKey handles keys in agenda mode. Quit and help fall through to
+// the list handler; other list actions are ignored since the cursor is on
+// agenda items rather than tasks.
+func (m *Model) handleAgendaKey(k tea.KeyMsg) (bool, tea.Cmd) {
+	items := flattenAgenda(m.agendaDays())
+	step := 1
+	if m.agendaWeek {
+		step = 7
+	}
+	switch {
+	case key.Matches(k, m.keys.Quit), key.Matches(k, m.keys.Help):
+		return false, nil
+	case key.Matches(k, m.keys.Agenda), key.Matches(k, m.keys.Back):
+		m.agendaMode = false
+	case key.Matches(k, m.keys.Board):
+		m.agendaMode = false
+		m.boardMode = true
+	case key.Matches(k, m.keys.Up):
+		if m.agendaCursor > 0 {
+			m.agendaCursor--
+		}
+	case key.Matches(k, m.keys.Down):
+		if m.agendaCursor < len(items)-1 {
+			m.agendaCursor++
+		}
+	case key.Matches(k, m.keys.Left):
+		m.agendaDay = m.agendaDay.AddDate(0, 0, -step)
+		m.agendaCursor = 0
+	case key.Matches(k, m.keys.Right):
+		m.agendaDay = m.agendaDay.AddDate(0, 0, step)
+		m.agendaCursor = 0
+	case key.Matches(k, m.keys.AgendaSpan):
+		m.agendaWeek = !m.agendaWeek
+		m.agendaCursor = 0
+	case key.Matches(k, m.keys.Today):
+		m.agendaDay = time.Now()
+		m.agendaCursor = 0
+	case key.Matches(k, m.keys.Open):
+		if m.agendaCursor < len(items) {
+			m.jumpToTask(items[m.agendaCursor].TaskID)
+		}
+	}
+	return true, nil
+}
+
+// jumpToTask leaves the agenda and opens the task's detail box.
+func (m *Model) jumpToTask(id string) {
+	if id == "" {
+		m.statusMessage = "no task linked to this event"
+		return
+	}
+	m.rebuild(id)
+	if len(m.view) == 0 || m.view[m.cursor].ID != id {
+		m.statusMessage = "linked task is hidden by the current filter"
+		return
+	}
+	m.agendaMode = false
+	current := m.view[m.cursor]
+	m.detailTask = &current
+	m.viewingDetail = true
+	m.detailFieldIndex = 0
+}
+
+func (m *Model) renderAgenda() string {
+	days := m.agendaDays()
+	from, n := m.agendaRange()
+	span := from.Format("Mon 2006-01-02")
+	if n > 1 {
+		span += " – " + from.AddDate(0, 0, n-1).Format("Mon 2006-01-02")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	var lines []string
+	cursorLine, idx := 0, 0
+	for _, d := range days {
+		heading := d.Date.Format("Monday 2 Jan")
+		if d.Date.Format("2006-01-02") == today {
+			heading += " (today)"
+		}
+		lines = append(lines, lipgloss.NewStyle().Bold(true).Render(heading))
+		if len(d.Items) == 0 {
+			lines = append(lines, lipgloss.NewStyle().Faint(true).Render("  nothing scheduled"))
+		}
+		for _, it := range d.Items {
+			line := "  " + agendaLine(it)
+			if idx == m.agendaCursor {
+				line = invert(line)
+				cursorLine = len(lines)
+			}
+			lines = append(lines, line)
+			idx++
+		}
+		lines = append(lines, "")
+	}
+
+	// Scroll so the cursor stays visible.
+	visible := m.pageSize()
+	start := 0
+	if cursorLine >= visible {
+		start = cursorLine - visible + 1
+	}
+	end := min(start+visible, len(lines))
+
+	var content strings.Builder
+	content.WriteString(m.listHeader("agenda: "+span) + "\n\n")
+	for _, l := range lines[start:end] {
+		content.WriteString(lipgloss.NewStyle().MaxWidth(m.width-8).Render(l) + "\n")
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2, 0, 2).
+		Width(m.width - 4).
+		Render(content.String())
+
+	spanLabel := "week"
+	if m.agendaWeek {
+		spanLabel = "day"
+	}
+	bar := fmt.Sprintf(" %s  %s  %s  %s  %s  %s ", keyHint(m.keys.Agenda, "list"), keyHint(m.keys.Left, "prev"),
+		keyHint(m.keys.Right, "next"), keyHint(m.keys.AgendaSpan, spanLabel), keyHint(m.keys.Today, "today"), keyHint(m.keys.Open, "open task"))
+	if m.statusMessage != "" {
+		bar += "│ " + m.statusMessage + " "
+	}
+	return box + "\n" + statusStyle.Render(bar)
+}
+
+// agendaLine renders one agenda entry: time, kind marker and title.
+func agendaLine(it planner.AgendaItem) string {
+	when := it.Start.Format("15:04")
+	switch {
+	case it.AllDay:
+		when = "all day"
+	case it.IsEvent() && !it.End.IsZero():
+		when += "–" + it.End.Format("15:04")
+	}
+	if it.IsEvent() {
+		line := fmt.Sprintf("%-11s ▣ %s", when, it.Title)
+		if it.Task != nil && !strings.EqualFold(strings.TrimSpace(it.Task.Title), strings.TrimSpace(it.Title)) {
+			line += " → " + it.Task.Title
+		} else if it.Task != nil {
+			line += " →"
+		}
+		return line
+	}
+	return fmt.Sprintf("%-11s %s %s %s", when, taskIcon(it.Task), priorityBadge(it.Task.Priority), it.Title)
+}
+
+// taskIcon returns the list's status icon for a task.
+func taskIcon(t *models.Task) string {
+	icon := statusIcon(t.Status)
+	if icon == "○" {
+		return icon
+	}
+	return fg(statusColor(t.Status), icon)
+}
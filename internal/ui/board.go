@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boardMinColumnWidth is the narrowest a board column may get before the
+// board shows fewer columns and scrolls horizontally instead.
+const boardMinColumnWidth = 24
+
+// boardColumn returns the board column (index into statusOptions) of a status.
+// Aliases land in their status's column, unknown statuses in the first.
+func boardColumn(status string) int {
+	wf := workflow.Current()
+	if r := wf.StatusRank(status); r < len(wf.Statuses) {
+		return r
+	}
+	return 0
+}
+
+// boardColumns groups view indices by column, keeping the view's filter and sort order.
+func (m *Model) boardColumns() [][]int {
+	cols := make([][]int, len(statusOptions()))
+	for i, t := range m.view {
+		c := boardColumn(t.Status)
+		cols[c] = append(cols[c], i)
+	}
+	return cols
+}
+
+// boardPosition returns the column and row of the cursor on the board.
+func (m *Model) boardPosition(cols [][]int) (int, int) {
+	if len(m.view) == 0 {
+		return 0, 0
+	}
+	c := boardColumn(m.view[m.cursor].Status)
+	for r, i := range cols[c] {
+		if i == m.cursor {
+			return c, r
+		}
+	}
+	return c, 0
+}
+
+// handleBoardKey handles navigation and card moves in board mode. It reports
+// whether the key was consumed; other keys fall through to the list handler,
+// which acts on the card under the cursor.
+func (m *Model) handleBoardKey(k tea.KeyMsg) (bool, tea.Cmd) {
+	cols := m.boardColumns()
+	col, row := m.boardPosition(cols)
+	switch {
+	case key.Matches(k, m.keys.Up):
+		if row > 0 {
+			m.cursor = cols[col][row-1]
+		}
+	case key.Matches(k, m.keys.Down):
+		if row < len(cols[col])-1 {
+			m.cursor = cols[col][row+1]
+		}
+	case key.Matches(k, m.keys.Left), key.Matches(k, m.keys.Right):
+		step := 1
+		if key.Matches(k, m.keys.Left) {
+			step = -1
+		}
+		// Skip empty columns; there is no card to land on.
+		for c := col + step; c >= 0 && c < len(cols); c += step {
+			if len(cols[c]) > 0 {
+				m.cursor = cols[c][min(row, len(cols[c])-1)]
+				break
+			}
+		}
+	case key.Matches(k, m.keys.MoveLeft), key.Matches(k, m.keys.MoveRight):
+		if len(m.view) == 0 {
+			return true, nil
+		}
+		target := col + 1
+		if key.Matches(k, m.keys.MoveLeft) {
+			target = col - 1
+		}
+		if target < 0 || target >= len(statusOptions()) {
+			return true, nil
+		}
+		t := m.view[m.cursor]
+		tasks.SetStatus(&t, statusOptions()[target], time.Now())
+		m.saveTask(t)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// boardLayout returns how many columns fit and their width.
+func (m *Model) boardLayout() (visible, width int) {
+	avail := m.width - 2
+	visible = avail / boardMinColumnWidth
+	if visible > len(statusOptions()) {
+		visible = len(statusOptions())
+	}
+	if visible < 1 {
+		visible = 1
+	}
+	width = avail / visible
+	if width < 10 {
+		width = 10
+	}
+	return visible, width
+}
+
+func (m *Model) renderBoard() string {
+	cols := m.boardColumns()
+	cur, row := m.boardPosition(cols)
+	visible, width := m.boardLayout()
+
+	// Scroll horizontally so the cursor's column is always shown.
+	first := 0
+	if cur >= visible {
+		first = cur - visible + 1
+	}
+	last := first + visible
+
+	rows := m.height - 9 // header, column borders, column title and status bar
+	if rows < 1 {
+		rows = 1
+	}
+
+	var rendered []string
+	for c := first; c < last && c < len(cols); c++ {
+		var b strings.Builder
+		title := fmt.Sprintf("%s (%d)", statusOptions()[c], len(cols[c]))
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(statusColor(statusOptions()[c])).Render(title) + "\n")
+
+		start := 0
+		if c == cur && row >= rows {
+			start = row - rows + 1
+		}
+		end := min(start+rows, len(cols[c]))
+		for _, i := range cols[c][start:end] {
+			t := m.view[i]
+			mark := " "
+			if m.isSelected(i) {
+				mark = fg(theme.Selection, "●")
+			}
+			line := lipgloss.NewStyle().MaxWidth(width - 4).Render(fmt.Sprintf("%s%s %s", mark, priorityBadge(t.Priority), t.Title))
+			if i == m.cursor {
+				line = invert(line)
+			}
+			b.WriteString(line + "\n")
+		}
+		if end < len(cols[c]) {
+			b.WriteString(fmt.Sprintf("  … %d more\n", len(cols[c])-end))
+		}
+
+		border := theme.Border
+		if c == cur {
+			border = statusColor(statusOptions()[c])
+		}
+		rendered = append(rendered, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(border).
+			Padding(0, 1).
+			Width(width-2).
+			Height(rows+1).
+			Render(b.String()))
+	}
+
+	header := m.listHeader("board")
+	if first > 0 || last < len(cols) {
+		header += fmt.Sprintf("  [columns %d-%d of %d]", first+1, min(last, len(cols)), len(cols))
+	}
+	board := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+
+	bar := fmt.Sprintf(" %s  %s  %s  %s  %s  %s ", keyHint(m.keys.Quit, "quit"), keyHint(m.keys.Help, "help"),
+		keyHint(m.keys.Board, "list"), keyHint(m.keys.Left, "column"), keyHint(m.keys.MoveLeft, "move left"), keyHint(m.keys.MoveRight, "move right"))
+	if m.statusMessage != "" {
+		bar += "│ " + m.statusMessage + " "
+	}
+	return header + "\n" + board + "\n" + statusStyle.Render(bar)
+}
+
+// priorityBadge renders the [H]/[M]/[L] marker (the first letter of the
+// workflow priority) for a priority; unknown priorities have none.
+func priorityBadge(priority string) string {
+	p, ok := workflow.Current().Priority(priority)
+	if !ok {
+		return ""
+	}
+	return fg(priorityColor(p), "["+strings.ToUpper(string([]rune(p.Name)[:1]))+"]")
+}
+
+// priorityColor returns the workflow colour of a priority, or the theme's
+// high/medium/low colour for the third of the scale it falls in.
+func priorityColor(p workflow.Priority) lipgloss.Color {
+	if p.Color != "" {
+		return lipgloss.Color(p.Color)
+	}
+	wf := workflow.Current()
+	switch wf.PriorityRank(p.Name) * 3 / len(wf.Priorities) {
+	case 0:
+		return theme.PriorityHigh
+	case 1:
+		return theme.PriorityMedium
+	}
+	return theme.PriorityLow
+}
+
+// statusColor returns the workflow colour of a status, or the theme colour
+// for its kind: terminal, active, initial or other (on hold).
+func statusColor(status string) lipgloss.Color {
+	wf := workflow.Current()
+	st, ok := wf.Status(status)
+	switch {
+	case !ok:
+		return theme.StatusTodo
+	case st.Color != "":
+		return lipgloss.Color(st.Color)
+	case st.Terminal:
+		return theme.StatusDone
+	case st.Active:
+		return theme.StatusInProgress
+	case wf.IsInitial(status):
+		return theme.StatusTodo
+	}
+	return theme.StatusOnHold
+}
+
+// statusIcon returns the list icon for a status kind.
+func statusIcon(status string) string {
+	wf := workflow.Current()
+	switch {
+	case wf.IsTerminal(status):
+		return "✓"
+	case wf.IsActive(status):
+		return "◐"
+	case wf.IsInitial(status) || wf.StatusRank(status) == len(wf.Statuses):
+		return "○"
+	}
+	return "⏸"
+}
+
+// statusLabel is the display name of a status: "in-progress" → "In Progress".
+func statusLabel(status string) string {
+	name := workflow.Current().Canonical(status)
+	if name == "to-do" {
+		return "To-Do"
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == ' ' })
+	for i, w := range words {
+		r := []rune(w)
+		words[i] = strings.ToUpper(string(r[:1])) + string(r[1:])
+	}
+	return strings.Join(words, " ")
+}
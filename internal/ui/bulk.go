@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/config"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/taskstore"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// isSelected reports whether the task at view index i is marked, either
+// explicitly or by the open visual range.
+func (m *Model) isSelected(i int) bool {
+	if i < 0 || i >= len(m.view) {
+		return false
+	}
+	if m.selected[m.view[i].ID] {
+		return true
+	}
+	if !m.visualMode {
+		return false
+	}
+	lo, hi := m.visualAnchor, m.cursor
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return i >= lo && i <= hi
+}
+
+// selectionIDs returns the IDs of all marked tasks in the view.
+func (m *Model) selectionIDs() map[string]bool {
+	ids := map[string]bool{}
+	for i, t := range m.view {
+		if m.isSelected(i) {
+			ids[t.ID] = true
+		}
+	}
+	return ids
+}
+
+func (m *Model) clearSelection() {
+	m.selected = nil
+	m.visualMode = false
+}
+
+// toggleSelect marks or unmarks the task under the cursor.
+func (m *Model) toggleSelect() {
+	if len(m.view) == 0 {
+		return
+	}
+	if m.selected == nil {
+		m.selected = map[string]bool{}
+	}
+	id := m.view[m.cursor].ID
+	if m.selected[id] {
+		delete(m.selected, id)
+	} else {
+		m.selected[id] = true
+	}
+}
+
+// toggleVisual starts a range at the cursor, or commits the open range.
+func (m *Model) toggleVisual() {
+	if len(m.view) == 0 {
+		return
+	}
+	if !m.visualMode {
+		m.visualMode = true
+		m.visualAnchor = m.cursor
+		return
+	}
+	m.selected = m.selectionIDs()
+	m.visualMode = false
+}
+
+// toggleSelectAll marks every task in the view, or clears the selection if
+// they are all marked already.
+func (m *Model) toggleSelectAll() {
+	ids := m.selectionIDs()
+	if len(m.view) > 0 && len(ids) == len(m.view) {
+		m.clearSelection()
+		return
+	}
+	m.visualMode = false
+	m.selected = map[string]bool{}
+	for _, t := range m.view {
+		m.selected[t.ID] = true
+	}
+}
+
+// openBulkMenu targets the selection, or the task under the cursor when
+// nothing is selected.
+func (m *Model) openBulkMenu() {
+	ids := m.selectionIDs()
+	if len(ids) == 0 {
+		if len(m.view) == 0 {
+			return
+		}
+		ids[m.view[m.cursor].ID] = true
+	}
+	m.bulkTargets = ids
+	m.bulkMenu = true
+	m.bulkCursor = 0
+	m.bulkStage = bulkStageMenu
+	m.bulkError = ""
+}
+
+func (m *Model) closeBulkMenu() {
+	m.bulkMenu = false
+	m.bulkTargets = nil
+	m.bulkError = ""
+	m.editInput.Blur()
+}
+
+// bulk menu stages
+const (
+	bulkStageMenu = iota
+	bulkStageChoose
+	bulkStageInput
+	bulkStageConfirm
+)
+
+// bulkChoices returns the fixed options for the chosen action, if any.
+func (m *Model) bulkChoices() []string {
+	switch m.bulkAction {
+	case tasks.BulkSetStatus:
+		return statusOptions()
+	case tasks.BulkSetPriority:
+		return priorityOptions()
+	}
+	return nil
+}
+
+func (m *Model) handleBulkKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.bulkStage {
+	case bulkStageConfirm:
+		switch {
+		case key.Matches(k, m.keys.Confirm):
+			m.runBulk("")
+		case key.Matches(k, m.keys.Cancel):
+			m.closeBulkMenu()
+		}
+		return m, nil
+
+	case bulkStageChoose:
+		choices := m.bulkChoices()
+		switch {
+		case key.Matches(k, m.keys.Back):
+			m.bulkStage = bulkStageMenu
+		case key.Matches(k, m.keys.Up):
+			if m.bulkChoiceCursor > 0 {
+				m.bulkChoiceCursor--
+			}
+		case key.Matches(k, m.keys.Down):
+			if m.bulkChoiceCursor < len(choices)-1 {
+				m.bulkChoiceCursor++
+			}
+		case key.Matches(k, m.keys.Open):
+			m.runBulk(choices[m.bulkChoiceCursor])
+		}
+		return m, nil
+
+	case bulkStageInput:
+		switch {
+		case key.Matches(k, inputKeys(m.keys.Back)):
+			m.bulkStage = bulkStageMenu
+			m.editInput.Blur()
+			return m, nil
+		case key.Matches(k, inputKeys(m.keys.Open)):
+			m.runBulk(m.editInput.Value())
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.editInput, cmd = m.editInput.Update(k)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(k, m.keys.Back, m.keys.Quit):
+		m.closeBulkMenu()
+	case key.Matches(k, m.keys.Up):
+		if m.bulkCursor > 0 {
+			m.bulkCursor--
+		}
+	case key.Matches(k, m.keys.Down):
+		if m.bulkCursor < len(tasks.BulkActions)-1 {
+			m.bulkCursor++
+		}
+	case key.Matches(k, m.keys.Open):
+		m.bulkAction = tasks.BulkActions[m.bulkCursor]
+		m.bulkError = ""
+		switch m.bulkAction {
+		case tasks.BulkSetStatus, tasks.BulkSetPriority:
+			m.bulkStage = bulkStageChoose
+			m.bulkChoiceCursor = 0
+		case tasks.BulkArchive, tasks.BulkDelete:
+			m.bulkStage = bulkStageConfirm
+		default:
+			m.bulkStage = bulkStageInput
+			m.editInput.SetValue("")
+			m.editInput.Focus()
+		}
+	}
+	return m, nil
+}
+
+// runBulk applies the chosen action to all targets with a single write,
+// backing up the tasks file first so `task undo` reverts the whole batch.
+// Archived tasks leave the tasks file before they reach the archive; if the
+// archive write fails, the tasks file is put back so no task is in both.
+func (m *Model) runBulk(value string) {
+	kept, removed, err := tasks.ApplyBulk(m.allTasks, m.bulkTargets, m.bulkAction, value, time.Now())
+	if err != nil {
+		m.bulkError = err.Error()
+		return
+	}
+	if err := m.storage.Backup(); err != nil {
+		m.bulkError = fmt.Sprintf("failed to create backup: %v", err)
+		return
+	}
+	stored, err := m.storage.ReadStored()
+	if err != nil {
+		m.bulkError = err.Error()
+		return
+	}
+	if err := m.storage.WriteTasks(kept); err != nil {
+		m.bulkError = err.Error()
+		return
+	}
+	if m.bulkAction == tasks.BulkArchive {
+		if err := archiveTasks(removed); err != nil {
+			if rbErr := m.storage.Rewrite(stored); rbErr != nil {
+				err = fmt.Errorf("%v; restoring the tasks file failed: %v", err, rbErr)
+			}
+			m.bulkError = err.Error()
+			m.reloadAfterMutation("")
+			return
+		}
+	}
+	m.statusMessage = fmt.Sprintf("%s: %d task(s)", m.bulkAction, len(m.bulkTargets))
+	m.closeBulkMenu()
+	m.clearSelection()
+	focus := ""
+	if len(m.view) > 0 {
+		focus = m.view[m.cursor].ID
+	}
+	m.reloadAfterMutation(focus)
+}
+
+// archiveTasks appends tasks to the configured archive file.
+func archiveTasks(archived []models.Task) error {
+	archivePath := config.GetArchiveFilePath()
+	if archivePath == "" {
+		return fmt.Errorf("archive path not configured")
+	}
+	archive, err := taskstore.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	return archive.Archive(archived)
+}
+
+func (m *Model) renderBulkBox() string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Width(60)
+
+	var content strings.Builder
+	content.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Bulk Actions (%d tasks)", len(m.bulkTargets))) + "\n\n")
+
+	switch m.bulkStage {
+	case bulkStageConfirm:
+		content.WriteString(fmt.Sprintf("%s %d task(s)?\n\n", m.bulkAction, len(m.bulkTargets)))
+		content.WriteString(statusStyle.Render(fmt.Sprintf(" [%s %s] ", bindingHint(m.keys.Confirm, "confirm"), bindingHint(m.keys.Cancel, "cancel"))))
+	case bulkStageChoose:
+		content.WriteString(lipgloss.NewStyle().Bold(true).Render(string(m.bulkAction)+":") + "\n\n")
+		for i, c := range m.bulkChoices() {
+			line := "  " + c
+			if i == m.bulkChoiceCursor {
+				line = invert(line)
+			}
+			content.WriteString(line + "\n")
+		}
+		content.WriteString("\n")
+		content.WriteString(statusStyle.Render(fmt.Sprintf(" [%s %s %s %s] ", bindingHint(m.keys.Up, "up"), bindingHint(m.keys.Down, "down"),
+			bindingHint(m.keys.Open, "apply"), bindingHint(m.keys.Back, "back"))))
+	case bulkStageInput:
+		hint := "comma separated"
+		if m.bulkAction == tasks.BulkSetDue {
+			hint = "YYYY-MM-DD or RFC3339, empty clears"
+		}
+		content.WriteString(fmt.Sprintf("%s (%s):\n\n", m.bulkAction, hint))
+		content.WriteString(m.editInput.View() + "\n\n")
+		content.WriteString(statusStyle.Render(fmt.Sprintf(" [%s %s] ", bindingHint(inputKeys(m.keys.Open), "apply"), bindingHint(inputKeys(m.keys.Back), "back"))))
+	default:
+		for i, a := range tasks.BulkActions {
+			line := "  " + string(a)
+			if i == m.bulkCursor {
+				line = invert(line)
+			}
+			content.WriteString(line + "\n")
+		}
+		content.WriteString("\n")
+		content.WriteString(statusStyle.Render(fmt.Sprintf(" [%s %s %s %s] ", bindingHint(m.keys.Up, "up"), bindingHint(m.keys.Down, "down"),
+			bindingHint(m.keys.Open, "select"), bindingHint(m.keys.Back, "cancel"))))
+	}
+	if m.bulkError != "" {
+		content.WriteString("\n\n" + fg(theme.Danger, m.bulkError))
+	}
+
+	box := boxStyle.Render(content.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
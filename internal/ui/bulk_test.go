@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+	"taskflow/internal/tasks"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestBulkArchiveRollsBackAndUndoes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+	viper.Reset()
+	defer viper.Reset()
+	dir := t.TempDir()
+	viper.Set("storage.dir", dir)
+	viper.Set("storage.archive_file", "archive.yaml")
+
+	path := filepath.Join(dir, "tasks.yaml")
+	st, _ := storage.NewStorage(path)
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Old", Status: "done"}, {ID: "2", Title: "New", Status: "to-do"}}); err != nil {
+		t.Fatal(err)
+	}
+	archive, _ := storage.NewStorage(filepath.Join(dir, "archive.yaml"))
+	archiveOne := func(m *Model) {
+		m.bulkTargets = map[string]bool{"1": true}
+		m.bulkAction = tasks.BulkArchive
+		m.bulkError = ""
+		m.runBulk("")
+	}
+
+	// A failed write of the tasks file leaves the archive alone.
+	vetoed, _ := storage.NewStorage(path)
+	vetoed.WithOptions(storage.Options{BeforeWrite: func(map[string]*models.Task, []models.Task) error {
+		return errors.New("tasks file is locked")
+	}})
+	m := New(vetoed, "", path)
+	archiveOne(m)
+	if got, _ := archive.ReadTasks(); m.bulkError == "" || len(got) != 0 {
+		t.Fatalf("archive written although the tasks write failed: %q %+v", m.bulkError, got)
+	}
+
+	// A vetoed archive leaves the task where it was.
+	viper.Set("hooks.on_archive", "exit 1")
+	m = New(st, "", path)
+	archiveOne(m)
+	if m.bulkError == "" {
+		t.Fatal("expected on_archive veto")
+	}
+	if got, _ := st.ReadTasks(); len(got) != 2 {
+		t.Fatalf("tasks file not restored: %+v", got)
+	}
+	if got, _ := archive.ReadTasks(); len(got) != 0 {
+		t.Fatalf("vetoed archive wrote tasks: %+v", got)
+	}
+
+	viper.Set("hooks.on_archive", "")
+	archiveOne(m)
+	if got, _ := st.ReadTasks(); m.bulkError != "" || len(got) != 1 {
+		t.Fatalf("archive failed: %s %+v", m.bulkError, got)
+	}
+
+	// Undo restores the backup; archiving again does not duplicate the task.
+	data, _ := os.ReadFile(path + ".bak")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	m = New(st, "", path)
+	archiveOne(m)
+	if got, _ := archive.ReadTasks(); len(got) != 1 {
+		t.Fatalf("task archived twice: %+v", got)
+	}
+}
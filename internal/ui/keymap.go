@@ -1,3 +1,327 @@
 package ui
 
-// Key bindings and mode-specific handlers will be defined here later.
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap holds every binding the interactive UI reacts to. Dropdowns and
+// forms reuse the list bindings (Up/Down/Open/Back) so a remapped key works
+// the same way everywhere.
+type KeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Top      key.Binding
+	Bottom   key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+
+	ToggleStatus key.Binding
+	Add          key.Binding
+	Open         key.Binding
+	Editor       key.Binding
+	Delete       key.Binding
+	Archive      key.Binding
+
+	Filter      key.Binding
+	ClearFilter key.Binding
+	Sort        key.Binding
+	HistoryPrev key.Binding
+	HistoryNext key.Binding
+
+	Board     key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+
+	Agenda     key.Binding
+	AgendaSpan key.Binding
+	Today      key.Binding
+
+	Select       key.Binding
+	VisualSelect key.Binding
+	SelectAll    key.Binding
+	Bulk         key.Binding
+
+	Back    key.Binding
+	Save    key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+// keyAction ties a config name (ui.keys.<name>) to a binding and its help text.
+type keyAction struct {
+	name    string
+	desc    string
+	binding func(*KeyMap) *key.Binding
+}
+
+var keyActions = []keyAction{
+	{"up", "Move cursor up", func(k *KeyMap) *key.Binding { return &k.Up }},
+	{"down", "Move cursor down", func(k *KeyMap) *key.Binding { return &k.Down }},
+	{"page_up", "Move up a page", func(k *KeyMap) *key.Binding { return &k.PageUp }},
+	{"page_down", "Move down a page", func(k *KeyMap) *key.Binding { return &k.PageDown }},
+	{"top", "Go to first task", func(k *KeyMap) *key.Binding { return &k.Top }},
+	{"bottom", "Go to last task", func(k *KeyMap) *key.Binding { return &k.Bottom }},
+	{"left", "Previous board column", func(k *KeyMap) *key.Binding { return &k.Left }},
+	{"right", "Next board column", func(k *KeyMap) *key.Binding { return &k.Right }},
+	{"help", "Show this help screen", func(k *KeyMap) *key.Binding { return &k.Help }},
+	{"quit", "Quit application", func(k *KeyMap) *key.Binding { return &k.Quit }},
+	{"toggle_status", "Cycle status (to-do → in-progress → on-hold → done)", func(k *KeyMap) *key.Binding { return &k.ToggleStatus }},
+	{"add", "Add new task", func(k *KeyMap) *key.Binding { return &k.Add }},
+	{"open", "Open task details / edit field", func(k *KeyMap) *key.Binding { return &k.Open }},
+	{"editor", "Edit notes (or description) in $EDITOR", func(k *KeyMap) *key.Binding { return &k.Editor }},
+	{"delete", "Delete task", func(k *KeyMap) *key.Binding { return &k.Delete }},
+	{"archive", "Archive task", func(k *KeyMap) *key.Binding { return &k.Archive }},
+	{"filter", "Live fuzzy search (#tag @status !priority)", func(k *KeyMap) *key.Binding { return &k.Filter }},
+	{"clear_filter", "Clear filter", func(k *KeyMap) *key.Binding { return &k.ClearFilter }},
+	{"history_prev", "Recall the previous search (while searching)", func(k *KeyMap) *key.Binding { return &k.HistoryPrev }},
+	{"history_next", "Recall the next search (while searching)", func(k *KeyMap) *key.Binding { return &k.HistoryNext }},
+	{"sort", "Cycle sort (priority → status → due → updated → title → none)", func(k *KeyMap) *key.Binding { return &k.Sort }},
+	{"board", "Toggle board (kanban) view", func(k *KeyMap) *key.Binding { return &k.Board }},
+	{"move_left", "Move card to previous status", func(k *KeyMap) *key.Binding { return &k.MoveLeft }},
+	{"move_right", "Move card to next status", func(k *KeyMap) *key.Binding { return &k.MoveRight }},
+	{"agenda", "Toggle agenda view", func(k *KeyMap) *key.Binding { return &k.Agenda }},
+	{"agenda_span", "Switch agenda between day and week", func(k *KeyMap) *key.Binding { return &k.AgendaSpan }},
+	{"today", "Jump agenda to today", func(k *KeyMap) *key.Binding { return &k.Today }},
+	{"select", "Mark/unmark task", func(k *KeyMap) *key.Binding { return &k.Select }},
+	{"visual_select", "Start range selection, again to keep it", func(k *KeyMap) *key.Binding { return &k.VisualSelect }},
+	{"select_all", "Select all tasks in view (again to clear)", func(k *KeyMap) *key.Binding { return &k.SelectAll }},
+	{"bulk", "Bulk actions on the selection", func(k *KeyMap) *key.Binding { return &k.Bulk }},
+	{"back", "Close view / cancel / clear selection", func(k *KeyMap) *key.Binding { return &k.Back }},
+	{"save", "Save new task", func(k *KeyMap) *key.Binding { return &k.Save }},
+	{"confirm", "Confirm", func(k *KeyMap) *key.Binding { return &k.Confirm }},
+	{"cancel", "Cancel", func(k *KeyMap) *key.Binding { return &k.Cancel }},
+}
+
+// defaultKeys are the bindings of the "default" preset.
+var defaultKeys = map[string][]string{
+	"up":            {"up", "k"},
+	"down":          {"down", "j"},
+	"page_up":       {"pgup"},
+	"page_down":     {"pgdown"},
+	"top":           {"home", "g"},
+	"bottom":        {"end", "G"},
+	"left":          {"left"},
+	"right":         {"right", "l"},
+	"help":          {"h", "?"},
+	"quit":          {"q", "ctrl+c"},
+	"toggle_status": {"x"},
+	"add":           {"a"},
+	"open":          {"enter", "e"},
+	"editor":        {"E"},
+	"delete":        {"d"},
+	"archive":       {"A"},
+	"filter":        {"/"},
+	"clear_filter":  {"c"},
+	"sort":          {"s"},
+	"history_prev":  {"ctrl+p"},
+	"history_next":  {"ctrl+n"},
+	"board":         {"tab"},
+	"move_left":     {"<", "shift+left"},
+	"move_right":    {">", "shift+right"},
+	"agenda":        {"C"},
+	"agenda_span":   {"w"},
+	"today":         {"."},
+	"select":        {" "},
+	"visual_select": {"V"},
+	"select_all":    {"*"},
+	"bulk":          {"b"},
+	"back":          {"esc"},
+	"save":          {"ctrl+s"},
+	"confirm":       {"y", "Y"},
+	"cancel":        {"n", "N", "esc"},
+}
+
+// keyPresets only list the bindings that differ from defaultKeys.
+var keyPresets = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"page_up":   {"ctrl+u", "pgup"},
+		"page_down": {"ctrl+d", "pgdown"},
+		"top":       {"g", "home"},
+		"bottom":    {"G", "end"},
+		"left":      {"h", "left"},
+		"help":      {"?"},
+	},
+	"emacs": {
+		"up":           {"ctrl+p", "up"},
+		"down":         {"ctrl+n", "down"},
+		"history_prev": {"alt+p"},
+		"history_next": {"alt+n"},
+		"page_up":      {"alt+v", "pgup"},
+		"page_down":    {"ctrl+v", "pgdown"},
+		"top":          {"alt+<", "home"},
+		"bottom":       {"alt+>", "end"},
+		"left":         {"ctrl+b", "left"},
+		"right":        {"ctrl+f", "right"},
+		"help":         {"ctrl+h", "?"},
+		"quit":         {"ctrl+c", "q"},
+		"back":         {"esc", "ctrl+g"},
+		"cancel":       {"n", "N", "esc", "ctrl+g"},
+	},
+}
+
+// KeyPresets returns the names of the built-in keymap presets.
+func KeyPresets() []string {
+	var names []string
+	for n := range keyPresets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sharedKeys lists the actions that may be bound to the same key because they
+// never apply at the same time (back closes views, cancel answers prompts).
+var sharedKeys = map[[2]string]bool{{"back", "cancel"}: true}
+
+// NewKeyMap builds the keymap for a preset ("" means default) with per-action
+// overrides keyed by config name, e.g. {"toggle_status": {"t"}}. An override
+// that binds a key another action already uses is rejected.
+func NewKeyMap(preset string, overrides map[string][]string) (KeyMap, error) {
+	if preset == "" {
+		preset = "default"
+	}
+	presetKeys, ok := keyPresets[preset]
+	if !ok {
+		return KeyMap{}, fmt.Errorf("unknown keymap preset %q (want one of %s)", preset, strings.Join(KeyPresets(), ", "))
+	}
+	known := map[string]bool{}
+	for _, a := range keyActions {
+		known[a.name] = true
+	}
+	for name := range overrides {
+		if !known[name] {
+			return KeyMap{}, fmt.Errorf("unknown key action %q in ui.keys", name)
+		}
+	}
+
+	var km KeyMap
+	for _, a := range keyActions {
+		keys := defaultKeys[a.name]
+		if k, ok := presetKeys[a.name]; ok {
+			keys = k
+		}
+		if k, ok := overrides[a.name]; ok && len(k) > 0 {
+			keys = make([]string, len(k))
+			for i, v := range k {
+				if v == "space" {
+					v = " "
+				}
+				keys[i] = v
+			}
+		}
+		*a.binding(&km) = key.NewBinding(key.WithKeys(keys...), key.WithHelp(helpKeys(keys), a.desc))
+	}
+
+	owner := map[string]string{}
+	for _, a := range keyActions {
+		for _, k := range a.binding(&km).Keys() {
+			o, taken := owner[k]
+			if !taken {
+				owner[k] = a.name
+				continue
+			}
+			_, overridden := overrides[a.name]
+			_, otherOverridden := overrides[o]
+			if (overridden || otherOverridden) && !sharedKeys[[2]string{o, a.name}] {
+				return KeyMap{}, fmt.Errorf("key %q is bound to both %s and %s in ui.keys", helpKeys([]string{k}), o, a.name)
+			}
+		}
+	}
+	return km, nil
+}
+
+// bindingHint renders b's keys as in the help screen with a label for
+// prompts, e.g. "y/Y:confirm".
+func bindingHint(b key.Binding, label string) string {
+	return b.Help().Key + ":" + label
+}
+
+// helpKeys renders keys for the help screen, e.g. "↑/k".
+func helpKeys(keys []string) string {
+	names := map[string]string{"up": "↑", "down": "↓", "left": "←", "right": "→", " ": "space"}
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		if n, ok := names[k]; ok {
+			k = n
+		}
+		out[i] = k
+	}
+	return strings.Join(out, "/")
+}
+
+// keyHint renders the first key of b with a label for status bars, e.g. "q:quit".
+func keyHint(b key.Binding, label string) string {
+	keys := b.Keys()
+	if len(keys) == 0 {
+		return ""
+	}
+	return helpKeys(keys[:1]) + ":" + label
+}
+
+// inputKeys narrows b to the keys that cannot be typed into a text input
+// (arrows, enter, esc, ctrl/alt combinations), so a text input can react to
+// a binding without swallowing letters such as "k" or "e".
+func inputKeys(b key.Binding) key.Binding {
+	var keys []string
+	for _, k := range b.Keys() {
+		if len([]rune(k)) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(helpKeys(keys), b.Help().Desc))
+}
+
+// helpSection is a titled group of bindings shown on the help screen. A
+// non-empty desc replaces the binding's own description.
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+type helpEntry struct {
+	binding key.Binding
+	desc    string
+}
+
+// helpSections lays out the help screen from the active bindings.
+func (k KeyMap) helpSections() []helpSection {
+	e := func(b key.Binding, desc string) helpEntry { return helpEntry{b, desc} }
+	return []helpSection{
+		{"Navigation:", []helpEntry{e(k.Up, ""), e(k.Down, ""), e(k.PageUp, ""), e(k.PageDown, ""), e(k.Top, ""), e(k.Bottom, ""), e(k.Help, ""), e(k.Quit, "")}},
+		{"Task Actions:", []helpEntry{e(k.ToggleStatus, ""), e(k.Add, ""), e(k.Open, "Edit task details"), e(k.Delete, ""), e(k.Archive, "")}},
+		{"Filtering & Sorting:", []helpEntry{e(k.Filter, ""), e(k.HistoryPrev, ""), e(k.HistoryNext, ""), e(k.ClearFilter, ""), e(k.Sort, "")}},
+		{"Board View:", []helpEntry{e(k.Board, ""), e(k.Left, ""), e(k.Right, ""), e(k.Up, "Previous card"), e(k.Down, "Next card"), e(k.MoveLeft, ""), e(k.MoveRight, "")}},
+		{"Agenda View:", []helpEntry{e(k.Agenda, ""), e(k.Left, "Previous day/week"), e(k.Right, "Next day/week"), e(k.AgendaSpan, ""), e(k.Today, ""), e(k.Open, "Open the task (or the event's linked task)")}},
+		{"Selection & Bulk Actions:", []helpEntry{e(k.Select, ""), e(k.VisualSelect, ""), e(k.SelectAll, ""), e(k.Bulk, ""), e(k.Back, "Clear selection")}},
+		{"Detail View:", []helpEntry{e(k.Up, "Previous field"), e(k.Down, "Next field"), e(k.Open, "Edit current field"), e(k.Editor, "Edit notes in $EDITOR"), e(k.PageUp, "Scroll notes up"), e(k.PageDown, "Scroll notes down"), e(k.Back, "Close detail view")}},
+		{"Add Task View:", []helpEntry{e(k.Up, "Previous field"), e(k.Down, "Next field"), e(k.Open, "Edit current field"), e(k.Save, ""), e(k.Back, "Cancel")}},
+	}
+}
+
+// helpLines renders the help sections as text lines.
+func (k KeyMap) helpLines(heading func(string) string) []string {
+	var lines []string
+	for i, s := range k.helpSections() {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, heading(s.title))
+		for _, en := range s.entries {
+			desc := en.desc
+			if desc == "" {
+				desc = en.binding.Help().Desc
+			}
+			lines = append(lines, fmt.Sprintf("  %-14s %s", en.binding.Help().Key, desc))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+	"taskflow/internal/tasks"
+	"testing"
+)
+
+func TestNewKeyMapRejectsConflictingOverrides(t *testing.T) {
+	if _, err := NewKeyMap("default", map[string][]string{"toggle_status": {"d"}}); err == nil || !strings.Contains(err.Error(), "toggle_status") {
+		t.Fatalf("expected a conflict with delete, got %v", err)
+	}
+	if _, err := NewKeyMap("vim", map[string][]string{"toggle_status": {"d"}, "delete": {"x"}}); err != nil {
+		t.Fatalf("swapping keys should work: %v", err)
+	}
+	if _, err := NewKeyMap("emacs", map[string][]string{"cancel": {"ctrl+g"}}); err != nil {
+		t.Fatalf("cancel may share keys with back: %v", err)
+	}
+}
+
+func TestBulkHintsFollowKeymap(t *testing.T) {
+	keys, err := NewKeyMap("default", map[string][]string{"confirm": {"o"}, "cancel": {"esc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Model{keys: keys, bulkStage: bulkStageConfirm, bulkAction: tasks.BulkArchive}
+	if box := m.renderBulkBox(); !strings.Contains(box, "o:confirm") || !strings.Contains(box, "esc:cancel") {
+		t.Fatalf("confirm hint not built from the keymap:\n%s", box)
+	}
+}
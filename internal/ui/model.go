@@ -3,23 +3,26 @@ package ui
 import (
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 	"taskflow/internal/config"
 	"taskflow/internal/models"
 	"taskflow/internal/storage"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"gopkg.in/yaml.v3"
+	"github.com/google/uuid"
 )
 
 // Model is the root Bubble Tea model for the new interactive UI.
 type Model struct {
 	storage     *storage.Storage
 	initialHash string
+	keys        KeyMap
 
 	width, height int
 
@@ -30,14 +33,28 @@ type Model struct {
 
 	// Filter / search
 	filterActive   bool
-	filterKind     string
-	filterValue    string
+	filterValue    string // search query, see tasks.ParseQuery
+	filterBefore   string // query restored when a search is cancelled
 	enteringFilter bool
 	filterInput    textinput.Model
+	matches        map[string][]int // task ID -> matched title runes
+	searchHistory  []string         // recent searches, newest first
+	historyIndex   int              // position while recalling history, -1 for none
+
+	// Board (kanban) mode
+	boardMode bool
+
+	// Agenda mode
+	agendaMode   bool
+	agendaWeek   bool      // week view instead of a single day
+	agendaDay    time.Time // day shown (or a day in the week shown)
+	agendaCursor int
+	events       []models.CalendarEvent
+	calendarMod  time.Time
 
 	// Sort
 	sortActive bool
-	sortKind   string // Priority | Status
+	sortKind   string // a tasks.ParseSort spec from sortCycle
 
 	// Detail box edit mode
 	viewingDetail     bool
@@ -45,7 +62,10 @@ type Model struct {
 	editingField      bool
 	editInput         textinput.Model
 	detailTask        *models.Task
-	selectingStatus   bool // for status dropdown
+	notesScroll       int      // first rendered notes line shown
+	notesSource       string   // cache key of notesRendered
+	notesRendered     []string // Markdown-rendered notes
+	selectingStatus   bool     // for status dropdown
 	statusCursor      int
 	selectingPriority bool // for priority dropdown
 	priorityCursor    int
@@ -64,6 +84,21 @@ type Model struct {
 	confirmingDelete bool
 	taskToDelete     *models.Task
 
+	// Multi-select
+	selected     map[string]bool // marked task IDs
+	visualMode   bool            // range selection from visualAnchor to cursor
+	visualAnchor int
+
+	// Bulk actions
+	bulkMenu         bool
+	bulkStage        int
+	bulkCursor       int
+	bulkChoiceCursor int
+	bulkAction       tasks.BulkAction
+	bulkTargets      map[string]bool
+	bulkError        string
+	statusMessage    string // shown in the status bar until the next key
+
 	// Help screen mode
 	showingHelp      bool
 	helpScrollOffset int
@@ -76,9 +111,16 @@ type Model struct {
 	quitMessage string
 }
 
-var fieldNames = []string{"Title", "Status", "Priority", "Link", "Tags", "Notes", "DueDate"}
-var statusOptions = []string{"to-do", "in-progress", "on-hold", "done"}
-var priorityOptions = []string{"high", "medium", "low"}
+var fieldNames = []string{"Title", "Description", "Status", "Priority", "Link", "Tags", "Notes", "DueDate", "Estimate"}
+
+// statusOptions and priorityOptions are the choices offered in pickers, in
+// workflow order.
+func statusOptions() []string   { return workflow.Current().StatusNames() }
+func priorityOptions() []string { return workflow.Current().PriorityNames() }
+
+// sortCycle lists the orders the sort key steps through before returning to
+// the file order.
+var sortCycle = []string{"priority,due", "status,priority", "due,priority", "-updated", "title"}
 
 // New constructs a new Model.
 func New(s *storage.Storage, initialHash string, storagePath string) *Model {
@@ -93,9 +135,32 @@ func New(s *storage.Storage, initialHash string, storagePath string) *Model {
 	if fi, err := os.Stat(storagePath); err == nil {
 		m.lastMod = fi.ModTime()
 	}
+	m.loadKeysAndTheme()
 	return m
 }
 
+// loadKeysAndTheme applies ui.keymap/ui.keys and ui.theme/ui.colors from the
+// config, falling back to the defaults (and saying so in the status bar) when
+// the configuration is invalid.
+func (m *Model) loadKeysAndTheme() {
+	var problems []string
+	keys, err := NewKeyMap(config.GetUIKeymap(), config.GetUIKeys())
+	if err != nil {
+		problems = append(problems, err.Error())
+		keys, _ = NewKeyMap("default", nil)
+	}
+	m.keys = keys
+	t, err := NewTheme(config.GetUITheme(), config.GetUIColors())
+	if err != nil {
+		problems = append(problems, err.Error())
+		t = themes["dark"]
+	}
+	useTheme(t)
+	if len(problems) > 0 {
+		m.statusMessage = "config: " + strings.Join(problems, "; ")
+	}
+}
+
 // polling message
 type filePollMsg struct{}
 
@@ -109,8 +174,9 @@ func (m *Model) Init() tea.Cmd {
 	m.editInput.Prompt = "> "
 
 	m.filterInput = textinput.New()
-	m.filterInput.Prompt = "Filter: "
-	m.filterInput.Placeholder = "Search title or tags..."
+	m.filterInput.Prompt = "Search: "
+	m.filterInput.Placeholder = "fuzzy words, #tag, @status, !priority"
+	m.loadSearchHistory()
 
 	return tea.Batch(pollFileCmd(), tea.EnterAltScreen)
 }
@@ -129,7 +195,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.rebuild("")
 			}
 		}
+		if m.agendaMode {
+			m.loadEvents()
+		}
 		return m, pollFileCmd()
+	case editorFinishedMsg:
+		m.applyEditorResult(msg)
+		return m, nil
 	case tea.KeyMsg:
 		if m.enteringFilter {
 			return m.handleFilterInputKey(msg)
@@ -140,6 +212,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.confirmingDelete {
 			return m.handleConfirmDeleteKey(msg)
 		}
+		if m.bulkMenu {
+			return m.handleBulkKey(msg)
+		}
 		if m.addingTask {
 			return m.handleAddTaskKey(msg)
 		}
@@ -152,25 +227,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleDetailKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.statusMessage = ""
 	// Selecting status from dropdown
 	if m.selectingStatus {
-		switch k.String() {
-		case "esc":
+		switch {
+		case key.Matches(k, m.keys.Back):
 			m.selectingStatus = false
 			return m, nil
-		case "up", "k":
+		case key.Matches(k, m.keys.Up):
 			if m.statusCursor > 0 {
 				m.statusCursor--
 			}
-		case "down", "j":
-			if m.statusCursor < len(statusOptions)-1 {
+		case key.Matches(k, m.keys.Down):
+			if m.statusCursor < len(statusOptions())-1 {
 				m.statusCursor++
 			}
-		case "enter":
+		case key.Matches(k, m.keys.Open):
 			// Apply selected status
-			m.detailTask.Status = statusOptions[m.statusCursor]
-			m.storage.UpdateTask(m.allTasks, *m.detailTask)
-			m.reloadAfterMutation(m.detailTask.ID)
+			tasks.SetStatus(m.detailTask, statusOptions()[m.statusCursor], time.Now())
+			m.saveTask(*m.detailTask)
 			m.selectingStatus = false
 		}
 		return m, nil
@@ -178,23 +253,22 @@ func (m *Model) handleDetailKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Selecting priority from dropdown
 	if m.selectingPriority {
-		switch k.String() {
-		case "esc":
+		switch {
+		case key.Matches(k, m.keys.Back):
 			m.selectingPriority = false
 			return m, nil
-		case "up", "k":
+		case key.Matches(k, m.keys.Up):
 			if m.priorityCursor > 0 {
 				m.priorityCursor--
 			}
-		case "down", "j":
-			if m.priorityCursor < len(priorityOptions)-1 {
+		case key.Matches(k, m.keys.Down):
+			if m.priorityCursor < len(priorityOptions())-1 {
 				m.priorityCursor++
 			}
-		case "enter":
+		case key.Matches(k, m.keys.Open):
 			// Apply selected priority
-			m.detailTask.Priority = priorityOptions[m.priorityCursor]
-			m.storage.UpdateTask(m.allTasks, *m.detailTask)
-			m.reloadAfterMutation(m.detailTask.ID)
+			m.detailTask.Priority = priorityOptions()[m.priorityCursor]
+			m.saveTask(*m.detailTask)
 			m.selectingPriority = false
 		}
 		return m, nil
@@ -218,28 +292,39 @@ func (m *Model) handleDetailKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// navigating detail box fields
-	switch k.String() {
-	case "esc", "q":
+	switch {
+	case key.Matches(k, m.keys.Back, m.keys.Quit):
 		m.viewingDetail = false
 		m.detailTask = nil
 		m.detailFieldIndex = 0
+		m.notesScroll = 0
 		return m, nil
-	case "up", "k":
+	case key.Matches(k, m.keys.PageUp):
+		m.notesScroll -= notesHeight / 2
+	case key.Matches(k, m.keys.PageDown):
+		m.notesScroll += notesHeight / 2 // clamped when rendered
+	case key.Matches(k, m.keys.Editor):
+		field := fieldNames[m.detailFieldIndex]
+		if field != "Description" {
+			field = "Notes"
+		}
+		return m, m.openEditor(field)
+	case key.Matches(k, m.keys.Up):
 		if m.detailFieldIndex > 0 {
 			m.detailFieldIndex--
 		}
-	case "down", "j":
+	case key.Matches(k, m.keys.Down):
 		if m.detailFieldIndex < len(fieldNames)-1 {
 			m.detailFieldIndex++
 		}
-	case "enter", "e":
+	case key.Matches(k, m.keys.Open):
 		// start editing current field
 		fieldName := fieldNames[m.detailFieldIndex]
 		if fieldName == "Status" {
 			// Show status dropdown
 			m.selectingStatus = true
 			// Set cursor to current status
-			for i, status := range statusOptions {
+			for i, status := range statusOptions() {
 				if status == m.detailTask.Status {
 					m.statusCursor = i
 					break
@@ -249,12 +334,15 @@ func (m *Model) handleDetailKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Show priority dropdown
 			m.selectingPriority = true
 			// Set cursor to current priority
-			for i, priority := range priorityOptions {
+			for i, priority := range priorityOptions() {
 				if priority == m.detailTask.Priority {
 					m.priorityCursor = i
 					break
 				}
 			}
+		} else if (fieldName == "Notes" || fieldName == "Description") && strings.Contains(m.getFieldValue(fieldName), "\n") {
+			// Multi-line text would be flattened by the single-line input
+			return m, m.openEditor(fieldName)
 		} else {
 			// Normal text editing for other fields
 			m.editingField = true
@@ -268,21 +356,21 @@ func (m *Model) handleDetailKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleAddTaskKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Selecting status from dropdown
 	if m.addSelectingStatus {
-		switch k.String() {
-		case "esc":
+		switch {
+		case key.Matches(k, m.keys.Back):
 			m.addSelectingStatus = false
 			return m, nil
-		case "up", "k":
+		case key.Matches(k, m.keys.Up):
 			if m.addStatusCursor > 0 {
 				m.addStatusCursor--
 			}
-		case "down", "j":
-			if m.addStatusCursor < len(statusOptions)-1 {
+		case key.Matches(k, m.keys.Down):
+			if m.addStatusCursor < len(statusOptions())-1 {
 				m.addStatusCursor++
 			}
-		case "enter":
+		case key.Matches(k, m.keys.Open):
 			// Apply selected status
-			m.newTask.Status = statusOptions[m.addStatusCursor]
+			m.newTask.Status = statusOptions()[m.addStatusCursor]
 			m.addSelectingStatus = false
 		}
 		return m, nil
@@ -290,21 +378,21 @@ func (m *Model) handleAddTaskKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Selecting priority from dropdown
 	if m.addSelectingPriority {
-		switch k.String() {
-		case "esc":
+		switch {
+		case key.Matches(k, m.keys.Back):
 			m.addSelectingPriority = false
 			return m, nil
-		case "up", "k":
+		case key.Matches(k, m.keys.Up):
 			if m.addPriorityCursor > 0 {
 				m.addPriorityCursor--
 			}
-		case "down", "j":
-			if m.addPriorityCursor < len(priorityOptions)-1 {
+		case key.Matches(k, m.keys.Down):
+			if m.addPriorityCursor < len(priorityOptions())-1 {
 				m.addPriorityCursor++
 			}
-		case "enter":
+		case key.Matches(k, m.keys.Open):
 			// Apply selected priority
-			m.newTask.Priority = priorityOptions[m.addPriorityCursor]
+			m.newTask.Priority = priorityOptions()[m.addPriorityCursor]
 			m.addSelectingPriority = false
 		}
 		return m, nil
@@ -328,29 +416,29 @@ func (m *Model) handleAddTaskKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// navigating add task form fields
-	switch k.String() {
-	case "esc":
+	switch {
+	case key.Matches(k, m.keys.Back):
 		// cancel adding task
 		m.addingTask = false
 		m.addFieldIndex = 0
 		m.newTask = models.Task{}
 		return m, nil
-	case "up", "k":
+	case key.Matches(k, m.keys.Up):
 		if m.addFieldIndex > 0 {
 			m.addFieldIndex--
 		}
-	case "down", "j":
+	case key.Matches(k, m.keys.Down):
 		if m.addFieldIndex < len(fieldNames)-1 {
 			m.addFieldIndex++
 		}
-	case "enter", "e":
+	case key.Matches(k, m.keys.Open):
 		// start editing current field
 		fieldName := fieldNames[m.addFieldIndex]
 		if fieldName == "Status" {
 			// Show status dropdown
 			m.addSelectingStatus = true
 			// Set cursor to current status
-			for i, status := range statusOptions {
+			for i, status := range statusOptions() {
 				if status == m.newTask.Status {
 					m.addStatusCursor = i
 					break
@@ -360,7 +448,7 @@ func (m *Model) handleAddTaskKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Show priority dropdown
 			m.addSelectingPriority = true
 			// Set cursor to current priority
-			for i, priority := range priorityOptions {
+			for i, priority := range priorityOptions() {
 				if priority == m.newTask.Priority {
 					m.addPriorityCursor = i
 					break
@@ -372,16 +460,24 @@ func (m *Model) handleAddTaskKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.editInput.SetValue(m.getAddFieldValue(fieldName))
 			m.editInput.Focus()
 		}
-	case "ctrl+s":
+	case key.Matches(k, m.keys.Save):
 		// save the task
 		if m.newTask.Title == "" {
 			// Title is required, don't save
 			return m, nil
 		}
-		tasks := append(m.allTasks, m.newTask)
-		if err := m.storage.WriteTasks(tasks); err == nil {
-			m.allTasks = tasks
+		now := time.Now().UTC().Format(time.RFC3339)
+		m.newTask.CreatedAt = now
+		m.newTask.UpdatedAt = now
+		if workflow.Current().IsTerminal(m.newTask.Status) {
+			m.newTask.CompletedAt = now
+		}
+		all := append(m.allTasks, m.newTask)
+		if err := m.storage.WriteTasks(all); err == nil {
+			m.allTasks = all
 			m.reloadAfterMutation(m.newTask.ID)
+		} else {
+			m.statusMessage = err.Error()
 		}
 		// reset add mode
 		m.addingTask = false
@@ -392,8 +488,8 @@ func (m *Model) handleAddTaskKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleConfirmDeleteKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch k.String() {
-	case "y", "Y":
+	switch {
+	case key.Matches(k, m.keys.Confirm):
 		// Confirm delete
 		if m.taskToDelete != nil {
 			// Remove task from allTasks
@@ -407,12 +503,14 @@ func (m *Model) handleConfirmDeleteKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if err := m.storage.WriteTasks(newTasks); err == nil {
 				m.allTasks = newTasks
 				m.rebuild("")
+			} else {
+				m.statusMessage = err.Error()
 			}
 		}
 		// Reset confirmation state
 		m.confirmingDelete = false
 		m.taskToDelete = nil
-	case "n", "N", "esc":
+	case key.Matches(k, m.keys.Cancel):
 		// Cancel delete
 		m.confirmingDelete = false
 		m.taskToDelete = nil
@@ -420,45 +518,16 @@ func (m *Model) handleConfirmDeleteKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *Model) handleFilterInputKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch k.Type {
-	case tea.KeyEsc:
-		// Cancel filter input
-		m.enteringFilter = false
-		m.filterInput.Blur()
-		return m, nil
-	case tea.KeyEnter:
-		// Apply filter
-		val := strings.TrimSpace(m.filterInput.Value())
-		if val == "" {
-			m.filterActive = false
-			m.filterValue = ""
-		} else {
-			m.filterActive = true
-			m.filterKind = "Title Contains"
-			m.filterValue = val
-		}
-		m.rebuild("")
-		m.enteringFilter = false
-		m.filterInput.Blur()
-		return m, nil
-	}
-	// Pass input to the text input bubble
-	var cmd tea.Cmd
-	m.filterInput, cmd = m.filterInput.Update(k)
-	return m, cmd
-}
-
 func (m *Model) handleHelpKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch k.String() {
-	case "esc", "q", "h", "?":
+	switch {
+	case key.Matches(k, m.keys.Back, m.keys.Quit, m.keys.Help):
 		m.showingHelp = false
 		m.helpScrollOffset = 0 // reset scroll when closing
-	case "up", "k":
+	case key.Matches(k, m.keys.Up):
 		if m.helpScrollOffset > 0 {
 			m.helpScrollOffset--
 		}
-	case "down", "j":
+	case key.Matches(k, m.keys.Down):
 		m.helpScrollOffset++
 		// The render function will clamp this to valid bounds
 	}
@@ -466,83 +535,103 @@ func (m *Model) handleHelpKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleListKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
-	key := k.String()
-	switch key {
-	case "ctrl+c", "q":
+	m.statusMessage = ""
+	if m.agendaMode {
+		if handled, cmd := m.handleAgendaKey(k); handled {
+			return m, cmd
+		}
+	} else if m.boardMode {
+		if handled, cmd := m.handleBoardKey(k); handled {
+			return m, cmd
+		}
+	}
+	switch {
+	case key.Matches(k, m.keys.Agenda):
+		m.openAgenda()
+	case key.Matches(k, m.keys.Board):
+		m.boardMode = !m.boardMode
+		m.visualMode = false
+	case key.Matches(k, m.keys.Select): // mark task
+		m.toggleSelect()
+	case key.Matches(k, m.keys.VisualSelect): // start/commit range selection
+		m.toggleVisual()
+	case key.Matches(k, m.keys.SelectAll): // select all in view
+		m.toggleSelectAll()
+	case key.Matches(k, m.keys.Bulk): // bulk actions on selection
+		m.openBulkMenu()
+	case key.Matches(k, m.keys.Back):
+		m.clearSelection()
+	case key.Matches(k, m.keys.Quit):
 		m.quitMessage = "👋 Goodbye"
 		return m, tea.Quit
-	case "h", "?": // show help
+	case key.Matches(k, m.keys.Help): // show help
 		m.showingHelp = true
 		return m, nil
-	case "up", "k":
+	case key.Matches(k, m.keys.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
-	case "down", "j":
+	case key.Matches(k, m.keys.Down):
 		if m.cursor < len(m.view)-1 {
 			m.cursor++
 		}
-	case "x": // toggle status
+	case key.Matches(k, m.keys.PageUp):
+		m.cursor -= m.pageSize()
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+	case key.Matches(k, m.keys.PageDown):
+		m.cursor += m.pageSize()
+		if m.cursor > len(m.view)-1 {
+			m.cursor = len(m.view) - 1
+		}
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+	case key.Matches(k, m.keys.Top):
+		m.cursor = 0
+	case key.Matches(k, m.keys.Bottom):
+		if len(m.view) > 0 {
+			m.cursor = len(m.view) - 1
+		}
+	case key.Matches(k, m.keys.ToggleStatus): // toggle status
 		if len(m.view) > 0 {
 			t := &m.view[m.cursor]
-			// Cycle through: to-do -> in-progress -> on-hold -> done -> to-do
-			switch t.Status {
-			case "to-do":
-				t.Status = "in-progress"
-			case "in-progress":
-				t.Status = "on-hold"
-			case "on-hold":
-				t.Status = "done"
-			case "done":
-				t.Status = "to-do"
-			default:
-				t.Status = "to-do"
-			}
-			m.storage.UpdateTask(m.allTasks, *t)
-			m.reloadAfterMutation(t.ID)
+			// Cycle through the workflow's statuses, skipping disallowed transitions
+			tasks.SetStatus(t, workflow.Current().Next(t.Status), time.Now())
+			m.saveTask(*t)
 		}
-	case "/": // text filter
-		// Enter filter input mode
-		m.enteringFilter = true
-		m.filterInput.SetValue(m.filterValue)
-		m.filterInput.Focus()
+	case key.Matches(k, m.keys.Filter): // text filter
+		m.openSearch()
 		return m, nil
-	case "c": // clear filter
+	case key.Matches(k, m.keys.ClearFilter): // clear filter
 		if m.filterActive {
 			m.filterActive = false
 			m.filterValue = ""
 			m.filterInput.SetValue("")
 			m.rebuild("")
 		}
-	case "s": // cycle sort
-		if !m.sortActive {
-			m.sortActive = true
-			m.sortKind = "Priority"
-		} else if m.sortKind == "Priority" {
-			m.sortKind = "Status"
-		} else {
-			m.sortActive = false
-			m.sortKind = ""
-		}
+	case key.Matches(k, m.keys.Sort): // cycle sort
+		m.cycleSort()
 		m.rebuild("")
-	case "a": // add task
+	case key.Matches(k, m.keys.Add): // add task
 		// Initialize new task with defaults
 		m.newTask = models.Task{
-			ID:       fmt.Sprintf("%d", time.Now().UnixNano()),
-			Status:   "to-do",
-			Priority: "medium",
+			ID:       uuid.New().String(),
+			Status:   workflow.Current().Initial(),
+			Priority: workflow.Current().DefaultPriority(),
 		}
 		m.addingTask = true
 		m.addFieldIndex = 0
 		m.addEditingField = false
-	case "d": // delete task
+	case key.Matches(k, m.keys.Delete): // delete task
 		if len(m.view) > 0 {
 			// Copy current task for confirmation
 			current := m.view[m.cursor]
 			m.taskToDelete = &current
 			m.confirmingDelete = true
 		}
-	case "A": // archive task (Shift+A)
+	case key.Matches(k, m.keys.Archive): // archive task (Shift+A)
 		if len(m.view) > 0 {
 			current := m.view[m.cursor]
 			if err := m.archiveTask(&current); err == nil {
@@ -557,10 +646,14 @@ func (m *Model) handleListKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if err := m.storage.WriteTasks(newTasks); err == nil {
 					m.allTasks = newTasks
 					m.rebuild("")
+				} else {
+					m.statusMessage = err.Error()
 				}
+			} else {
+				m.statusMessage = err.Error()
 			}
 		}
-	case "enter", "e": // open detail box
+	case key.Matches(k, m.keys.Open): // open detail box
 		if len(m.view) > 0 {
 			// copy current task for detail view
 			current := m.view[m.cursor]
@@ -572,6 +665,33 @@ func (m *Model) handleListKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// cycleSort advances to the next order in sortCycle, ending unsorted.
+func (m *Model) cycleSort() {
+	next := 0
+	if m.sortActive {
+		next = len(sortCycle)
+		for i, spec := range sortCycle {
+			if spec == m.sortKind {
+				next = i + 1
+			}
+		}
+	}
+	m.sortActive = next < len(sortCycle)
+	m.sortKind = ""
+	if m.sortActive {
+		m.sortKind = sortCycle[next]
+	}
+}
+
+// pageSize is the number of task rows that fit in the list box.
+func (m *Model) pageSize() int {
+	visible := m.height - 8 // account for box border and padding
+	if visible < 1 {
+		visible = 1
+	}
+	return visible
+}
+
 func (m *Model) getFieldValue(fieldName string) string {
 	if m.detailTask == nil {
 		return ""
@@ -587,10 +707,14 @@ func (m *Model) getFieldValue(fieldName string) string {
 		return m.detailTask.Link
 	case "Tags":
 		return strings.Join(m.detailTask.Tags, ", ")
+	case "Description":
+		return m.detailTask.Description
 	case "Notes":
 		return m.detailTask.Notes
 	case "DueDate":
 		return m.detailTask.DueDate
+	case "Estimate":
+		return m.detailTask.Estimate
 	}
 	return ""
 }
@@ -614,14 +738,17 @@ func (m *Model) applyFieldEdit(val string) {
 		m.detailTask.Link = val
 	case "Tags":
 		m.detailTask.Tags = splitTags(val)
+	case "Description":
+		m.detailTask.Description = val
 	case "Notes":
 		m.detailTask.Notes = val
 	case "DueDate":
 		m.detailTask.DueDate = val
+	case "Estimate":
+		m.detailTask.Estimate = val
 	}
 	// persist to storage
-	m.storage.UpdateTask(m.allTasks, *m.detailTask)
-	m.reloadAfterMutation(m.detailTask.ID)
+	m.saveTask(*m.detailTask)
 }
 
 func (m *Model) getAddFieldValue(fieldName string) string {
@@ -636,10 +763,14 @@ func (m *Model) getAddFieldValue(fieldName string) string {
 		return m.newTask.Link
 	case "Tags":
 		return strings.Join(m.newTask.Tags, ", ")
+	case "Description":
+		return m.newTask.Description
 	case "Notes":
 		return m.newTask.Notes
 	case "DueDate":
 		return m.newTask.DueDate
+	case "Estimate":
+		return m.newTask.Estimate
 	}
 	return ""
 }
@@ -660,10 +791,14 @@ func (m *Model) applyAddFieldEdit(val string) {
 		m.newTask.Link = val
 	case "Tags":
 		m.newTask.Tags = splitTags(val)
+	case "Description":
+		m.newTask.Description = val
 	case "Notes":
 		m.newTask.Notes = val
 	case "DueDate":
 		m.newTask.DueDate = val
+	case "Estimate":
+		m.newTask.Estimate = val
 	}
 }
 
@@ -678,6 +813,15 @@ func splitTags(s string) []string {
 	return out
 }
 
+// saveTask writes t and reloads from disk, so a rejected write (e.g. a
+// transition the workflow does not allow) leaves the UI showing the file.
+func (m *Model) saveTask(t models.Task) {
+	if err := m.storage.UpdateTask(m.allTasks, t); err != nil {
+		m.statusMessage = err.Error()
+	}
+	m.reloadAfterMutation(t.ID)
+}
+
 func (m *Model) reloadAfterMutation(focusID string) {
 	updated, err := m.storage.ReadTasks()
 	if err != nil {
@@ -697,53 +841,14 @@ func (m *Model) reloadAfterMutation(focusID string) {
 }
 
 func (m *Model) rebuild(focusID string) {
-	filtered := m.allTasks
+	filtered := append([]models.Task(nil), m.allTasks...)
+	m.matches = nil
 	if m.filterActive {
-		var tmp []models.Task
-		words := strings.Fields(strings.ToLower(m.filterValue))
-		for _, t := range filtered {
-			if m.filterKind == "Title Contains" {
-				// Search in title
-				titleLower := strings.ToLower(t.Title)
-
-				// Search in tags
-				tagsLower := make([]string, len(t.Tags))
-				for i, tag := range t.Tags {
-					tagsLower[i] = strings.ToLower(tag)
-				}
-
-				// Check if all words are found in either title or tags
-				ok := true
-				for _, w := range words {
-					foundInTitle := strings.Contains(titleLower, w)
-					foundInTags := false
-					for _, tag := range tagsLower {
-						if strings.Contains(tag, w) {
-							foundInTags = true
-							break
-						}
-					}
-					if !foundInTitle && !foundInTags {
-						ok = false
-						break
-					}
-				}
-				if ok {
-					tmp = append(tmp, t)
-				}
-			} else {
-				tmp = append(tmp, t)
-			}
-		}
-		filtered = tmp
+		filtered = m.searchResults(filtered)
 	}
 	if m.sortActive {
-		switch m.sortKind {
-		case "Priority":
-			sort.Slice(filtered, func(i, j int) bool { return filtered[i].Priority > filtered[j].Priority })
-		case "Status":
-			sort.Slice(filtered, func(i, j int) bool { return filtered[i].Status < filtered[j].Status })
-		}
+		keys, _ := tasks.ParseSort(m.sortKind)
+		tasks.Sort(filtered, keys)
 	}
 	m.view = filtered
 	if focusID != "" {
@@ -769,24 +874,57 @@ func (m *Model) archiveTask(task *models.Task) error {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
-	archivePath := config.GetArchiveFilePath()
-	if archivePath == "" {
-		return fmt.Errorf("archive path not configured")
+	return archiveTasks([]models.Task{*task})
+}
+
+// renderTimer shows the running timer, if any, as "⏱ title h:mm:ss".
+func (m *Model) renderTimer(now time.Time) string {
+	i := tasks.ActiveTimer(m.allTasks)
+	if i < 0 {
+		return ""
 	}
+	t := m.allTasks[i]
+	e, _ := tasks.RunningEntry(t)
+	d := tasks.EntryDuration(e, now)
+	clock := fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	return fg(theme.Timer, "⏱ "+t.Title+" "+clock)
+}
 
-	var existing models.TaskList
-	if data, err := os.ReadFile(archivePath); err == nil {
-		if len(data) > 0 {
-			_ = yaml.Unmarshal(data, &existing)
+// detailHistoryLines is how many recent history entries the detail box shows.
+const detailHistoryLines = 5
+
+// renderLifecycle shows the timestamps and most recent history of the detail task.
+func (m *Model) renderLifecycle() string {
+	t := m.detailTask
+	if t == nil {
+		return ""
+	}
+	var b strings.Builder
+	dim := lipgloss.NewStyle().Faint(true)
+	for _, f := range []struct{ name, val string }{
+		{"Created", t.CreatedAt}, {"Started", t.StartedAt}, {"Completed", t.CompletedAt}, {"Updated", t.UpdatedAt},
+	} {
+		if f.val != "" {
+			b.WriteString(dim.Render(fmt.Sprintf("%-11s: %s", f.name, f.val)) + "\n")
 		}
 	}
-
-	existing.Tasks = append(existing.Tasks, *task)
-	out, err := yaml.Marshal(existing)
-	if err != nil {
-		return err
+	if spent := tasks.TimeSpent(*t, time.Now()); spent > 0 {
+		b.WriteString(dim.Render(fmt.Sprintf("%-11s: %s", "Logged", spent.Round(time.Minute))) + "\n")
+	}
+	if len(t.History) > 0 {
+		b.WriteString(dim.Render("History:") + "\n")
+		start := len(t.History) - detailHistoryLines
+		if start < 0 {
+			start = 0
+		}
+		for _, c := range t.History[start:] {
+			b.WriteString(dim.Render("  "+tasks.FormatChange(c)) + "\n")
+		}
 	}
-	return os.WriteFile(archivePath, out, 0644)
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n" + b.String()
 }
 
 // View renders UI.
@@ -795,10 +933,6 @@ func (m *Model) View() string {
 		return m.quitMessage
 	}
 
-	if m.enteringFilter {
-		return m.renderFilterInput()
-	}
-
 	if m.showingHelp {
 		return m.renderHelpBox()
 	}
@@ -807,6 +941,10 @@ func (m *Model) View() string {
 		return m.renderDeleteConfirmation()
 	}
 
+	if m.bulkMenu {
+		return m.renderBulkBox()
+	}
+
 	if m.addingTask {
 		return m.renderAddTaskBox()
 	}
@@ -815,29 +953,46 @@ func (m *Model) View() string {
 		return m.renderDetailBox()
 	}
 
+	if m.agendaMode {
+		return m.renderAgenda()
+	}
+	if m.boardMode {
+		return m.renderBoard()
+	}
 	return m.renderTaskList()
 }
 
-func (m *Model) renderTaskList() string {
-	var content strings.Builder
-
-	// Header
+// listHeader renders the title line with the active filter, sort, mode and timer.
+func (m *Model) listHeader(mode string) string {
 	header := "Tasks"
+	if mode != "" {
+		header += fmt.Sprintf(" [%s]", mode)
+	}
 	if m.filterActive {
 		header += fmt.Sprintf(" [filter: %s]", m.filterValue)
 	}
 	if m.sortActive {
 		header += fmt.Sprintf(" [sort: %s]", m.sortKind)
 	}
-	content.WriteString(lipgloss.NewStyle().Bold(true).Render(header) + "\n\n")
+	header = lipgloss.NewStyle().Bold(true).Render(header)
+	if timer := m.renderTimer(time.Now()); timer != "" {
+		header += "  " + timer
+	}
+	if m.enteringFilter {
+		header += "\n\n" + m.searchLine()
+	}
+	return header
+}
+
+func (m *Model) renderTaskList() string {
+	var content strings.Builder
+
+	content.WriteString(m.listHeader("") + "\n\n")
 
 	if len(m.view) == 0 {
 		content.WriteString("No tasks.\n")
 	} else {
-		visible := m.height - 8 // account for box border and padding
-		if visible < 1 {
-			visible = 1
-		}
+		visible := m.pageSize()
 		start := 0
 		if m.cursor >= visible {
 			start = m.cursor - visible + 1
@@ -849,44 +1004,14 @@ func (m *Model) renderTaskList() string {
 		for i := start; i < end; i++ {
 			t := m.view[i]
 
-			// Status indicator
-			statusIcon := "○"
-			if t.Status == "done" {
-				statusIcon = "✓"
-			} else if t.Status == "in-progress" {
-				statusIcon = "◐"
-			} else if t.Status == "on-hold" {
-				statusIcon = "⏸"
-			}
-
-			// Priority badge
-			priorityBadge := ""
-			switch t.Priority {
-			case "high":
-				priorityBadge = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[H]")
-			case "medium":
-				priorityBadge = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("[M]")
-			case "low":
-				priorityBadge = lipgloss.NewStyle().Foreground(lipgloss.Color("248")).Render("[L]")
-			}
+			// Status indicator and label (padded before colouring so columns line up)
+			label := fg(statusColor(t.Status), fmt.Sprintf("%-11s", statusLabel(t.Status)))
 
-			// Status label
-			statusLabel := ""
-			switch t.Status {
-			case "done":
-				statusLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("28")).Render("Done")
-			case "in-progress":
-				statusLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Render("In Progress")
-			case "on-hold":
-				statusLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("On Hold")
-			case "to-do":
-				statusLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("To-Do")
-			default:
-				// Handle legacy "todo" status
-				statusLabel = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("To-Do")
+			mark := " "
+			if m.isSelected(i) {
+				mark = fg(theme.Selection, "●")
 			}
-
-			line := fmt.Sprintf("%s %s %-11s %s", statusIcon, priorityBadge, statusLabel, t.Title)
+			line := fmt.Sprintf("%s %s %s %s %s", mark, statusIcon(t.Status), priorityBadge(t.Priority), label, highlight(t.Title, m.matches[t.ID]))
 			if i == m.cursor {
 				line = invert(line)
 			}
@@ -897,14 +1022,25 @@ func (m *Model) renderTaskList() string {
 	// Box style for task list - no bottom padding
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Border).
 		Padding(1, 2, 0, 2). // top, right, bottom, left - no bottom padding
 		Width(m.width - 4)
 
 	taskBox := boxStyle.Render(content.String())
 
 	// Status bar - positioned adjacent to bottom border
-	statusBar := statusStyle.Render(" q:quit  h:help  /:filter  s:sort ")
+	bar := fmt.Sprintf(" %s  %s  %s  %s  %s ", keyHint(m.keys.Quit, "quit"), keyHint(m.keys.Help, "help"),
+		keyHint(m.keys.Filter, "search"), keyHint(m.keys.Sort, "sort"), keyHint(m.keys.Select, "select"))
+	if n := len(m.selectionIDs()); n > 0 {
+		bar += fmt.Sprintf("│ %d selected  %s  %s ", n, keyHint(m.keys.Bulk, "bulk"), keyHint(m.keys.Back, "clear"))
+	}
+	if m.visualMode {
+		bar += fmt.Sprintf("│ VISUAL (%s to commit) ", helpKeys(m.keys.VisualSelect.Keys()[:1]))
+	}
+	if m.statusMessage != "" {
+		bar += "│ " + m.statusMessage + " "
+	}
+	statusBar := statusStyle.Render(bar)
 
 	// Combine task box and status bar
 	return taskBox + "\n" + statusBar
@@ -913,7 +1049,7 @@ func (m *Model) renderTaskList() string {
 func (m *Model) renderDetailBox() string {
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Border).
 		Padding(1, 2).
 		Width(60)
 
@@ -923,7 +1059,7 @@ func (m *Model) renderDetailBox() string {
 	// If selecting status, show dropdown
 	if m.selectingStatus {
 		content.WriteString(lipgloss.NewStyle().Bold(true).Render("Select Status:") + "\n\n")
-		for i, status := range statusOptions {
+		for i, status := range statusOptions() {
 			line := "  " + status
 			if i == m.statusCursor {
 				line = invert(line)
@@ -935,7 +1071,7 @@ func (m *Model) renderDetailBox() string {
 	} else if m.selectingPriority {
 		// If selecting priority, show dropdown
 		content.WriteString(lipgloss.NewStyle().Bold(true).Render("Select Priority:") + "\n\n")
-		for i, priority := range priorityOptions {
+		for i, priority := range priorityOptions() {
 			line := "  " + priority
 			if i == m.priorityCursor {
 				line = invert(line)
@@ -947,23 +1083,31 @@ func (m *Model) renderDetailBox() string {
 	} else {
 		// Normal detail view
 		for i, fieldName := range fieldNames {
-			val := m.getFieldValue(fieldName)
-			line := fmt.Sprintf("%-10s: %s", fieldName, val)
+			val := firstLine(m.getFieldValue(fieldName))
+			line := fmt.Sprintf("%-11s: %s", fieldName, val)
 			if i == m.detailFieldIndex {
 				if m.editingField {
-					line = fmt.Sprintf("%-10s: %s", fieldName, m.editInput.View())
+					line = fmt.Sprintf("%-11s: %s", fieldName, m.editInput.View())
 				} else {
 					line = invert(line)
 				}
 			}
 			content.WriteString(line + "\n")
 		}
+		if notes, hint := m.notesPanel(); notes != "" {
+			content.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render("Notes"+hint) + "\n" + notes + "\n")
+		}
+		content.WriteString(m.renderLifecycle())
+		if m.statusMessage != "" {
+			content.WriteString("\n" + fg(theme.Danger, m.statusMessage) + "\n")
+		}
 
 		content.WriteString("\n")
 		if m.editingField {
 			content.WriteString(statusStyle.Render(" [Enter:save Esc:cancel] "))
 		} else {
-			content.WriteString(statusStyle.Render(" [↑/↓:navigate e:edit Esc:close] "))
+			content.WriteString(statusStyle.Render(fmt.Sprintf(" [↑/↓:navigate %s %s %s] ",
+				keyHint(m.keys.Open, "edit"), keyHint(m.keys.Editor, "$EDITOR"), keyHint(m.keys.Back, "close"))))
 		}
 	}
 
@@ -988,7 +1132,7 @@ func (m *Model) renderDetailBox() string {
 func (m *Model) renderAddTaskBox() string {
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Border).
 		Padding(1, 2).
 		Width(60)
 
@@ -998,7 +1142,7 @@ func (m *Model) renderAddTaskBox() string {
 	// If selecting status, show dropdown
 	if m.addSelectingStatus {
 		content.WriteString(lipgloss.NewStyle().Bold(true).Render("Select Status:") + "\n\n")
-		for i, status := range statusOptions {
+		for i, status := range statusOptions() {
 			line := "  " + status
 			if i == m.addStatusCursor {
 				line = invert(line)
@@ -1010,7 +1154,7 @@ func (m *Model) renderAddTaskBox() string {
 	} else if m.addSelectingPriority {
 		// If selecting priority, show dropdown
 		content.WriteString(lipgloss.NewStyle().Bold(true).Render("Select Priority:") + "\n\n")
-		for i, priority := range priorityOptions {
+		for i, priority := range priorityOptions() {
 			line := "  " + priority
 			if i == m.addPriorityCursor {
 				line = invert(line)
@@ -1035,12 +1179,14 @@ func (m *Model) renderAddTaskBox() string {
 				hint = " (comma separated)"
 			case "DueDate":
 				hint = " (RFC3339 format)"
+			case "Estimate":
+				hint = " (e.g. 1h30m)"
 			}
 
-			line := fmt.Sprintf("%-10s: %s%s", fieldName, val, hint)
+			line := fmt.Sprintf("%-11s: %s%s", fieldName, val, hint)
 			if i == m.addFieldIndex {
 				if m.addEditingField {
-					line = fmt.Sprintf("%-10s: %s", fieldName, m.editInput.View())
+					line = fmt.Sprintf("%-11s: %s", fieldName, m.editInput.View())
 				} else {
 					line = invert(line)
 				}
@@ -1074,12 +1220,12 @@ func (m *Model) renderDeleteConfirmation() string {
 
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("196")). // Red border for warning
+		BorderForeground(theme.Danger). // Red border for warning
 		Padding(1, 2).
 		Width(60)
 
 	var content strings.Builder
-	content.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")).Render("Delete Task") + "\n\n")
+	content.WriteString(lipgloss.NewStyle().Bold(true).Foreground(theme.Danger).Render("Delete Task") + "\n\n")
 	content.WriteString("Are you sure you want to delete this task?\n\n")
 
 	// Show task details
@@ -1101,60 +1247,10 @@ func (m *Model) renderDeleteConfirmation() string {
 	return positioned
 }
 
-func (m *Model) renderFilterInput() string {
-	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
-		Padding(1, 2).
-		Width(60)
-
-	var content strings.Builder
-	content.WriteString(lipgloss.NewStyle().Bold(true).Render("Filter Tasks") + "\n\n")
-	content.WriteString("Enter text to filter tasks by title or tags:\n\n")
-	content.WriteString(m.filterInput.View() + "\n\n")
-	content.WriteString(statusStyle.Render(" [Enter:apply Esc:cancel] "))
-
-	box := boxStyle.Render(content.String())
-
-	// center the box
-	positioned := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
-	return positioned
-}
-
 func (m *Model) renderHelpBox() string {
-	// Build help content as lines
-	helpLines := []string{
-		lipgloss.NewStyle().Bold(true).Render("Keyboard Shortcuts"),
-		"",
-		lipgloss.NewStyle().Bold(true).Render("Navigation:"),
-		"  ↑/k         Move cursor up",
-		"  ↓/j         Move cursor down",
-		"  h/?         Show this help screen",
-		"  q/Ctrl+C    Quit application",
-		"",
-		lipgloss.NewStyle().Bold(true).Render("Task Actions:"),
-		"  x           Toggle task status (to-do → in-progress → on-hold → done)",
-		"  a           Add new task",
-		"  e/Enter     Edit task details",
-		"  d           Delete task",
-		"  A (Shift+A) Archive task",
-		"",
-		lipgloss.NewStyle().Bold(true).Render("Filtering & Sorting:"),
-		"  /           Filter tasks by title or tags",
-		"  c           Clear filter",
-		"  s           Cycle sort (Priority → Status → None)",
-		"",
-		lipgloss.NewStyle().Bold(true).Render("Detail View:"),
-		"  ↑/↓         Navigate between fields",
-		"  e/Enter     Edit current field",
-		"  Esc         Close detail view",
-		"",
-		lipgloss.NewStyle().Bold(true).Render("Add Task View:"),
-		"  ↑/↓         Navigate between fields",
-		"  e/Enter     Edit current field",
-		"  Ctrl+S      Save task",
-		"  Esc         Cancel",
-	}
+	// Build help content from the active bindings
+	heading := func(t string) string { return lipgloss.NewStyle().Bold(true).Render(t) }
+	helpLines := append([]string{heading("Keyboard Shortcuts"), ""}, m.keys.helpLines(heading)...)
 
 	// Calculate available height for content (account for border, padding, and status line)
 	boxBorder := 2  // top and bottom border
@@ -1206,11 +1302,11 @@ func (m *Model) renderHelpBox() string {
 	}
 
 	content.WriteString("\n")
-	content.WriteString(statusStyle.Render(fmt.Sprintf(" [h/?/Esc/q: close]%s ", scrollInfo)))
+	content.WriteString(statusStyle.Render(fmt.Sprintf(" [%s: close]%s ", helpKeys(m.keys.Help.Keys()), scrollInfo)))
 
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Border).
 		Padding(1, 2).
 		Width(70)
 
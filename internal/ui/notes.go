@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/editor"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+)
+
+// notesWidth is the wrap width of rendered notes inside the detail box.
+const notesWidth = 52
+
+// notesHeight is how many rendered note lines the detail box shows at once.
+const notesHeight = 10
+
+// editorFinishedMsg is sent when the external editor exits.
+type editorFinishedMsg struct {
+	taskID string
+	field  string // "Notes" or "Description"
+	path   string
+	err    error
+}
+
+// openEditor suspends the UI and opens field of the detail task in $EDITOR.
+func (m *Model) openEditor(field string) tea.Cmd {
+	if m.detailTask == nil {
+		return nil
+	}
+	content, err := m.editorContent(field)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return nil
+	}
+	path, err := editor.TempFile(strings.ToLower(field), content)
+	if err != nil {
+		m.statusMessage = err.Error()
+		return nil
+	}
+	id := m.detailTask.ID
+	return tea.ExecProcess(editor.Command(path), func(err error) tea.Msg {
+		return editorFinishedMsg{taskID: id, field: field, path: path, err: err}
+	})
+}
+
+// editorContent is the text field of the detail task opens with. The
+// description is read as stored, since ReadTasks shows the link in an empty
+// one.
+func (m *Model) editorContent(field string) (string, error) {
+	if field != "Description" {
+		return m.detailTask.Notes, nil
+	}
+	stored, err := m.storage.ReadStored()
+	if err != nil {
+		return "", err
+	}
+	for _, t := range stored {
+		if t.ID == m.detailTask.ID {
+			return t.Description, nil
+		}
+	}
+	return "", nil
+}
+
+// applyEditorResult stores the edited text on the task.
+func (m *Model) applyEditorResult(msg editorFinishedMsg) {
+	text, err := editor.ReadBack(msg.path)
+	if msg.err != nil {
+		m.statusMessage = "editor: " + msg.err.Error()
+		return
+	}
+	if err != nil {
+		m.statusMessage = err.Error()
+		return
+	}
+	for _, t := range m.allTasks {
+		if t.ID != msg.taskID {
+			continue
+		}
+		if msg.field == "Description" {
+			t.Description = text
+		} else {
+			t.Notes = text
+		}
+		m.saveTask(t)
+		m.notesScroll = 0
+		return
+	}
+}
+
+// renderNotes renders the detail task's notes as Markdown, caching the
+// result until the notes or theme change.
+func (m *Model) renderNotes() []string {
+	if m.detailTask == nil || strings.TrimSpace(m.detailTask.Notes) == "" {
+		return nil
+	}
+	key := theme.Glamour + "\x00" + m.detailTask.Notes
+	if key != m.notesSource {
+		out := m.detailTask.Notes
+		r, err := glamour.NewTermRenderer(glamour.WithStandardStyle(theme.Glamour), glamour.WithWordWrap(notesWidth))
+		if err == nil {
+			if rendered, err := r.Render(m.detailTask.Notes); err == nil {
+				out = rendered
+			}
+		}
+		m.notesSource = key
+		m.notesRendered = strings.Split(strings.Trim(out, "\n"), "\n")
+	}
+	return m.notesRendered
+}
+
+// notesPanel returns the visible window of rendered notes and a scroll hint.
+func (m *Model) notesPanel() (string, string) {
+	lines := m.renderNotes()
+	if len(lines) == 0 {
+		return "", ""
+	}
+	maxScroll := len(lines) - notesHeight
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.notesScroll > maxScroll {
+		m.notesScroll = maxScroll
+	}
+	if m.notesScroll < 0 {
+		m.notesScroll = 0
+	}
+	end := m.notesScroll + notesHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+	hint := ""
+	if maxScroll > 0 {
+		hint = fmt.Sprintf(" (%d/%d, %s)", end, len(lines), keyHint(m.keys.PageDown, "more"))
+	}
+	return strings.Join(lines[m.notesScroll:end], "\n"), hint
+}
+
+// firstLine shortens multi-line text for single-line field display.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " …"
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"taskflow/internal/editor"
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+	"testing"
+)
+
+func TestDescriptionEditorLeavesLinkOut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	st, _ := storage.NewStorage(path)
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "Read", Status: "to-do", Link: "https://example.com/post"}}); err != nil {
+		t.Fatal(err)
+	}
+	m := New(st, "", path)
+	m.detailTask = &m.allTasks[0]
+
+	content, err := m.editorContent("Description")
+	if err != nil || content != "" {
+		t.Fatalf("editor opened with %q, %v", content, err)
+	}
+	// Save the file unchanged.
+	tmp, err := editor.TempFile("description", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.applyEditorResult(editorFinishedMsg{taskID: "1", field: "Description", path: tmp})
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "description:") {
+		t.Fatalf("link saved as description:\n%s", data)
+	}
+}
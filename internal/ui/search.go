@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+// maxSearchHistory bounds the recent searches kept next to the tasks file.
+const maxSearchHistory = 20
+
+// searchHistoryPath is the sidecar file holding recent searches, newest first.
+func (m *Model) searchHistoryPath() string {
+	if m.storagePath == "" {
+		return ""
+	}
+	return m.storagePath + ".searches"
+}
+
+func (m *Model) loadSearchHistory() {
+	path := m.searchHistoryPath()
+	if path == "" {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	m.searchHistory = nil
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			m.searchHistory = append(m.searchHistory, line)
+		}
+	}
+}
+
+// rememberSearch moves q to the front of the history and saves it.
+func (m *Model) rememberSearch(q string) {
+	hist := []string{q}
+	for _, h := range m.searchHistory {
+		if h != q && len(hist) < maxSearchHistory {
+			hist = append(hist, h)
+		}
+	}
+	m.searchHistory = hist
+	if path := m.searchHistoryPath(); path != "" {
+		_ = os.WriteFile(path, []byte(strings.Join(hist, "\n")+"\n"), 0644)
+	}
+}
+
+// openSearch starts live search, remembering the filter to restore on Esc.
+func (m *Model) openSearch() {
+	m.enteringFilter = true
+	m.filterBefore = m.filterValue
+	m.historyIndex = -1
+	m.filterInput.SetValue(m.filterValue)
+	m.filterInput.CursorEnd()
+	m.filterInput.Focus()
+}
+
+// setFilter applies q as the current search and refreshes the view.
+func (m *Model) setFilter(q string) {
+	m.filterValue = strings.TrimSpace(q)
+	m.filterActive = m.filterValue != ""
+	m.cursor = 0
+	m.rebuild("")
+}
+
+func (m *Model) handleFilterInputKey(k tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(k, inputKeys(m.keys.Back)):
+		m.enteringFilter = false
+		m.filterInput.Blur()
+		m.setFilter(m.filterBefore)
+		return m, nil
+	case key.Matches(k, inputKeys(m.keys.Open)):
+		m.enteringFilter = false
+		m.filterInput.Blur()
+		if m.filterActive {
+			m.rememberSearch(m.filterValue)
+		}
+		return m, nil
+	case key.Matches(k, inputKeys(m.keys.Up)):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case key.Matches(k, inputKeys(m.keys.Down)):
+		if m.cursor < len(m.view)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case key.Matches(k, inputKeys(m.keys.HistoryPrev), inputKeys(m.keys.HistoryNext)):
+		// Step through recent searches
+		if key.Matches(k, inputKeys(m.keys.HistoryPrev)) && m.historyIndex < len(m.searchHistory)-1 {
+			m.historyIndex++
+		} else if key.Matches(k, inputKeys(m.keys.HistoryNext)) && m.historyIndex >= 0 {
+			m.historyIndex--
+		}
+		val := ""
+		if m.historyIndex >= 0 {
+			val = m.searchHistory[m.historyIndex]
+		}
+		m.filterInput.SetValue(val)
+		m.filterInput.CursorEnd()
+		m.setFilter(val)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(k)
+	if v := m.filterInput.Value(); strings.TrimSpace(v) != m.filterValue {
+		m.setFilter(v)
+	}
+	return m, cmd
+}
+
+// searchLine is the input line shown above the list while searching, with
+// recent searches when the input is empty.
+func (m *Model) searchLine() string {
+	line := m.filterInput.View()
+	if m.filterInput.Value() == "" && len(m.searchHistory) > 0 {
+		recent := m.searchHistory
+		if len(recent) > 5 {
+			recent = recent[:5]
+		}
+		line += "\n" + lipgloss.NewStyle().Faint(true).Render("recent: "+strings.Join(recent, " · "))
+	}
+	hints := []string{"type to search  #tag @status !priority"}
+	for _, h := range []struct {
+		b     key.Binding
+		label string
+	}{
+		{m.keys.Up, "up"}, {m.keys.Down, "down"}, {m.keys.HistoryPrev, "previous search"},
+		{m.keys.HistoryNext, "next search"}, {m.keys.Open, "keep"}, {m.keys.Back, "cancel"},
+	} {
+		if hint := keyHint(inputKeys(h.b), h.label); hint != "" {
+			hints = append(hints, hint)
+		}
+	}
+	hint := statusStyle.Render(" [" + strings.Join(hints, "  ") + "] ")
+	return line + "\n" + hint
+}
+
+// searchResults runs the active search over ts, recording title match
+// positions for highlighting.
+func (m *Model) searchResults(ts []models.Task) []models.Task {
+	m.matches = map[string][]int{}
+	results := tasks.Search(ts, tasks.ParseQuery(m.filterValue))
+	out := make([]models.Task, len(results))
+	for i, r := range results {
+		out[i] = r.Task
+		if len(r.Title) > 0 {
+			m.matches[r.Task.ID] = r.Title
+		}
+	}
+	return out
+}
+
+// highlight renders the runes of s at the given positions in the match colour.
+func highlight(s string, pos []int) string {
+	if len(pos) == 0 {
+		return s
+	}
+	style := lipgloss.NewStyle().Foreground(theme.Match).Bold(true)
+	var b strings.Builder
+	p := 0
+	for i, r := range []rune(s) {
+		if p < len(pos) && pos[p] == i {
+			b.WriteString(style.Render(string(r)))
+			for p < len(pos) && pos[p] == i {
+				p++
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/storage"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchInputFollowsKeymap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	st, _ := storage.NewStorage(path)
+	if err := st.WriteTasks([]models.Task{{ID: "1", Title: "One", Status: "to-do"}, {ID: "2", Title: "Two", Status: "to-do"}}); err != nil {
+		t.Fatal(err)
+	}
+	m := New(st, "", path)
+	m.Init()
+	m.keys, _ = NewKeyMap("emacs", nil)
+	m.searchHistory = []string{"Two"}
+	m.openSearch()
+	m.cursor = 1
+
+	// Under emacs ctrl+p moves up, as it does in the list; alt+p recalls.
+	m.handleFilterInputKey(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.cursor != 0 || m.filterInput.Value() != "" {
+		t.Fatalf("ctrl+p: cursor %d, input %q", m.cursor, m.filterInput.Value())
+	}
+	m.handleFilterInputKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}, Alt: true})
+	if m.filterInput.Value() != "Two" {
+		t.Fatalf("alt+p should recall the last search, got %q", m.filterInput.Value())
+	}
+	if hint := m.searchLine(); !strings.Contains(hint, "alt+p:previous search") || !strings.Contains(hint, "esc:cancel") {
+		t.Fatalf("hint not built from the keymap: %s", hint)
+	}
+
+	// Letters bound to actions are still typed.
+	m.handleFilterInputKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !m.enteringFilter || m.filterInput.Value() != "Twoe" {
+		t.Fatalf("typing e: entering %v, input %q", m.enteringFilter, m.filterInput.Value())
+	}
+}
@@ -1,10 +1,125 @@
 package ui
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	lipgloss "github.com/charmbracelet/lipgloss"
 )
 
+// Theme holds the colours used by the interactive UI. Values are lipgloss
+// colours: ANSI 256 numbers ("62") or hex ("#5f5fd7").
+type Theme struct {
+	Border           lipgloss.Color
+	Danger           lipgloss.Color
+	StatusBarFg      lipgloss.Color
+	StatusBarBg      lipgloss.Color
+	PriorityHigh     lipgloss.Color
+	PriorityMedium   lipgloss.Color
+	PriorityLow      lipgloss.Color
+	StatusTodo       lipgloss.Color
+	StatusInProgress lipgloss.Color
+	StatusOnHold     lipgloss.Color
+	StatusDone       lipgloss.Color
+	Selection        lipgloss.Color
+	Timer            lipgloss.Color
+	Match            lipgloss.Color // fuzzy search match highlight
+	Glamour          string         // glamour style for Markdown notes
+}
+
+var themes = map[string]Theme{
+	"dark": {
+		Border:           "62",
+		Danger:           "196",
+		StatusBarFg:      "241",
+		StatusBarBg:      "236",
+		PriorityHigh:     "196",
+		PriorityMedium:   "214",
+		PriorityLow:      "248",
+		StatusTodo:       "240",
+		StatusInProgress: "33",
+		StatusOnHold:     "214",
+		StatusDone:       "28",
+		Selection:        "212",
+		Timer:            "208",
+		Match:            "81",
+		Glamour:          "dark",
+	},
+	"light": {
+		Border:           "61",
+		Danger:           "160",
+		StatusBarFg:      "238",
+		StatusBarBg:      "252",
+		PriorityHigh:     "160",
+		PriorityMedium:   "166",
+		PriorityLow:      "244",
+		StatusTodo:       "245",
+		StatusInProgress: "25",
+		StatusOnHold:     "130",
+		StatusDone:       "28",
+		Selection:        "163",
+		Timer:            "166",
+		Match:            "31",
+		Glamour:          "light",
+	},
+}
+
+// themeColors maps config names (ui.colors.<name>) to theme fields.
+var themeColors = map[string]func(*Theme) *lipgloss.Color{
+	"border":             func(t *Theme) *lipgloss.Color { return &t.Border },
+	"danger":             func(t *Theme) *lipgloss.Color { return &t.Danger },
+	"status_bar_fg":      func(t *Theme) *lipgloss.Color { return &t.StatusBarFg },
+	"status_bar_bg":      func(t *Theme) *lipgloss.Color { return &t.StatusBarBg },
+	"priority_high":      func(t *Theme) *lipgloss.Color { return &t.PriorityHigh },
+	"priority_medium":    func(t *Theme) *lipgloss.Color { return &t.PriorityMedium },
+	"priority_low":       func(t *Theme) *lipgloss.Color { return &t.PriorityLow },
+	"status_todo":        func(t *Theme) *lipgloss.Color { return &t.StatusTodo },
+	"status_in_progress": func(t *Theme) *lipgloss.Color { return &t.StatusInProgress },
+	"status_on_hold":     func(t *Theme) *lipgloss.Color { return &t.StatusOnHold },
+	"status_done":        func(t *Theme) *lipgloss.Color { return &t.StatusDone },
+	"selection":          func(t *Theme) *lipgloss.Color { return &t.Selection },
+	"timer":              func(t *Theme) *lipgloss.Color { return &t.Timer },
+	"match":              func(t *Theme) *lipgloss.Color { return &t.Match },
+}
+
+// NewTheme returns a built-in theme ("" means dark) with colour overrides.
+func NewTheme(name string, overrides map[string]string) (Theme, error) {
+	if name == "" {
+		name = "dark"
+	}
+	t, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (want dark or light)", name)
+	}
+	for k, v := range overrides {
+		field, ok := themeColors[k]
+		if !ok {
+			var names []string
+			for n := range themeColors {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return Theme{}, fmt.Errorf("unknown colour %q in ui.colors (want one of %s)", k, strings.Join(names, ", "))
+		}
+		*field(&t) = lipgloss.Color(v)
+	}
+	return t, nil
+}
+
 var (
-	statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Background(lipgloss.Color("236")).PaddingLeft(1).PaddingRight(1)
+	theme       = themes["dark"]
+	statusStyle = lipgloss.NewStyle().Foreground(theme.StatusBarFg).Background(theme.StatusBarBg).PaddingLeft(1).PaddingRight(1)
 	cursorStyle = lipgloss.NewStyle().Reverse(true)
 )
+
+// useTheme makes t the active theme for all rendering.
+func useTheme(t Theme) {
+	theme = t
+	statusStyle = lipgloss.NewStyle().Foreground(t.StatusBarFg).Background(t.StatusBarBg).PaddingLeft(1).PaddingRight(1)
+}
+
+// fg renders s in colour c.
+func fg(c lipgloss.Color, s string) string {
+	return lipgloss.NewStyle().Foreground(c).Render(s)
+}
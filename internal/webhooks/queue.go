@@ -0,0 +1,199 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/statefile"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery is a queued request to one endpoint.
+type Delivery struct {
+	ID          string    `json:"id"`
+	Endpoint    string    `json:"endpoint"`
+	Event       Event     `json:"event"`
+	TaskID      string    `json:"task_id"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+
+	file string
+}
+
+// Queue stores deliveries as one JSON file each under Dir: pending/ holds
+// deliveries still to be sent, failed/ those that ran out of attempts. New
+// files are written under a temporary name and renamed into place, so a
+// crash never leaves a half-written delivery and processes adding events
+// don't disturb a running worker.
+type Queue struct {
+	Dir string
+}
+
+// NewQueue returns the queue stored in dir.
+func NewQueue(dir string) *Queue { return &Queue{Dir: dir} }
+
+func (q *Queue) pendingDir() string { return filepath.Join(q.Dir, "pending") }
+func (q *Queue) failedDir() string  { return filepath.Join(q.Dir, "failed") }
+
+// Enqueue renders and queues c for every endpoint that wants it.
+func (q *Queue) Enqueue(endpoints []Endpoint, c Change, now time.Time) error {
+	for i, e := range endpoints {
+		if !e.Wants(c.Event, c.Task) {
+			continue
+		}
+		body, err := e.Payload(c.Event, c.Task, now)
+		if err != nil {
+			return err
+		}
+		d := Delivery{
+			ID:          uuid.New().String(),
+			Endpoint:    e.Name,
+			Event:       c.Event,
+			TaskID:      c.Task.ID,
+			Body:        string(body),
+			CreatedAt:   now.UTC(),
+			NextAttempt: now.UTC(),
+		}
+		// File names sort in queueing order.
+		d.file = fmt.Sprintf("%020d-%03d-%s.json", now.UnixNano(), i, d.ID)
+		if err := q.write(q.pendingDir(), d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pending returns the queued deliveries, oldest first.
+func (q *Queue) Pending() ([]Delivery, error) { return q.list(q.pendingDir()) }
+
+// Failed returns the deliveries that ran out of attempts, oldest first.
+func (q *Queue) Failed() ([]Delivery, error) { return q.list(q.failedDir()) }
+
+// Done removes a delivered entry.
+func (q *Queue) Done(d Delivery) error {
+	err := os.Remove(filepath.Join(q.pendingDir(), d.file))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Retry records a failed attempt: the delivery is rescheduled after
+// Backoff, or moved to failed/ once it has had maxAttempts.
+func (q *Queue) Retry(d Delivery, cause error, now time.Time, maxAttempts int) error {
+	d.Attempts++
+	d.LastError = cause.Error()
+	if d.Attempts >= maxAttempts {
+		if err := q.write(q.failedDir(), d); err != nil {
+			return err
+		}
+		return q.Done(d)
+	}
+	d.NextAttempt = now.UTC().Add(Backoff(d.Attempts))
+	return q.write(q.pendingDir(), d)
+}
+
+// Backoff is the wait after the given number of failed attempts: 30s,
+// doubling up to an hour.
+func Backoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempts && d < time.Hour; i++ {
+		d *= 2
+	}
+	if d > time.Hour {
+		d = time.Hour
+	}
+	return d
+}
+
+func (q *Queue) list(dir string) ([]Delivery, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []Delivery
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if errors.Is(err, os.ErrNotExist) {
+			continue // delivered meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+		var d Delivery
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil, fmt.Errorf("webhook queue entry %s: %w", e.Name(), err)
+		}
+		d.file = e.Name()
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].file < out[j].file })
+	return out, nil
+}
+
+func (q *Queue) write(dir string, d Delivery) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return statefile.WriteFile(filepath.Join(dir, d.file), data, 0600)
+}
+
+// overdueFile records the tasks already reported overdue, with the due date
+// they were reported for, so each due date fires once.
+func (q *Queue) overdueFile() string { return filepath.Join(q.Dir, "overdue.json") }
+
+// QueueOverdue queues task.overdue for the open tasks in all that are past
+// due and were not reported for that due date yet.
+func (q *Queue) QueueOverdue(endpoints []Endpoint, all []models.Task, now time.Time) error {
+	reported := map[string]string{}
+	data, err := os.ReadFile(q.overdueFile())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &reported); err != nil {
+			return fmt.Errorf("%s: %w", q.overdueFile(), err)
+		}
+	}
+	next := map[string]string{}
+	changed := false
+	for _, t := range all {
+		if !IsOverdue(t, now) {
+			continue
+		}
+		next[t.ID] = t.DueDate
+		if reported[t.ID] == t.DueDate {
+			continue
+		}
+		changed = true
+		if err := q.Enqueue(endpoints, Change{TaskOverdue, t}, now); err != nil {
+			return err
+		}
+	}
+	// Forget tasks that are done or were rescheduled.
+	if !changed && len(next) == len(reported) {
+		return nil
+	}
+	data, err = json.MarshalIndent(next, "", "  ")
+	if err != nil {
+		return err
+	}
+	return statefile.WriteFile(q.overdueFile(), data, 0600)
+}
@@ -0,0 +1,226 @@
+// Package webhooks posts task events (created, completed, overdue) to HTTP
+// endpoints. Events are written to a durable queue on disk when they happen
+// and delivered by a Worker, which retries failed deliveries with backoff.
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"taskflow/internal/models"
+	"taskflow/internal/tasks"
+	"taskflow/internal/workflow"
+	"text/template"
+	"time"
+)
+
+// Event names a task event sent to webhooks.
+type Event string
+
+const (
+	TaskCreated   Event = "task.created"
+	TaskCompleted Event = "task.completed"
+	TaskOverdue   Event = "task.overdue"
+)
+
+// Events lists the known events.
+var Events = []Event{TaskCreated, TaskCompleted, TaskOverdue}
+
+// Payload formats.
+const (
+	FormatJSON  = "json"
+	FormatSlack = "slack"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body when the
+// endpoint has a secret, as "sha256=<hex>".
+const SignatureHeader = "X-Taskflow-Signature"
+
+// Endpoint is one configured webhook, an entry of webhooks.endpoints.
+type Endpoint struct {
+	Name string `mapstructure:"name"`
+	URL  string `mapstructure:"url"`
+	// Events limits the events sent ("created" or "task.created"); empty
+	// sends all.
+	Events []string `mapstructure:"events"`
+	// Filter is a search query (see tasks.ParseQuery) the task must match.
+	Filter string `mapstructure:"filter"`
+	// Format is json (default) or slack; Template, a text/template, takes
+	// precedence over it.
+	Format   string `mapstructure:"format"`
+	Template string `mapstructure:"template"`
+	// Secret signs the body (see SignatureHeader).
+	Secret string `mapstructure:"secret"`
+}
+
+// Validate checks the endpoint's settings.
+func (e Endpoint) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("webhook without a name")
+	}
+	if !strings.HasPrefix(e.URL, "http://") && !strings.HasPrefix(e.URL, "https://") {
+		return fmt.Errorf("webhook %s: url must start with http:// or https://", e.Name)
+	}
+	for _, ev := range e.Events {
+		if !known(normalizeEvent(ev)) {
+			return fmt.Errorf("webhook %s: unknown event %q", e.Name, ev)
+		}
+	}
+	switch e.Format {
+	case "", FormatJSON, FormatSlack:
+	default:
+		return fmt.Errorf("webhook %s: unknown format %q (want json or slack)", e.Name, e.Format)
+	}
+	if e.Template != "" {
+		if _, err := e.template(); err != nil {
+			return fmt.Errorf("webhook %s: %w", e.Name, err)
+		}
+	}
+	return nil
+}
+
+// Wants reports whether ev on t should be sent to e.
+func (e Endpoint) Wants(ev Event, t models.Task) bool {
+	if len(e.Events) > 0 {
+		found := false
+		for _, want := range e.Events {
+			if normalizeEvent(want) == ev {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q := tasks.ParseQuery(e.Filter); !q.Empty() {
+		return len(tasks.Search([]models.Task{t}, q)) > 0
+	}
+	return true
+}
+
+// payloadData is what templates see.
+type payloadData struct {
+	Event Event
+	Time  string
+	Task  models.Task
+}
+
+// Payload renders the request body for ev on t.
+func (e Endpoint) Payload(ev Event, t models.Task, at time.Time) ([]byte, error) {
+	data := payloadData{Event: ev, Time: at.UTC().Format(time.RFC3339), Task: t}
+	if e.Template != "" {
+		tmpl, err := e.template()
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("webhook %s: %w", e.Name, err)
+		}
+		return buf.Bytes(), nil
+	}
+	if e.Format == FormatSlack {
+		return json.Marshal(map[string]string{"text": slackText(ev, t)})
+	}
+	return json.Marshal(struct {
+		Event Event       `json:"event"`
+		Time  string      `json:"time"`
+		Task  models.Task `json:"task"`
+	}{ev, data.Time, t})
+}
+
+func (e Endpoint) template() (*template.Template, error) {
+	return template.New(e.Name).Funcs(template.FuncMap{
+		// json quotes a value for use inside a JSON template.
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(e.Template)
+}
+
+func slackText(ev Event, t models.Task) string {
+	var what string
+	switch ev {
+	case TaskCreated:
+		what = "Task created"
+	case TaskCompleted:
+		what = "Task completed"
+	case TaskOverdue:
+		what = "Task overdue"
+	}
+	text := fmt.Sprintf("%s: *%s*", what, t.Title)
+	var details []string
+	if t.Priority != "" {
+		details = append(details, "priority "+t.Priority)
+	}
+	if t.DueDate != "" {
+		details = append(details, "due "+t.DueDate)
+	}
+	if len(t.Tags) > 0 {
+		details = append(details, "tags "+strings.Join(t.Tags, ", "))
+	}
+	if len(details) > 0 {
+		text += " (" + strings.Join(details, "; ") + ")"
+	}
+	return text
+}
+
+// Sign returns the signature header value of body for secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Change is an event on a task.
+type Change struct {
+	Event Event
+	Task  models.Task
+}
+
+// Changes returns the events of a write of all over the stored tasks (prev,
+// by ID): task.created for new tasks and task.completed for tasks that reach
+// a terminal status.
+func Changes(prev map[string]*models.Task, all []models.Task) []Change {
+	wf := workflow.Current()
+	var out []Change
+	for _, t := range all {
+		old := prev[t.ID]
+		switch {
+		case old == nil:
+			out = append(out, Change{TaskCreated, t})
+		case !wf.IsTerminal(old.Status) && wf.IsTerminal(t.Status):
+			out = append(out, Change{TaskCompleted, t})
+		}
+	}
+	return out
+}
+
+// IsOverdue reports whether t is open and past its due date.
+func IsOverdue(t models.Task, now time.Time) bool {
+	due, ok := tasks.ParseDue(t.DueDate, now.Location())
+	return ok && due.Before(now) && !workflow.Current().IsTerminal(t.Status)
+}
+
+func normalizeEvent(s string) Event {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, "task.") {
+		s = "task." + s
+	}
+	return Event(s)
+}
+
+func known(ev Event) bool {
+	for _, k := range Events {
+		if k == ev {
+			return true
+		}
+	}
+	return false
+}
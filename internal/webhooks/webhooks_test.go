@@ -0,0 +1,199 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"taskflow/internal/models"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestEndpointWantsAndPayload(t *testing.T) {
+	task := models.Task{ID: "1", Title: "Deploy \"api\"", Status: "to-do", Priority: "high", Tags: []string{"backend"}}
+
+	e := Endpoint{Name: "x", URL: "http://x", Events: []string{"completed"}, Filter: "#back !high"}
+	if e.Wants(TaskCreated, task) || !e.Wants(TaskCompleted, task) {
+		t.Fatal("event filter not applied")
+	}
+	if e.Filter = "#frontend"; e.Wants(TaskCompleted, task) {
+		t.Fatal("query filter not applied")
+	}
+
+	body, _ := Endpoint{Name: "x"}.Payload(TaskCreated, task, now)
+	var generic struct {
+		Event Event       `json:"event"`
+		Time  string      `json:"time"`
+		Task  models.Task `json:"task"`
+	}
+	if err := json.Unmarshal(body, &generic); err != nil || generic.Event != TaskCreated || generic.Task.Title != task.Title || generic.Time != "2025-10-01T12:00:00Z" {
+		t.Fatalf("json payload %s: %v", body, err)
+	}
+
+	body, _ = Endpoint{Name: "x", Format: FormatSlack}.Payload(TaskOverdue, task, now)
+	if want := `{"text":"Task overdue: *Deploy \"api\"* (priority high; tags backend)"}`; string(body) != want {
+		t.Fatalf("slack payload = %s, want %s", body, want)
+	}
+
+	tmpl := Endpoint{Name: "x", Template: `{"msg": {{json .Task.Title}}, "event": "{{.Event}}"}`}
+	if err := tmpl.Validate(); err == nil {
+		t.Fatal("expected missing url to be rejected")
+	}
+	body, err := tmpl.Payload(TaskCompleted, task, now)
+	if want := `{"msg": "Deploy \"api\"", "event": "task.completed"}`; err != nil || string(body) != want {
+		t.Fatalf("template payload = %s (%v), want %s", body, err, want)
+	}
+
+	for _, bad := range []Endpoint{
+		{Name: "x", URL: "http://x", Events: []string{"deleted"}},
+		{Name: "x", URL: "http://x", Format: "xml"},
+		{Name: "x", URL: "http://x", Template: "{{.Nope"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", bad)
+		}
+	}
+}
+
+// receiver is an httptest endpoint that fails the first `fail` requests.
+type receiver struct {
+	mu       sync.Mutex
+	fail     int
+	requests []*http.Request
+	bodies   []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, string(body))
+	if r.fail > 0 {
+		r.fail--
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+	}
+}
+
+func TestWorkerDeliversWithRetries(t *testing.T) {
+	recv := &receiver{fail: 1}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	e := Endpoint{Name: "ci", URL: srv.URL, Secret: "s3cret"}
+	q := NewQueue(t.TempDir())
+	w := &Worker{Queue: q, Endpoints: []Endpoint{e}, Client: srv.Client()}
+	task := models.Task{ID: "1", Title: "Ship", Status: "done"}
+	if err := q.Enqueue(w.Endpoints, Change{TaskCompleted, task}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := w.Flush(context.Background(), now)
+	if err != nil || res != (Result{Retrying: 1}) {
+		t.Fatalf("first flush: %+v %v", res, err)
+	}
+	pending, _ := q.Pending()
+	if len(pending) != 1 || pending[0].Attempts != 1 || !strings.Contains(pending[0].LastError, "try later") || !pending[0].NextAttempt.Equal(now.Add(Backoff(1))) {
+		t.Fatalf("delivery not rescheduled: %+v", pending)
+	}
+
+	// Not due yet.
+	if res, _ := w.Flush(context.Background(), now.Add(time.Second)); res != (Result{}) {
+		t.Fatalf("flushed too early: %+v", res)
+	}
+
+	res, err = w.Flush(context.Background(), now.Add(Backoff(1)))
+	if err != nil || res != (Result{Sent: 1}) {
+		t.Fatalf("second flush: %+v %v", res, err)
+	}
+	if pending, _ := q.Pending(); len(pending) != 0 {
+		t.Fatalf("delivered entry still queued: %+v", pending)
+	}
+
+	req := recv.requests[1]
+	if got := req.Header.Get(SignatureHeader); got != Sign("s3cret", []byte(recv.bodies[1])) {
+		t.Fatalf("signature = %q", got)
+	}
+	if req.Header.Get("X-Taskflow-Event") != "task.completed" || req.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("headers = %v", req.Header)
+	}
+	if recv.bodies[0] != recv.bodies[1] || !strings.Contains(recv.bodies[1], `"title":"Ship"`) {
+		t.Fatalf("bodies = %q", recv.bodies)
+	}
+}
+
+func TestWorkerGivesUp(t *testing.T) {
+	recv := &receiver{fail: 100}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	q := NewQueue(t.TempDir())
+	w := &Worker{Queue: q, Endpoints: []Endpoint{{Name: "ci", URL: srv.URL}}, Client: srv.Client(), MaxAttempts: 2}
+	_ = q.Enqueue(w.Endpoints, Change{TaskCreated, models.Task{ID: "1"}}, now)
+	// An entry for an endpoint removed from the config fails at once.
+	_ = q.Enqueue([]Endpoint{{Name: "gone", URL: srv.URL}}, Change{TaskCreated, models.Task{ID: "2"}}, now)
+
+	if res, _ := w.Flush(context.Background(), now); res != (Result{Retrying: 1, Failed: 1}) {
+		t.Fatalf("first flush: %+v", res)
+	}
+	if res, _ := w.Flush(context.Background(), now.Add(time.Hour)); res != (Result{Failed: 1}) {
+		t.Fatalf("second flush: %+v", res)
+	}
+	failed, _ := q.Failed()
+	pending, _ := q.Pending()
+	if len(failed) != 2 || len(pending) != 0 {
+		t.Fatalf("failed %+v, pending %+v", failed, pending)
+	}
+	if len(recv.requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(recv.requests))
+	}
+}
+
+func TestTickQueuesOverdueOnce(t *testing.T) {
+	recv := &receiver{}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	all := []models.Task{
+		{ID: "late", Title: "Late", Status: "to-do", DueDate: "2025-09-30"},
+		{ID: "done", Title: "Done", Status: "done", DueDate: "2025-09-30"},
+		{ID: "future", Title: "Future", Status: "to-do", DueDate: "2025-10-02T00:00:00Z"},
+	}
+	w := &Worker{
+		Queue:     NewQueue(t.TempDir()),
+		Endpoints: []Endpoint{{Name: "slack", URL: srv.URL, Format: FormatSlack}},
+		Client:    srv.Client(),
+		ReadTasks: func() ([]models.Task, error) { return all, nil },
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := w.Tick(context.Background(), now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(recv.bodies) != 1 || !strings.Contains(recv.bodies[0], "Task overdue: *Late*") {
+		t.Fatalf("bodies = %q", recv.bodies)
+	}
+
+	// Rescheduling and missing the new due date reports again.
+	all[0].DueDate = "2025-10-01T06:00:00Z"
+	if _, err := w.Tick(context.Background(), now); err != nil {
+		t.Fatal(err)
+	}
+	if len(recv.bodies) != 2 {
+		t.Fatalf("expected a second overdue event, got %q", recv.bodies)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 4: 4 * time.Minute, 20: time.Hour} {
+		if got := Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"taskflow/internal/models"
+	"time"
+)
+
+// DefaultMaxAttempts is used when Worker.MaxAttempts is not set.
+const DefaultMaxAttempts = 8
+
+// Worker delivers queued webhooks and queues overdue events.
+type Worker struct {
+	Queue     *Queue
+	Endpoints []Endpoint
+	// ReadTasks returns the current tasks for the overdue check; nil skips
+	// it.
+	ReadTasks   func() ([]models.Task, error)
+	Client      *http.Client // nil uses a client with a 10s timeout
+	MaxAttempts int
+	// Log receives one line per delivery attempt; nil discards them.
+	Log io.Writer
+}
+
+// Result counts the outcome of a Flush.
+type Result struct {
+	Sent, Retrying, Failed int
+}
+
+// Run calls Tick every interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if _, err := w.Tick(ctx, time.Now()); err != nil {
+			w.logf("webhooks: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Tick queues overdue events, then delivers what is due.
+func (w *Worker) Tick(ctx context.Context, now time.Time) (Result, error) {
+	if w.ReadTasks != nil {
+		all, err := w.ReadTasks()
+		if err != nil {
+			return Result{}, err
+		}
+		if err := w.Queue.QueueOverdue(w.Endpoints, all, now); err != nil {
+			return Result{}, err
+		}
+	}
+	return w.Flush(ctx, now)
+}
+
+// Flush sends the pending deliveries whose next attempt is due, oldest
+// first. Deliveries that fail are rescheduled (see Backoff) until they have
+// had MaxAttempts, then kept under failed/.
+func (w *Worker) Flush(ctx context.Context, now time.Time) (Result, error) {
+	var res Result
+	pending, err := w.Queue.Pending()
+	if err != nil {
+		return res, err
+	}
+	maxAttempts := w.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	for _, d := range pending {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		if d.NextAttempt.After(now) {
+			continue
+		}
+		var sendErr error
+		limit := maxAttempts
+		if e, ok := w.endpoint(d.Endpoint); ok {
+			sendErr = w.send(ctx, e, d)
+		} else {
+			sendErr = fmt.Errorf("endpoint %s is no longer configured", d.Endpoint)
+			limit = d.Attempts + 1 // give up now
+		}
+		if sendErr == nil {
+			w.logf("webhooks: %s %s -> %s: delivered\n", d.Event, d.TaskID, d.Endpoint)
+			res.Sent++
+			if err := w.Queue.Done(d); err != nil {
+				return res, err
+			}
+			continue
+		}
+		w.logf("webhooks: %s %s -> %s: %v\n", d.Event, d.TaskID, d.Endpoint, sendErr)
+		if d.Attempts+1 >= limit {
+			res.Failed++
+		} else {
+			res.Retrying++
+		}
+		if err := w.Queue.Retry(d, sendErr, now, limit); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func (w *Worker) endpoint(name string) (Endpoint, bool) {
+	for _, e := range w.Endpoints {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+func (w *Worker) send(ctx context.Context, e Endpoint, d Delivery) error {
+	body := []byte(d.Body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "taskflow-webhooks")
+	req.Header.Set("X-Taskflow-Event", string(d.Event))
+	req.Header.Set("X-Taskflow-Delivery", d.ID)
+	if e.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(e.Secret, body))
+	}
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		if s := bytes.TrimSpace(msg); len(s) > 0 {
+			return fmt.Errorf("%s: %s", resp.Status, s)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+func (w *Worker) logf(format string, args ...any) {
+	if w.Log != nil {
+		fmt.Fprintf(w.Log, format, args...)
+	}
+}
@@ -0,0 +1,362 @@
+// Package workflow describes the statuses and priorities tasks move through
+// and which status changes are allowed. The built-in default matches the
+// classic to-do → in-progress → on-hold → done flow; teams can replace it
+// in config (see config.GetWorkflow).
+package workflow
+
+import (
+	"fmt"
+	"strings"
+	"taskflow/internal/models"
+)
+
+// Status is one step of the workflow. Statuses are listed in workflow order;
+// the first one is given to new tasks.
+type Status struct {
+	Name     string   `mapstructure:"name" yaml:"name"`
+	Terminal bool     `mapstructure:"terminal" yaml:"terminal,omitempty"` // the task is finished (completed_at, archive)
+	Active   bool     `mapstructure:"active" yaml:"active,omitempty"`     // work is under way (started_at, timers)
+	Color    string   `mapstructure:"color" yaml:"color,omitempty"`       // ANSI 256 number or hex, for the TUI
+	Aliases  []string `mapstructure:"aliases" yaml:"aliases,omitempty"`   // other spellings accepted for this status
+}
+
+// Priority is one priority level. Lower ranks are more urgent.
+type Priority struct {
+	Name  string `mapstructure:"name" yaml:"name"`
+	Rank  int    `mapstructure:"rank" yaml:"rank"`
+	Color string `mapstructure:"color" yaml:"color,omitempty"`
+}
+
+// Workflow is an ordered set of statuses and priorities with the allowed
+// transitions between statuses.
+type Workflow struct {
+	Statuses   []Status
+	Priorities []Priority
+	// Transitions maps a status to the statuses it may move to. A status
+	// without an entry may move anywhere.
+	Transitions map[string][]string
+	// Enforce rejects writes that use unknown values or disallowed
+	// transitions. It is set for configured workflows; the default accepts
+	// legacy values so existing files keep working.
+	Enforce bool
+}
+
+// Default returns the built-in workflow.
+func Default() *Workflow {
+	return &Workflow{
+		Statuses: []Status{
+			{Name: "to-do", Aliases: []string{"todo"}},
+			{Name: "in-progress", Active: true},
+			{Name: "on-hold"},
+			{Name: "done", Terminal: true},
+		},
+		Priorities: []Priority{
+			{Name: "high", Rank: 1},
+			{Name: "medium", Rank: 2},
+			{Name: "low", Rank: 3},
+		},
+	}
+}
+
+var current = Default()
+
+// Current returns the workflow in use.
+func Current() *Workflow { return current }
+
+// Use makes w the workflow in use. A nil w restores the default.
+func Use(w *Workflow) {
+	if w == nil {
+		w = Default()
+	}
+	current = w
+}
+
+// Validate checks that the workflow definition itself is usable.
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow needs at least one status")
+	}
+	seen := map[string]bool{}
+	terminal := false
+	for _, s := range w.Statuses {
+		for _, n := range append([]string{s.Name}, s.Aliases...) {
+			key := strings.ToLower(strings.TrimSpace(n))
+			if key == "" {
+				return fmt.Errorf("workflow status without a name")
+			}
+			if seen[key] {
+				return fmt.Errorf("workflow status %q is defined twice", n)
+			}
+			seen[key] = true
+		}
+		terminal = terminal || s.Terminal
+	}
+	if !terminal {
+		return fmt.Errorf("workflow needs a terminal status (terminal: true)")
+	}
+	if len(w.Priorities) == 0 {
+		return fmt.Errorf("workflow needs at least one priority")
+	}
+	seenPrio := map[string]bool{}
+	for _, p := range w.Priorities {
+		key := strings.ToLower(strings.TrimSpace(p.Name))
+		if key == "" {
+			return fmt.Errorf("workflow priority without a name")
+		}
+		if seenPrio[key] {
+			return fmt.Errorf("workflow priority %q is defined twice", p.Name)
+		}
+		seenPrio[key] = true
+	}
+	for from, tos := range w.Transitions {
+		if _, ok := w.Status(from); !ok {
+			return fmt.Errorf("transition from unknown status %q", from)
+		}
+		for _, to := range tos {
+			if _, ok := w.Status(to); !ok {
+				return fmt.Errorf("transition from %q to unknown status %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// Status looks up a status by name or alias, case-insensitively.
+func (w *Workflow) Status(name string) (Status, bool) {
+	i := w.statusIndex(name)
+	if i < 0 {
+		return Status{}, false
+	}
+	return w.Statuses[i], true
+}
+
+func (w *Workflow) statusIndex(name string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, s := range w.Statuses {
+		if strings.ToLower(s.Name) == name {
+			return i
+		}
+		for _, a := range s.Aliases {
+			if strings.ToLower(a) == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Priority looks up a priority by name, case-insensitively.
+func (w *Workflow) Priority(name string) (Priority, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, p := range w.Priorities {
+		if strings.ToLower(p.Name) == name {
+			return p, true
+		}
+	}
+	return Priority{}, false
+}
+
+// StatusNames returns the status names in workflow order.
+func (w *Workflow) StatusNames() []string {
+	names := make([]string, len(w.Statuses))
+	for i, s := range w.Statuses {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// PriorityNames returns the priority names, most urgent first.
+func (w *Workflow) PriorityNames() []string {
+	names := make([]string, 0, len(w.Priorities))
+	for _, p := range w.sortedPriorities() {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func (w *Workflow) sortedPriorities() []Priority {
+	ps := append([]Priority(nil), w.Priorities...)
+	for i := 1; i < len(ps); i++ { // insertion sort keeps config order for equal ranks
+		for j := i; j > 0 && ps[j].Rank < ps[j-1].Rank; j-- {
+			ps[j], ps[j-1] = ps[j-1], ps[j]
+		}
+	}
+	return ps
+}
+
+// Canonical returns the configured spelling of status, or status unchanged
+// when it is unknown.
+func (w *Workflow) Canonical(status string) string {
+	if s, ok := w.Status(status); ok {
+		return s.Name
+	}
+	return status
+}
+
+// SameStatus reports whether a and b name the same status.
+func (w *Workflow) SameStatus(a, b string) bool {
+	return strings.EqualFold(w.Canonical(a), w.Canonical(b))
+}
+
+// Initial is the status given to new tasks.
+func (w *Workflow) Initial() string { return w.Statuses[0].Name }
+
+// IsInitial reports whether status is the initial status (or empty).
+func (w *Workflow) IsInitial(status string) bool {
+	return strings.TrimSpace(status) == "" || w.statusIndex(status) == 0
+}
+
+// IsTerminal reports whether status marks a task as finished.
+func (w *Workflow) IsTerminal(status string) bool {
+	s, ok := w.Status(status)
+	return ok && s.Terminal
+}
+
+// IsActive reports whether status means work on the task is under way.
+func (w *Workflow) IsActive(status string) bool {
+	s, ok := w.Status(status)
+	return ok && s.Active
+}
+
+// Done is the first terminal status, used to complete tasks.
+func (w *Workflow) Done() string {
+	for _, s := range w.Statuses {
+		if s.Terminal {
+			return s.Name
+		}
+	}
+	return w.Statuses[len(w.Statuses)-1].Name
+}
+
+// Started is the first active status, used when a timer starts. It falls
+// back to the status after the initial one.
+func (w *Workflow) Started() string {
+	for _, s := range w.Statuses {
+		if s.Active {
+			return s.Name
+		}
+	}
+	if len(w.Statuses) > 1 {
+		return w.Statuses[1].Name
+	}
+	return w.Statuses[0].Name
+}
+
+// DefaultPriority is the middle priority, given to new tasks.
+func (w *Workflow) DefaultPriority() string {
+	ps := w.sortedPriorities()
+	return ps[len(ps)/2].Name
+}
+
+// StatusRank is the position of status in the workflow; unknown statuses
+// rank after all known ones.
+func (w *Workflow) StatusRank(status string) int {
+	if i := w.statusIndex(status); i >= 0 {
+		return i
+	}
+	return len(w.Statuses)
+}
+
+// PriorityRank is the position of priority from most to least urgent;
+// unknown priorities rank after all known ones.
+func (w *Workflow) PriorityRank(priority string) int {
+	for i, p := range w.sortedPriorities() {
+		if strings.EqualFold(p.Name, strings.TrimSpace(priority)) {
+			return i
+		}
+	}
+	return len(w.Priorities)
+}
+
+// PriorityWeight is the numeric weight of priority: the least urgent level
+// weighs 1, each more urgent level one more, unknown values 0.
+func (w *Workflow) PriorityWeight(priority string) int {
+	r := w.PriorityRank(priority)
+	if r >= len(w.Priorities) {
+		return 0
+	}
+	return len(w.Priorities) - r
+}
+
+// CanTransition reports whether a task may move from one status to another.
+// Staying put is always allowed, as is leaving an unknown status.
+func (w *Workflow) CanTransition(from, to string) bool {
+	if w.SameStatus(from, to) {
+		return true
+	}
+	fromIdx := w.statusIndex(from)
+	if fromIdx < 0 && strings.TrimSpace(from) != "" {
+		return true
+	}
+	if fromIdx < 0 {
+		fromIdx = 0
+	}
+	for key, allowed := range w.Transitions {
+		if w.statusIndex(key) != fromIdx {
+			continue
+		}
+		for _, a := range allowed {
+			if w.SameStatus(a, to) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Next returns the status after current in workflow order that current may
+// move to, wrapping around; current itself when there is none.
+func (w *Workflow) Next(current string) string {
+	i := w.statusIndex(current)
+	for step := 1; step <= len(w.Statuses); step++ {
+		cand := w.Statuses[(i+step+len(w.Statuses))%len(w.Statuses)].Name
+		if w.CanTransition(current, cand) && !w.SameStatus(cand, current) {
+			return cand
+		}
+	}
+	return current
+}
+
+// CheckStatus validates a status value.
+func (w *Workflow) CheckStatus(status string) error {
+	if _, ok := w.Status(status); !ok {
+		return fmt.Errorf("unknown status %q (want one of %s)", status, strings.Join(w.StatusNames(), ", "))
+	}
+	return nil
+}
+
+// CheckPriority validates a priority value.
+func (w *Workflow) CheckPriority(priority string) error {
+	if _, ok := w.Priority(priority); !ok {
+		return fmt.Errorf("unknown priority %q (want one of %s)", priority, strings.Join(w.PriorityNames(), ", "))
+	}
+	return nil
+}
+
+// CheckTask validates a write of t over its previous version prev (nil for
+// a new task). Only values that change are checked, so legacy data already
+// on disk does not block unrelated edits. It is a no-op unless Enforce is set.
+func (w *Workflow) CheckTask(prev *models.Task, t models.Task) error {
+	if !w.Enforce {
+		return nil
+	}
+	var prevStatus, prevPriority string
+	if prev != nil {
+		prevStatus, prevPriority = prev.Status, prev.Priority
+	}
+	if prev == nil || t.Status != prevStatus {
+		if err := w.CheckStatus(t.Status); err != nil {
+			return fmt.Errorf("task %s: %w", t.ID, err)
+		}
+		if prev != nil && !w.CanTransition(prevStatus, t.Status) {
+			return fmt.Errorf("task %s: status cannot change from %q to %q", t.ID, prevStatus, t.Status)
+		}
+	}
+	if t.Priority != "" && (prev == nil || t.Priority != prevPriority) {
+		if err := w.CheckPriority(t.Priority); err != nil {
+			return fmt.Errorf("task %s: %w", t.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"reflect"
+	"strings"
+	"taskflow/internal/models"
+	"testing"
+)
+
+func review() *Workflow {
+	return &Workflow{
+		Statuses: []Status{
+			{Name: "backlog"},
+			{Name: "doing", Active: true},
+			{Name: "review"},
+			{Name: "shipped", Terminal: true, Aliases: []string{"released"}},
+		},
+		Priorities: []Priority{{Name: "p2", Rank: 2}, {Name: "p1", Rank: 1}, {Name: "p3", Rank: 3}},
+		Transitions: map[string][]string{
+			"backlog": {"doing"},
+			"doing":   {"review", "backlog"},
+			"review":  {"doing", "shipped"},
+		},
+		Enforce: true,
+	}
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	wf := Default()
+	if err := wf.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if wf.Initial() != "to-do" || wf.Done() != "done" || wf.Started() != "in-progress" || wf.DefaultPriority() != "medium" {
+		t.Fatalf("unexpected defaults: %s %s %s %s", wf.Initial(), wf.Done(), wf.Started(), wf.DefaultPriority())
+	}
+	if !wf.SameStatus("todo", "to-do") || wf.StatusRank("todo") != 0 || wf.StatusRank("bogus") != 4 {
+		t.Fatal("legacy todo alias not resolved")
+	}
+	if wf.PriorityWeight("high") != 3 || wf.PriorityWeight("low") != 1 || wf.PriorityWeight("") != 0 {
+		t.Fatalf("weights: %d %d", wf.PriorityWeight("high"), wf.PriorityWeight("low"))
+	}
+	if wf.Next("on-hold") != "done" || wf.Next("done") != "to-do" || wf.Next("weird") != "to-do" {
+		t.Fatal("default cycle wrong")
+	}
+	if err := wf.CheckTask(nil, models.Task{Status: "weird", Priority: "urgent"}); err != nil {
+		t.Fatalf("default workflow should not enforce: %v", err)
+	}
+}
+
+func TestCustomWorkflow(t *testing.T) {
+	wf := review()
+	if err := wf.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := wf.PriorityNames(); !reflect.DeepEqual(got, []string{"p1", "p2", "p3"}) {
+		t.Fatalf("priorities not ordered by rank: %v", got)
+	}
+	if !wf.IsTerminal("Released") || wf.Canonical("released") != "shipped" {
+		t.Fatal("alias not resolved")
+	}
+	if wf.CanTransition("backlog", "shipped") || !wf.CanTransition("review", "shipped") || !wf.CanTransition("shipped", "backlog") {
+		t.Fatal("transitions not applied")
+	}
+	// Next skips statuses the current one may not move to.
+	if wf.Next("backlog") != "doing" || wf.Next("doing") != "review" || wf.Next("review") != "shipped" {
+		t.Fatal("cycle should follow allowed transitions")
+	}
+
+	prev := &models.Task{ID: "1", Status: "backlog", Priority: "legacy"}
+	if err := wf.CheckTask(prev, models.Task{ID: "1", Status: "shipped", Priority: "legacy"}); err == nil || !strings.Contains(err.Error(), "cannot change") {
+		t.Fatalf("expected transition error, got %v", err)
+	}
+	if err := wf.CheckTask(prev, models.Task{ID: "1", Status: "backlog", Priority: "legacy", Title: "x"}); err != nil {
+		t.Fatalf("unchanged legacy values should pass: %v", err)
+	}
+	if err := wf.CheckTask(nil, models.Task{ID: "2", Status: "backlog", Priority: "p9"}); err == nil {
+		t.Fatal("expected unknown priority error")
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	cases := map[string]*Workflow{
+		"at least one status": {Priorities: []Priority{{Name: "p"}}},
+		"terminal status":     {Statuses: []Status{{Name: "a"}}, Priorities: []Priority{{Name: "p"}}},
+		"defined twice":       {Statuses: []Status{{Name: "a", Terminal: true}, {Name: "b", Aliases: []string{"A"}}}, Priorities: []Priority{{Name: "p"}}},
+		"unknown status":      {Statuses: []Status{{Name: "a", Terminal: true}}, Priorities: []Priority{{Name: "p"}}, Transitions: map[string][]string{"a": {"z"}}},
+		"priority":            {Statuses: []Status{{Name: "a", Terminal: true}}},
+	}
+	for want, wf := range cases {
+		if err := wf.Validate(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q error, got %v", want, err)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"os"
 	"taskflow/cmd"
 	"taskflow/internal/config"
-	"taskflow/internal/plugin"
 	_ "taskflow/internal/plugins/hello" // Import to register the plugin
 )
 
@@ -16,10 +15,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize plugins
-	for _, p := range plugin.GetAllPlugins() {
-		p.Init(cmd.RootCmd)
-	}
+	// Register external plugins (taskflow-<name> executables), then
+	// initialize all plugins
+	cmd.RegisterExternalPlugins()
+	cmd.InitPlugins()
 
 	cmd.Execute()
 }